@@ -22,7 +22,6 @@ import (
 	"io"
 	"math/big"
 
-	walletmldsa87 "github.com/theQRL/go-qrllib/wallet/ml_dsa_87"
 	"github.com/theQRL/go-zond/accounts"
 	"github.com/theQRL/go-zond/accounts/external"
 	"github.com/theQRL/go-zond/accounts/keystore"
@@ -83,8 +82,8 @@ func NewKeyStoreTransactorWithChainID(keystore *keystore.KeyStore, account accou
 }
 
 // NewKeyedTransactorWithChainID is a utility method to easily create a transaction signer
-// from a single private key.
-func NewKeyedTransactorWithChainID(w *walletmldsa87.Wallet, chainID *big.Int) (*TransactOpts, error) {
+// from a single private key of any supported signature scheme.
+func NewKeyedTransactorWithChainID(w pqcrypto.Wallet, chainID *big.Int) (*TransactOpts, error) {
 	keyAddr := w.GetAddress()
 	if chainID == nil {
 		return nil, ErrNoChainID
@@ -100,9 +99,7 @@ func NewKeyedTransactorWithChainID(w *walletmldsa87.Wallet, chainID *big.Int) (*
 			if err != nil {
 				return nil, err
 			}
-			pk := w.GetPK()
-			desc := w.GetDescriptor().ToDescriptor().ToBytes()
-			return tx.WithSignaturePublicKeyAndDescriptor(signer, signature, pk[:], desc)
+			return tx.WithSignaturePublicKeyAndDescriptor(signer, signature, w.GetPK(), w.GetDescriptor().ToBytes())
 		},
 		Context: context.Background(),
 	}, nil
@@ -24,7 +24,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	walletmldsa87 "github.com/theQRL/go-qrllib/wallet/ml_dsa_87"
+	"github.com/theQRL/go-qrllib/wallet/common/wallettype"
 	"github.com/theQRL/go-zond/accounts"
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/crypto/pqcrypto"
@@ -40,7 +40,7 @@ type Key struct {
 	Address common.Address
 	// we only store seed as pubkey/address & private key can be derived from it
 	// seed in this struct is always in plaintext
-	Wallet *walletmldsa87.Wallet
+	Wallet pqcrypto.Wallet
 }
 
 type keyStore interface {
@@ -78,11 +78,22 @@ type cipherparamsJSON struct {
 	IV string `json:"iv"`
 }
 
+// keySeed returns the secret material persisted for a wallet. ML-DSA-87 keys
+// store the bare seed to stay compatible with existing key files, all other
+// schemes store the extended seed so the scheme can be recovered on load.
+func keySeed(w pqcrypto.Wallet) []byte {
+	if wallettype.WalletType(w.GetDescriptor().Type()) == wallettype.ML_DSA_87 {
+		seed := w.GetSeed()
+		return seed[:]
+	}
+	extendedSeed := w.GetExtendedSeed()
+	return extendedSeed[:]
+}
+
 func (k *Key) MarshalJSON() (j []byte, err error) {
-	seed := k.Wallet.GetSeed()
 	jStruct := plainKeyJSON{
 		fmt.Sprintf("%#x", k.Address),
-		common.Bytes2Hex(seed[:]),
+		common.Bytes2Hex(keySeed(k.Wallet)),
 		k.Id.String(),
 		version,
 	}
@@ -117,7 +128,7 @@ func (k *Key) UnmarshalJSON(j []byte) (err error) {
 	return nil
 }
 
-func newKeyFromMLDSA87(w *walletmldsa87.Wallet) *Key {
+func newKeyFromWallet(w pqcrypto.Wallet) *Key {
 	id, err := uuid.NewRandom()
 	if err != nil {
 		panic(fmt.Sprintf("Could not create random uuid: %v", err))
@@ -131,11 +142,11 @@ func newKeyFromMLDSA87(w *walletmldsa87.Wallet) *Key {
 }
 
 func newKey() (*Key, error) {
-	d, err := walletmldsa87.NewWallet()
+	w, err := pqcrypto.GenerateWalletKey()
 	if err != nil {
 		return nil, err
 	}
-	return newKeyFromMLDSA87(d), nil
+	return newKeyFromWallet(w), nil
 }
 
 func storeNewKey(ks keyStore, auth string) (*Key, accounts.Account, error) {
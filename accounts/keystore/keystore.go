@@ -30,7 +30,6 @@ import (
 	"sync"
 	"time"
 
-	walletmldsa87 "github.com/theQRL/go-qrllib/wallet/ml_dsa_87"
 	"github.com/theQRL/go-zond/accounts"
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/core/types"
@@ -248,7 +247,8 @@ func (ks *KeyStore) Delete(a accounts.Account, passphrase string) error {
 	return err
 }
 
-// SignHash returns the ML-DSA-87 signature for the given hash.
+// SignHash returns the signature for the given hash, using the signature scheme
+// of the account's key.
 func (ks *KeyStore) SignHash(a accounts.Account, hash []byte) ([]byte, error) {
 	// Look up the key to sign with and abort if it cannot be found
 	ks.mu.RLock()
@@ -259,9 +259,7 @@ func (ks *KeyStore) SignHash(a accounts.Account, hash []byte) ([]byte, error) {
 		return nil, ErrLocked
 	}
 
-	signature, err := unlockedKey.Wallet.Sign(hash)
-
-	return signature[:], err
+	return unlockedKey.Wallet.Sign(hash)
 }
 
 func (ks *KeyStore) GetPublicKey(a accounts.Account) ([]byte, error) {
@@ -274,9 +272,7 @@ func (ks *KeyStore) GetPublicKey(a accounts.Account) ([]byte, error) {
 		return nil, ErrLocked
 	}
 
-	pk := unlockedKey.Wallet.GetPK()
-
-	return pk[:], nil
+	return unlockedKey.Wallet.GetPK(), nil
 }
 
 func (ks *KeyStore) GetDescriptor(a accounts.Account) ([]byte, error) {
@@ -289,9 +285,7 @@ func (ks *KeyStore) GetDescriptor(a accounts.Account) ([]byte, error) {
 		return nil, ErrLocked
 	}
 
-	desc := unlockedKey.Wallet.GetDescriptor()
-
-	return desc[:], nil
+	return unlockedKey.Wallet.GetDescriptor().ToBytes(), nil
 }
 
 // SignTx signs the given transaction with the requested account.
@@ -477,12 +471,12 @@ func (ks *KeyStore) Import(keyJSON []byte, passphrase, newPassphrase string) (ac
 	return ks.importKey(key, newPassphrase)
 }
 
-// ImportMLDSA87 stores the given key into the key directory, encrypting it with the passphrase.
-func (ks *KeyStore) ImportMLDSA87(w *walletmldsa87.Wallet, passphrase string) (accounts.Account, error) {
+// ImportWallet stores the given key into the key directory, encrypting it with the passphrase.
+func (ks *KeyStore) ImportWallet(w pqcrypto.Wallet, passphrase string) (accounts.Account, error) {
 	ks.importMu.Lock()
 	defer ks.importMu.Unlock()
 
-	key := newKeyFromMLDSA87(w)
+	key := newKeyFromWallet(w)
 	if ks.cache.hasAddress(key.Address) {
 		return accounts.Account{
 			Address: key.Address,
@@ -518,7 +512,7 @@ func (ks *KeyStore) isUpdating() bool {
 	return ks.updating
 }
 
-// zeroKey nil to ML-DSA-87 key in memory.
-func zeroKey(k **walletmldsa87.Wallet) {
+// zeroKey drops the reference to the key in memory.
+func zeroKey(k *pqcrypto.Wallet) {
 	*k = nil
 }
@@ -27,6 +27,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/theQRL/go-qrllib/wallet/common/wallettype"
 	"github.com/theQRL/go-zond/accounts"
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/crypto/pqcrypto"
@@ -346,17 +347,52 @@ func TestImportECDSA(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to generate key: %v", key)
 	}
-	if _, err = ks.ImportMLDSA87(key, "old"); err != nil {
+	if _, err = ks.ImportWallet(key, "old"); err != nil {
 		t.Errorf("importing failed: %v", err)
 	}
-	if _, err = ks.ImportMLDSA87(key, "old"); err == nil {
+	if _, err = ks.ImportWallet(key, "old"); err == nil {
 		t.Errorf("importing same key twice succeeded")
 	}
-	if _, err = ks.ImportMLDSA87(key, "new"); err == nil {
+	if _, err = ks.ImportWallet(key, "new"); err == nil {
 		t.Errorf("importing same key twice succeeded")
 	}
 }
 
+// TestImportExportSphincsPlus tests that keys of schemes other than ML-DSA-87
+// survive an import, sign and export round trip.
+func TestImportExportSphincsPlus(t *testing.T) {
+	_, ks := tmpKeyStore(t)
+	key, err := pqcrypto.GenerateWallet(wallettype.SPHINCSPLUS_256S)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	acc, err := ks.ImportWallet(key, "old")
+	if err != nil {
+		t.Fatalf("importing failed: %v", err)
+	}
+	if acc.Address != key.GetAddress() {
+		t.Fatalf("account address mismatch: have %x want %x", acc.Address, key.GetAddress())
+	}
+	sig, err := ks.SignHashWithPassphrase(acc, "old", testSigData)
+	if err != nil {
+		t.Fatalf("signing failed: %v", err)
+	}
+	if !pqcrypto.Verify(testSigData, sig, key.GetPK(), key.GetDescriptor()) {
+		t.Fatal("signature verification failed")
+	}
+	json, err := ks.Export(acc, "old", "new")
+	if err != nil {
+		t.Fatalf("failed to export account: %v", err)
+	}
+	decrypted, err := DecryptKey(json, "new")
+	if err != nil {
+		t.Fatalf("failed to decrypt exported key: %v", err)
+	}
+	if decrypted.Address != acc.Address || decrypted.Wallet.GetDescriptor() != key.GetDescriptor() {
+		t.Error("exported key does not match imported key")
+	}
+}
+
 // TestImportECDSA tests the import and export functionality of a keystore.
 func TestImportExport(t *testing.T) {
 	_, ks := tmpKeyStore(t)
@@ -35,6 +35,7 @@ type PayloadAttributes struct {
 	Random                common.Hash         `json:"prevRandao"            gencodec:"required"`
 	SuggestedFeeRecipient common.Address      `json:"suggestedFeeRecipient" gencodec:"required"`
 	Withdrawals           []*types.Withdrawal `json:"withdrawals"`
+	BeaconRoot            *common.Hash        `json:"parentBeaconBlockRoot"`
 }
 
 // JSON type overrides for PayloadAttributes.
@@ -45,6 +46,11 @@ type payloadAttributesMarshaling struct {
 //go:generate go run github.com/fjl/gencodec -type ExecutableData -field-override executableDataMarshaling -out gen_ed.go
 
 // ExecutableData is the data necessary to execute an EL payload.
+//
+// The same payload is used by the V2 and V3 engine API methods. V3 only adds
+// the parent beacon block root, which is passed next to the payload instead of
+// inside it, and there are no blob fields on this chain to extend it with. The
+// withdrawals are mandatory in both versions, which the API methods enforce.
 type ExecutableData struct {
 	ParentHash    common.Hash         `json:"parentHash"    gencodec:"required"`
 	FeeRecipient  common.Address      `json:"feeRecipient"  gencodec:"required"`
@@ -77,10 +83,14 @@ type executableDataMarshaling struct {
 
 //go:generate go run github.com/fjl/gencodec -type ExecutionPayloadEnvelope -field-override executionPayloadEnvelopeMarshaling -out gen_epe.go
 
+// ExecutionPayloadEnvelope is the payload returned by the getPayload methods of
+// the engine API. As the payload, it is shared between V2 and V3: the beacon
+// root a V3 payload was built on is known to the consensus client already.
 type ExecutionPayloadEnvelope struct {
 	ExecutionPayload *ExecutableData `json:"executionPayload"  gencodec:"required"`
 	BlockValue       *big.Int        `json:"blockValue"  gencodec:"required"`
 	Override         bool            `json:"shouldOverrideBuilder"`
+	Revenue          *PayloadRevenue `json:"revenue,omitempty"`
 }
 
 // JSON type overrides for ExecutionPayloadEnvelope.
@@ -88,6 +98,22 @@ type executionPayloadEnvelopeMarshaling struct {
 	BlockValue *hexutil.Big
 }
 
+//go:generate go run github.com/fjl/gencodec -type PayloadRevenue -field-override payloadRevenueMarshaling -out gen_revenue.go
+
+// PayloadRevenue is the breakdown of the fees collected by a locally built
+// payload, along with the strategy its transactions were selected with.
+type PayloadRevenue struct {
+	Tips      *big.Int `json:"tips"       gencodec:"required"` // Priority fees paid to the fee recipient
+	BurntFees *big.Int `json:"burntFees"  gencodec:"required"` // Base fees burnt by the included transactions
+	Ordering  string   `json:"ordering"`                       // Transaction ordering strategy of the payload
+}
+
+// JSON type overrides for PayloadRevenue.
+type payloadRevenueMarshaling struct {
+	Tips      *hexutil.Big
+	BurntFees *hexutil.Big
+}
+
 type PayloadStatusV1 struct {
 	Status          string       `json:"status"`
 	LatestValidHash *common.Hash `json:"latestValidHash"`
@@ -152,7 +178,9 @@ func decodeTransactions(enc [][]byte) ([]*types.Transaction, error) {
 // and that the blockhash of the constructed block matches the parameters. Nil
 // Withdrawals value will propagate through the returned block. Empty
 // Withdrawals value must be passed via non-nil, length 0 value in params.
-func ExecutableDataToBlock(params ExecutableData) (*types.Block, error) {
+// The beacon root is only set in the header if it is non-nil, that is for
+// V3 payloads.
+func ExecutableDataToBlock(params ExecutableData, beaconRoot *common.Hash) (*types.Block, error) {
 	txs, err := decodeTransactions(params.Transactions)
 	if err != nil {
 		return nil, err
@@ -176,20 +204,21 @@ func ExecutableDataToBlock(params ExecutableData) (*types.Block, error) {
 		withdrawalsRoot = &h
 	}
 	header := &types.Header{
-		ParentHash:      params.ParentHash,
-		Coinbase:        params.FeeRecipient,
-		Root:            params.StateRoot,
-		TxHash:          types.DeriveSha(types.Transactions(txs), trie.NewStackTrie(nil)),
-		ReceiptHash:     params.ReceiptsRoot,
-		Bloom:           types.BytesToBloom(params.LogsBloom),
-		Number:          new(big.Int).SetUint64(params.Number),
-		GasLimit:        params.GasLimit,
-		GasUsed:         params.GasUsed,
-		Time:            params.Timestamp,
-		BaseFee:         params.BaseFeePerGas,
-		Extra:           params.ExtraData,
-		Random:          params.Random,
-		WithdrawalsHash: withdrawalsRoot,
+		ParentHash:       params.ParentHash,
+		Coinbase:         params.FeeRecipient,
+		Root:             params.StateRoot,
+		TxHash:           types.DeriveSha(types.Transactions(txs), trie.NewStackTrie(nil)),
+		ReceiptHash:      params.ReceiptsRoot,
+		Bloom:            types.BytesToBloom(params.LogsBloom),
+		Number:           new(big.Int).SetUint64(params.Number),
+		GasLimit:         params.GasLimit,
+		GasUsed:          params.GasUsed,
+		Time:             params.Timestamp,
+		BaseFee:          params.BaseFeePerGas,
+		Extra:            params.ExtraData,
+		Random:           params.Random,
+		WithdrawalsHash:  withdrawalsRoot,
+		ParentBeaconRoot: beaconRoot,
 	}
 	block := types.NewBlockWithHeader(header).WithBody(types.Body{Transactions: txs, Withdrawals: params.Withdrawals})
 	if block.Hash() != params.BlockHash {
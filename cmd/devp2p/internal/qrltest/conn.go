@@ -52,13 +52,30 @@ func (s *Suite) dialAs(key *ecdsa.PrivateKey) (*Conn, error) {
 		return nil, err
 	}
 	conn.caps = []p2p.Cap{
-		{Name: "eth", Version: 67},
-		{Name: "eth", Version: 68},
+		{Name: qrl.ProtocolName, Version: qrl.QRL1},
+		{Name: qrl.ProtocolName, Version: qrl.QRL2},
 	}
-	conn.ourHighestProtoVersion = 68
+	conn.ourHighestProtoVersion = qrl.QRL2
 	return &conn, nil
 }
 
+// dialQRL creates a connection advertising only the given qrl protocol versions.
+func (s *Suite) dialQRL(versions ...uint) (*Conn, error) {
+	conn, err := s.dial()
+	if err != nil {
+		return nil, fmt.Errorf("dial failed: %v", err)
+	}
+	conn.caps = nil
+	conn.ourHighestProtoVersion = 0
+	for _, version := range versions {
+		conn.caps = append(conn.caps, p2p.Cap{Name: qrl.ProtocolName, Version: version})
+		if version > conn.ourHighestProtoVersion {
+			conn.ourHighestProtoVersion = version
+		}
+	}
+	return conn, nil
+}
+
 // dialSnap creates a connection with snap/1 capability.
 func (s *Suite) dialSnap() (*Conn, error) {
 	conn, err := s.dial()
@@ -266,7 +283,7 @@ func (c *Conn) negotiateEthProtocol(caps []p2p.Cap) {
 	var highestSnapVersion uint
 	for _, capability := range caps {
 		switch capability.Name {
-		case "eth":
+		case qrl.ProtocolName:
 			if capability.Version > highestEthVersion && capability.Version <= c.ourHighestProtoVersion {
 				highestEthVersion = capability.Version
 			}
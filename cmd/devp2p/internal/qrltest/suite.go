@@ -53,6 +53,10 @@ func (s *Suite) QRLTests() []utesting.Test {
 		{Name: "TestZeroRequestID", Fn: s.TestZeroRequestID},
 		// get block bodies
 		{Name: "TestGetBlockBodies", Fn: s.TestGetBlockBodies},
+		// qrl/2 public key elision
+		{Name: "TestQRL2Negotiation", Fn: s.TestQRL2Negotiation},
+		{Name: "TestQRL2PublicKeyElision", Fn: s.TestQRL2PublicKeyElision},
+		{Name: "TestQRL1Fallback", Fn: s.TestQRL1Fallback},
 		// broadcast
 		// {Name: "TestBroadcast", Fn: s.TestBroadcast},
 		// {Name: "TestLargeAnnounce", Fn: s.TestLargeAnnounce},
@@ -337,6 +341,145 @@ func (s *Suite) TestGetBlockBodies(t *utesting.T) {
 	}
 }
 
+// TestQRL2Negotiation tests whether the node negotiates qrl/2 with a peer that
+// supports both qrl/1 and qrl/2.
+func (s *Suite) TestQRL2Negotiation(t *utesting.T) {
+	conn, err := s.dialQRL(qrl.QRL1, qrl.QRL2)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+	if err := conn.peer(s.chain, nil); err != nil {
+		t.Fatalf("peering failed: %v", err)
+	}
+	if conn.negotiatedProtoVersion != qrl.QRL2 {
+		t.Fatalf("wrong protocol version negotiated: have %d, want %d", conn.negotiatedProtoVersion, qrl.QRL2)
+	}
+}
+
+// TestQRL2PublicKeyElision tests whether the node sends the public key of each
+// sender in full the first time over a qrl/2 connection, and elides it from all
+// later transactions of the same sender.
+func (s *Suite) TestQRL2PublicKeyElision(t *utesting.T) {
+	conn, err := s.dialQRL(qrl.QRL2)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+	if err := conn.peer(s.chain, nil); err != nil {
+		t.Fatalf("peering failed: %v", err)
+	}
+	txs, err := conn.getBodyTransactions(s.chain, 1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		sent   = make(map[common.Address]bool)
+		elided int
+	)
+	for i, enc := range txs {
+		kind, _, _, err := rlp.Split(enc)
+		if err != nil {
+			t.Fatalf("tx %d: invalid encoding: %v", i, err)
+		}
+		if kind == rlp.List {
+			var keyless struct {
+				Sender common.Address
+				Tx     []byte
+			}
+			if err := rlp.DecodeBytes(enc, &keyless); err != nil {
+				t.Fatalf("tx %d: invalid keyless transaction: %v", i, err)
+			}
+			if !sent[keyless.Sender] {
+				t.Fatalf("tx %d: public key of %v elided before being sent", i, keyless.Sender)
+			}
+			elided++
+			continue
+		}
+		addr, err := publicKeyAddress(enc)
+		if err != nil {
+			t.Fatalf("tx %d: %v", i, err)
+		}
+		if sent[addr] {
+			t.Fatalf("tx %d: public key of %v sent again", i, addr)
+		}
+		sent[addr] = true
+	}
+	if elided == 0 {
+		t.Fatalf("no public keys elided from %d transactions", len(txs))
+	}
+}
+
+// TestQRL1Fallback tests whether the node falls back to qrl/1 with a peer that
+// doesn't support qrl/2, and never elides public keys over it.
+func (s *Suite) TestQRL1Fallback(t *utesting.T) {
+	conn, err := s.dialQRL(qrl.QRL1)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+	if err := conn.peer(s.chain, nil); err != nil {
+		t.Fatalf("peering failed: %v", err)
+	}
+	if conn.negotiatedProtoVersion != qrl.QRL1 {
+		t.Fatalf("wrong protocol version negotiated: have %d, want %d", conn.negotiatedProtoVersion, qrl.QRL1)
+	}
+	txs, err := conn.getBodyTransactions(s.chain, 1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, enc := range txs {
+		if _, err := publicKeyAddress(enc); err != nil {
+			t.Fatalf("tx %d: %v", i, err)
+		}
+	}
+}
+
+// getBodyTransactions retrieves the bodies of the given range of blocks and
+// returns their transactions as sent over the wire.
+func (c *Conn) getBodyTransactions(chain *Chain, from, count int) ([]rlp.RawValue, error) {
+	req := &qrl.GetBlockBodiesPacket{RequestId: 66}
+	for i := from; i < from+count && i < chain.Len(); i++ {
+		req.GetBlockBodiesRequest = append(req.GetBlockBodiesRequest, chain.blocks[i].Hash())
+	}
+	if err := c.Write(qrlProto, qrl.GetBlockBodiesMsg, req); err != nil {
+		return nil, fmt.Errorf("could not write to connection: %v", err)
+	}
+	resp := new(qrl.BlockBodiesRLPPacket)
+	if err := c.ReadMsg(qrlProto, qrl.BlockBodiesMsg, resp); err != nil {
+		return nil, fmt.Errorf("error reading block bodies msg: %v", err)
+	}
+	if resp.RequestId != req.RequestId {
+		return nil, fmt.Errorf("request id mismatch: have %d, want %d", resp.RequestId, req.RequestId)
+	}
+	var txs []rlp.RawValue
+	for i, enc := range resp.BlockBodiesRLPResponse {
+		var body struct {
+			Transactions []rlp.RawValue
+			Withdrawals  []*types.Withdrawal `rlp:"optional"`
+		}
+		if err := rlp.DecodeBytes(enc, &body); err != nil {
+			return nil, fmt.Errorf("invalid body %d: %v", i, err)
+		}
+		txs = append(txs, body.Transactions...)
+	}
+	return txs, nil
+}
+
+// publicKeyAddress decodes a transaction carrying its public key in full and
+// returns the address of the key.
+func publicKeyAddress(enc rlp.RawValue) (common.Address, error) {
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(enc, tx); err != nil {
+		return common.Address{}, fmt.Errorf("invalid transaction: %v", err)
+	}
+	desc, err := descriptor.FromBytes(tx.RawDescriptorValue())
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid descriptor: %v", err)
+	}
+	return pqcrypto.PKToAddress(tx.RawPublicKeyValue(), desc)
+}
+
 /*
 // TestBroadcast tests whether a block announcement is correctly
 // propagated to the node's peers.
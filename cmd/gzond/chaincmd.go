@@ -98,6 +98,9 @@ if one is set.  Otherwise it prints the genesis from the datadir.`,
 			utils.TransactionHistoryFlag,
 			utils.StateSchemeFlag,
 			utils.StateHistoryFlag,
+			utils.CompactBodiesFlag,
+			utils.VMTraceFlag,
+			utils.VMTraceJsonConfigFlag,
 		}, utils.DatabasePathFlags),
 		Description: `
 The import command imports blocks from an RLP-encoded form. The form can be one file
@@ -122,6 +125,57 @@ Optional second and third arguments control the first and
 last block to write. In this mode, the file will be appended
 if already existing. If the file ends with .gz, the output will
 be gzipped.`,
+	}
+	importHistoryCommand = &cli.Command{
+		Action:    importHistory,
+		Name:      "import-history",
+		Usage:     "Import blockchain history from archive files",
+		ArgsUsage: "<dir>",
+		Flags: flags.Merge([]cli.Flag{
+			utils.CacheFlag,
+			utils.TransactionHistoryFlag,
+			utils.StateSchemeFlag,
+		}, utils.DatabasePathFlags),
+		Description: `
+The import-history command imports the blocks and receipts from the archive
+files in the given directory, as written by export-history. The archives are
+verified against the checksums file and their accumulators before the blocks
+are inserted.
+
+The blocks are not executed, the imported history only seeds the database and
+the state has to be synced afterwards.`,
+	}
+	exportHistoryCommand = &cli.Command{
+		Action:    exportHistory,
+		Name:      "export-history",
+		Usage:     "Export blockchain history to archive files",
+		ArgsUsage: "<dir> <first> <last>",
+		Flags: flags.Merge([]cli.Flag{
+			utils.CacheFlag,
+			utils.StateSchemeFlag,
+		}, utils.DatabasePathFlags),
+		Description: `
+The export-history command writes the blocks and receipts in the given range
+into archive files in the given directory, one file for every epoch of 8192
+blocks. Every archive is indexed for random access by block number and holds
+the accumulator root of its block hashes. The sha256 checksums of the archives
+are written into the checksums.txt file.`,
+	}
+	pruneHistoryCommand = &cli.Command{
+		Action:    pruneHistory,
+		Name:      "prune-history",
+		Usage:     "Prune block bodies and receipts beyond the history retention limit",
+		ArgsUsage: "",
+		Flags: flags.Merge([]cli.Flag{
+			utils.ChainHistoryFlag,
+		}, utils.DatabasePathFlags),
+		Description: `
+The prune-history command drops the bodies and receipts of the frozen blocks
+older than the number of recent blocks given by --history.chain. Headers are
+retained, and the transaction indices of the pruned blocks are deleted.
+
+Pruned blocks can not be served to peers or over RPC anymore, and this is an
+irreversible action.`,
 	}
 	dumpCommand = &cli.Command{
 		Action:    dump,
@@ -341,6 +395,79 @@ func exportChain(ctx *cli.Context) error {
 	return nil
 }
 
+func importHistory(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	chain, db := utils.MakeChain(ctx, stack, false)
+	defer db.Close()
+
+	start := time.Now()
+	err := utils.ImportHistory(chain, ctx.Args().First())
+	chain.Stop()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Import done in %v\n", time.Since(start))
+	return nil
+}
+
+func exportHistory(ctx *cli.Context) error {
+	if ctx.Args().Len() != 3 {
+		utils.Fatalf("usage: %s", ctx.Command.ArgsUsage)
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	chain, _ := utils.MakeChain(ctx, stack, true)
+	start := time.Now()
+
+	first, ferr := strconv.ParseUint(ctx.Args().Get(1), 10, 64)
+	last, lerr := strconv.ParseUint(ctx.Args().Get(2), 10, 64)
+	if ferr != nil || lerr != nil {
+		utils.Fatalf("Export error in parsing parameters: block number not an integer\n")
+	}
+	if err := utils.ExportHistory(chain, ctx.Args().First(), first, last); err != nil {
+		utils.Fatalf("Export error: %v\n", err)
+	}
+	fmt.Printf("Export done in %v\n", time.Since(start))
+	return nil
+}
+
+func pruneHistory(ctx *cli.Context) error {
+	if ctx.Args().Len() != 0 {
+		utils.Fatalf("This command takes no arguments.")
+	}
+	limit := ctx.Uint64(utils.ChainHistoryFlag.Name)
+	if limit == 0 {
+		utils.Fatalf("The history retention limit must be set with --%s.", utils.ChainHistoryFlag.Name)
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, false)
+	defer db.Close()
+
+	head := rawdb.ReadHeadBlock(db)
+	if head == nil {
+		return errors.New("head block is missing")
+	}
+	if head.NumberU64() < limit {
+		log.Info("Chain history is within the retention limit", "head", head.NumberU64(), "limit", limit)
+		return nil
+	}
+	start := time.Now()
+	tail, err := rawdb.PruneChainHistory(db, head.NumberU64()-limit+1, nil)
+	if err != nil {
+		return err
+	}
+	log.Info("Chain history pruned", "tail", tail, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
 func parseDumpConfig(ctx *cli.Context, stack *node.Node) (*state.DumpConfig, qrldb.Database, common.Hash, error) {
 	db := utils.MakeChainDatabase(ctx, stack, true)
 	var header *types.Header
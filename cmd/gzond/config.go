@@ -205,8 +205,8 @@ func makeFullNode(ctx *cli.Context) (*node.Node, qrlapi.Backend) {
 		utils.RegisterFullSyncTester(stack, qrl, common.BytesToHash(hex))
 	}
 
-	// Start the dev mode if requested, or launch the engine API for
-	// interacting with external consensus client.
+	// Start the dev mode or the local follower if requested, or launch the
+	// engine API for interacting with external consensus client.
 	if ctx.IsSet(utils.DeveloperFlag.Name) {
 		simBeacon, err := catalyst.NewSimulatedBeacon(ctx.Uint64(utils.DeveloperPeriodFlag.Name), qrl)
 		if err != nil {
@@ -214,6 +214,8 @@ func makeFullNode(ctx *cli.Context) (*node.Node, qrlapi.Backend) {
 		}
 		catalyst.RegisterSimulatedBeaconAPIs(stack, simBeacon)
 		stack.RegisterLifecycle(simBeacon)
+	} else if ctx.IsSet(utils.FollowFlag.Name) {
+		utils.RegisterLocalFollower(stack, qrl, ctx.String(utils.FollowFlag.Name))
 	} else {
 		err := catalyst.Register(stack, qrl)
 		if err != nil {
@@ -40,6 +40,7 @@ import (
 
 	// Force-load the tracer engines to trigger registration
 	_ "github.com/theQRL/go-zond/qrl/tracers/js"
+	_ "github.com/theQRL/go-zond/qrl/tracers/live"
 	_ "github.com/theQRL/go-zond/qrl/tracers/native"
 	"go.uber.org/automaxprocs/maxprocs"
 
@@ -57,6 +58,7 @@ var (
 		utils.UnlockedAccountFlag,
 		utils.PasswordFileFlag,
 		utils.BootnodesFlag,
+		utils.CompactBodiesFlag,
 		utils.MinFreeDiskSpaceFlag,
 		utils.KeyStoreDirFlag,
 		utils.ExternalSignerFlag,
@@ -68,6 +70,7 @@ var (
 		utils.TxPoolNoLocalsFlag,
 		utils.TxPoolJournalFlag,
 		utils.TxPoolRejournalFlag,
+		utils.TxPoolPersistFlag,
 		utils.TxPoolPriceLimitFlag,
 		utils.TxPoolPriceBumpFlag,
 		utils.TxPoolAccountSlotsFlag,
@@ -75,14 +78,19 @@ var (
 		utils.TxPoolAccountQueueFlag,
 		utils.TxPoolGlobalQueueFlag,
 		utils.TxPoolLifetimeFlag,
+		utils.LargePoolDataDirFlag,
+		utils.LargePoolDataCapFlag,
+		utils.LargePoolPriceBumpFlag,
 		utils.SyncModeFlag,
 		utils.SyncTargetFlag,
+		utils.FollowFlag,
 		utils.ExitWhenSyncedFlag,
 		utils.GCModeFlag,
 		utils.SnapshotFlag,
 		utils.TransactionHistoryFlag,
 		utils.StateSchemeFlag,
 		utils.StateHistoryFlag,
+		utils.ChainHistoryFlag,
 		utils.LightKDFFlag,
 		utils.QRLRequiredBlocksFlag,
 		utils.BloomFilterSizeFlag,
@@ -104,6 +112,8 @@ var (
 		utils.MinerExtraDataFlag,
 		utils.MinerRecommitIntervalFlag,
 		utils.MinerPendingFeeRecipientFlag,
+		utils.MinerOrderingFlag,
+		utils.MinerMaxTxsPerSenderFlag,
 		utils.NATFlag,
 		utils.NoDiscoverFlag,
 		utils.DiscoveryV4Flag,
@@ -116,6 +126,8 @@ var (
 		utils.DeveloperGasLimitFlag,
 		utils.DeveloperPeriodFlag,
 		utils.VMEnableDebugFlag,
+		utils.VMTraceFlag,
+		utils.VMTraceJsonConfigFlag,
 		utils.NetworkIdFlag,
 		utils.QRLStatsURLFlag,
 		utils.NoCompactionFlag,
@@ -186,9 +198,12 @@ func init() {
 		initCommand,
 		importCommand,
 		exportCommand,
+		importHistoryCommand,
+		exportHistoryCommand,
 		removedbCommand,
 		dumpCommand,
 		dumpGenesisCommand,
+		pruneHistoryCommand,
 		// See accountcmd.go:
 		accountCommand,
 		// See consolecmd.go:
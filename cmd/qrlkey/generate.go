@@ -22,10 +22,8 @@ import (
 	"path/filepath"
 
 	"github.com/google/uuid"
-	walletmldsa87 "github.com/theQRL/go-qrllib/wallet/ml_dsa_87"
 	"github.com/theQRL/go-zond/accounts/keystore"
 	"github.com/theQRL/go-zond/cmd/utils"
-	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/crypto/pqcrypto"
 	"github.com/urfave/cli/v2"
 )
@@ -53,13 +51,17 @@ var commandGenerate = &cli.Command{
 Generate a new keyfile.
 
 If you want to encrypt an existing private key seed, it can be specified by setting
---seed with the location of the file containing the private key.
+--seed with the location of the file containing the private key. A plain seed is
+loaded as an ML-DSA-87 key, an extended seed selects the scheme of its descriptor.
+
+Random keys are generated for the signature scheme given by --scheme.
 `,
 	Flags: []cli.Flag{
 		passphraseFlag,
 		jsonFlag,
 		seedFlag,
 		lightKDFFlag,
+		schemeFlag,
 	},
 	Action: func(ctx *cli.Context) error {
 		// Check if keyfile path given and make sure it doesn't already exist.
@@ -73,7 +75,7 @@ If you want to encrypt an existing private key seed, it can be specified by sett
 			utils.Fatalf("Error checking if keyfile exists: %v", err)
 		}
 
-		var wallet *walletmldsa87.Wallet
+		var wallet pqcrypto.Wallet
 		var err error
 		if file := ctx.String(seedFlag.Name); file != "" {
 			// Load private key seed from file.
@@ -83,7 +85,7 @@ If you want to encrypt an existing private key seed, it can be specified by sett
 			}
 		} else {
 			// If not loaded, generate random.
-			wallet, err = pqcrypto.GenerateWalletKey()
+			wallet, err = pqcrypto.GenerateWallet(getWalletType(ctx))
 			if err != nil {
 				utils.Fatalf("Failed to generate random private key: %v", err)
 			}
@@ -96,7 +98,7 @@ If you want to encrypt an existing private key seed, it can be specified by sett
 		}
 		key := &keystore.Key{
 			Id:      UUID,
-			Address: common.Address(wallet.GetAddress()),
+			Address: wallet.GetAddress(),
 			Wallet:  wallet,
 		}
 
@@ -21,15 +21,17 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/theQRL/go-qrllib/wallet/common/wallettype"
 	"github.com/theQRL/go-zond/accounts/keystore"
 	"github.com/theQRL/go-zond/cmd/utils"
 	"github.com/urfave/cli/v2"
 )
 
 type outputInspect struct {
-	Address   string
-	PublicKey string
-	Seed      string
+	Address    string
+	PublicKey  string
+	Descriptor string
+	Seed       string
 }
 
 var (
@@ -71,14 +73,22 @@ make sure to use this feature with great caution!`,
 
 		// Output all relevant information we can retrieve.
 		showPrivate := ctx.Bool(privateFlag.Name)
-		publicKey := key.Wallet.GetPK()
+		desc := key.Wallet.GetDescriptor()
 		out := outputInspect{
-			Address:   key.Address.Hex(),
-			PublicKey: hex.EncodeToString(publicKey[:]),
+			Address:    key.Address.Hex(),
+			PublicKey:  hex.EncodeToString(key.Wallet.GetPK()),
+			Descriptor: hex.EncodeToString(desc.ToBytes()),
 		}
 		if showPrivate {
-			seed := key.Wallet.GetSeed()
-			out.Seed = hex.EncodeToString(seed[:])
+			// Only ML-DSA-87 keys can be restored from a plain seed, all
+			// other schemes need the descriptor prefixed extended seed.
+			if wallettype.WalletType(desc.Type()) == wallettype.ML_DSA_87 {
+				seed := key.Wallet.GetSeed()
+				out.Seed = hex.EncodeToString(seed[:])
+			} else {
+				extendedSeed := key.Wallet.GetExtendedSeed()
+				out.Seed = hex.EncodeToString(extendedSeed[:])
+			}
 		}
 
 		if ctx.Bool(jsonFlag.Name) {
@@ -86,6 +96,7 @@ make sure to use this feature with great caution!`,
 		} else {
 			fmt.Println("Address:       ", out.Address)
 			fmt.Println("Public key:    ", out.PublicKey)
+			fmt.Println("Descriptor:    ", out.Descriptor)
 			if showPrivate {
 				fmt.Println("Seed:   ", out.Seed)
 			}
@@ -51,6 +51,11 @@ var (
 		Name:  "json",
 		Usage: "output JSON instead of human-readable format",
 	}
+	schemeFlag = &cli.StringFlag{
+		Name:  "scheme",
+		Usage: "signature scheme of the key (mldsa87 or sphincsplus256s)",
+		Value: "mldsa87",
+	}
 )
 
 func main() {
@@ -21,7 +21,7 @@ import (
 	"fmt"
 	"os"
 
-	walletmldsa87 "github.com/theQRL/go-qrllib/wallet/ml_dsa_87"
+	"github.com/theQRL/go-qrllib/wallet/common/descriptor"
 	"github.com/theQRL/go-zond/accounts"
 	"github.com/theQRL/go-zond/accounts/keystore"
 	"github.com/theQRL/go-zond/cmd/utils"
@@ -96,10 +96,12 @@ var commandVerifyMessage = &cli.Command{
 	ArgsUsage: "<signature> <publickey> <message>",
 	Description: `
 Verify the signature of the message.
-It is possible to refer to a file containing the message.`,
+It is possible to refer to a file containing the message.
+The signature scheme of the public key is given by --scheme.`,
 	Flags: []cli.Flag{
 		jsonFlag,
 		msgfileFlag,
+		schemeFlag,
 	},
 	Action: func(ctx *cli.Context) error {
 		signatureHex := ctx.Args().First()
@@ -109,9 +111,9 @@ It is possible to refer to a file containing the message.`,
 		signature := common.FromHex(signatureHex)
 		publicKey := common.FromHex(pubKeyHex)
 
-		pk := walletmldsa87.PK(publicKey)
+		desc := descriptor.New(descriptor.GetDescriptorBytes(getWalletType(ctx), [2]byte{}))
 		out := outputVerify{
-			Success: walletmldsa87.Verify(accounts.TextHash(message), signature, &pk, walletmldsa87.NewMLDSA87Descriptor()),
+			Success: pqcrypto.Verify(accounts.TextHash(message), signature, publicKey, desc),
 		}
 		if ctx.Bool(jsonFlag.Name) {
 			mustPrintJSON(out)
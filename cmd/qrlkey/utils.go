@@ -22,6 +22,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/theQRL/go-qrllib/wallet/common/wallettype"
 	"github.com/theQRL/go-zond/cmd/utils"
 	"github.com/urfave/cli/v2"
 )
@@ -54,3 +55,17 @@ func mustPrintJSON(jsonObject interface{}) {
 	}
 	fmt.Println(string(str))
 }
+
+// getWalletType returns the wallet type selected by the --scheme flag and
+// exits the program with an error message when the scheme is unknown.
+func getWalletType(ctx *cli.Context) wallettype.WalletType {
+	switch scheme := ctx.String(schemeFlag.Name); scheme {
+	case "mldsa87":
+		return wallettype.ML_DSA_87
+	case "sphincsplus256s":
+		return wallettype.SPHINCSPLUS_256S
+	default:
+		utils.Fatalf("Unsupported signature scheme %q", scheme)
+		return 0
+	}
+}
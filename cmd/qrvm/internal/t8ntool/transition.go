@@ -25,7 +25,6 @@ import (
 	"path"
 	"strings"
 
-	walletmldsa87 "github.com/theQRL/go-qrllib/wallet/ml_dsa_87"
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/common/hexutil"
 	"github.com/theQRL/go-zond/consensus/misc/eip1559"
@@ -207,7 +206,7 @@ func Transition(ctx *cli.Context) error {
 // txWithKey is a helper-struct, to allow us to use the types.Transaction along with
 // a `seed`-field, for input
 type txWithKey struct {
-	key *walletmldsa87.Wallet
+	key pqcrypto.Wallet
 	tx  *types.Transaction
 }
 
@@ -308,7 +307,7 @@ func loadTransactions(txStr string, inputData *input, chainConfig *params.ChainC
 		txsWithKeys = inputData.Txs
 	}
 	// We may have to sign the transactions.
-	signer := types.MakeSigner(chainConfig)
+	signer := types.LatestSigner(chainConfig)
 	return signUnsignedTransactions(txsWithKeys, signer)
 }
 
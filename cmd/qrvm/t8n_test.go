@@ -195,6 +195,14 @@ func TestT8n(t *testing.T) {
 			output: t8nOutput{alloc: true, result: true},
 			expOut: "exp.json",
 		},
+		{ // Test rejection of transactions with forged signatures
+			base: "./testdata/28",
+			input: t8nInput{
+				"alloc.json", "signed_txs.rlp", "env.json", "Shanghai", "",
+			},
+			output: t8nOutput{result: true},
+			expOut: "exp.json",
+		},
 	} {
 		args := []string{"t8n"}
 		args = append(args, tc.output.get()...)
@@ -19,12 +19,17 @@ package utils
 
 import (
 	"bufio"
+	"bytes"
 	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"syscall"
@@ -36,8 +41,10 @@ import (
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/crypto"
 	"github.com/theQRL/go-zond/internal/debug"
+	"github.com/theQRL/go-zond/internal/era"
 	"github.com/theQRL/go-zond/log"
 	"github.com/theQRL/go-zond/node"
+	"github.com/theQRL/go-zond/params"
 	"github.com/theQRL/go-zond/qrl/qrlconfig"
 	"github.com/theQRL/go-zond/qrldb"
 	"github.com/theQRL/go-zond/rlp"
@@ -46,6 +53,10 @@ import (
 
 const (
 	importBatchSize = 2500
+
+	// historyChecksums is the name of the file listing the checksums of the
+	// chain history archives.
+	historyChecksums = "checksums.txt"
 )
 
 // Fatalf formats a message to standard error and exits the program.
@@ -296,6 +307,264 @@ func ExportAppendChain(blockchain *core.BlockChain, fn string, first uint64, las
 	return nil
 }
 
+// historyNetwork returns the name of the network the chain history archives of
+// the given genesis are named after.
+func historyNetwork(genesis common.Hash) string {
+	switch genesis {
+	case params.MainnetGenesisHash:
+		return "mainnet"
+	case params.BetaNetGenesisHash:
+		return "betanet"
+	case params.TestnetGenesisHash:
+		return "testnet"
+	default:
+		return fmt.Sprintf("%x", genesis[:4])
+	}
+}
+
+// ExportHistory exports the chain history in the given range into fixed-epoch
+// archives in the specified directory, along with a checksums file listing the
+// sha256 hashes of the archives.
+func ExportHistory(bc *core.BlockChain, dir string, first, last uint64) error {
+	log.Info("Exporting chain history", "dir", dir)
+	if head := bc.CurrentBlock().Number.Uint64(); head < last {
+		log.Warn("Last block beyond head, setting last = head", "head", head, "last", last)
+		last = head
+	}
+	if first > last {
+		return fmt.Errorf("export failed: first (%d) is greater than last (%d)", first, last)
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("error creating output directory: %w", err)
+	}
+	var (
+		network   = historyNetwork(bc.Genesis().Hash())
+		start     = time.Now()
+		reported  = time.Now()
+		checksums []string
+	)
+	for epoch := first / era.MaxSize; epoch <= last/era.MaxSize; epoch++ {
+		var (
+			from = max(first, epoch*era.MaxSize)
+			to   = min(last, (epoch+1)*era.MaxSize-1)
+		)
+		filename, err := exportEpoch(bc, dir, network, epoch, from, to)
+		if err != nil {
+			return err
+		}
+		checksum, err := fileChecksum(filepath.Join(dir, filename))
+		if err != nil {
+			return err
+		}
+		checksums = append(checksums, fmt.Sprintf("%x  %s", checksum, filename))
+
+		if time.Since(reported) >= 8*time.Second {
+			log.Info("Exporting chain history", "exported", to-first+1, "elapsed", common.PrettyDuration(time.Since(start)))
+			reported = time.Now()
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, historyChecksums), []byte(strings.Join(checksums, "\n")+"\n"), os.ModePerm); err != nil {
+		return err
+	}
+	log.Info("Exported chain history", "dir", dir, "epochs", len(checksums), "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// exportEpoch writes the blocks in the given range into the archive of the
+// epoch, returning the name of the created file.
+func exportEpoch(bc *core.BlockChain, dir string, network string, epoch, from, to uint64) (string, error) {
+	tmp := filepath.Join(dir, fmt.Sprintf("%s-%05d.tmp", network, epoch))
+	f, err := os.Create(tmp)
+	if err != nil {
+		return "", fmt.Errorf("error creating archive file: %w", err)
+	}
+	defer os.Remove(tmp)
+	defer f.Close()
+
+	var (
+		builder = era.NewBuilder(f)
+		parent  common.Hash
+	)
+	for n := from; n <= to; n++ {
+		block := bc.GetBlockByNumber(n)
+		if block == nil {
+			return "", fmt.Errorf("export failed on #%d: not found", n)
+		}
+		if n > from && block.ParentHash() != parent {
+			return "", errors.New("export failed: chain reorg during export")
+		}
+		parent = block.Hash()
+
+		receipts := bc.GetReceiptsByHash(block.Hash())
+		if receipts == nil && len(block.Transactions()) > 0 {
+			return "", fmt.Errorf("export failed on #%d: receipts not found", n)
+		}
+		if err := builder.Add(block, receipts); err != nil {
+			return "", err
+		}
+	}
+	root, err := builder.Finalize()
+	if err != nil {
+		return "", fmt.Errorf("export failed to finalize epoch %d: %w", epoch, err)
+	}
+	if err := f.Sync(); err != nil {
+		return "", err
+	}
+	filename := era.Filename(network, int(epoch), root)
+	if err := os.Rename(tmp, filepath.Join(dir, filename)); err != nil {
+		return "", err
+	}
+	return filename, nil
+}
+
+// ImportHistory imports the chain history from the archives in the specified
+// directory. The archives are checked against the checksums file, and their
+// blocks against the accumulators before anything is written. The blocks are
+// inserted along with their receipts without execution, as seeding history.
+func ImportHistory(chain *core.BlockChain, dir string) error {
+	network := historyNetwork(chain.Genesis().Hash())
+	files, err := era.ReadDir(dir, network)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no %s history archives found in %s", network, dir)
+	}
+	checksums, err := readChecksums(filepath.Join(dir, historyChecksums))
+	if err != nil {
+		return err
+	}
+	var (
+		start    = time.Now()
+		reported = time.Now()
+		imported uint64
+	)
+	for _, filename := range files {
+		path := filepath.Join(dir, filename)
+		want, ok := checksums[filename]
+		if !ok {
+			return fmt.Errorf("missing checksum of %s", filename)
+		}
+		have, err := fileChecksum(path)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(have, want) {
+			return fmt.Errorf("checksum mismatch of %s: have %x, want %x", filename, have, want)
+		}
+		n, err := importEpoch(chain, path)
+		if err != nil {
+			return fmt.Errorf("error importing %s: %w", filename, err)
+		}
+		imported += n
+
+		if time.Since(reported) >= 8*time.Second {
+			log.Info("Importing chain history", "imported", imported, "elapsed", common.PrettyDuration(time.Since(start)))
+			reported = time.Now()
+		}
+	}
+	log.Info("Imported chain history", "dir", dir, "blocks", imported, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// importEpoch verifies and imports the blocks of a single archive, returning
+// the number of newly inserted blocks.
+func importEpoch(chain *core.BlockChain, path string) (uint64, error) {
+	e, err := era.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer e.Close()
+
+	root, err := e.Verify()
+	if err != nil {
+		return 0, err
+	}
+	if filepath.Base(path) != era.Filename(historyNetwork(chain.Genesis().Hash()), int(e.Start()/era.MaxSize), root) {
+		return 0, fmt.Errorf("%w: archive name does not match root %x", era.ErrAccumulatorMismatch, root)
+	}
+	var imported uint64
+	for from := e.Start(); from < e.Start()+e.Count(); from += importBatchSize {
+		var (
+			headers  []*types.Header
+			blocks   types.Blocks
+			receipts []types.Receipts
+		)
+		for n := from; n < min(from+importBatchSize, e.Start()+e.Count()); n++ {
+			block, err := e.GetBlockByNumber(n)
+			if err != nil {
+				return imported, err
+			}
+			if n == 0 {
+				if block.Hash() != chain.Genesis().Hash() {
+					return imported, fmt.Errorf("genesis mismatch: have %x, want %x", block.Hash(), chain.Genesis().Hash())
+				}
+				continue
+			}
+			if chain.HasBlock(block.Hash(), n) {
+				continue
+			}
+			rs, err := e.GetReceiptsByNumber(n)
+			if err != nil {
+				return imported, err
+			}
+			headers = append(headers, block.Header())
+			blocks = append(blocks, block)
+			receipts = append(receipts, rs)
+		}
+		if len(blocks) == 0 {
+			continue
+		}
+		if _, err := chain.InsertHeaderChain(headers); err != nil {
+			return imported, fmt.Errorf("error inserting headers: %w", err)
+		}
+		if _, err := chain.InsertReceiptChain(blocks, receipts, math.MaxUint64); err != nil {
+			return imported, fmt.Errorf("error inserting blocks: %w", err)
+		}
+		imported += uint64(len(blocks))
+	}
+	return imported, nil
+}
+
+// fileChecksum computes the sha256 hash of the given file.
+func fileChecksum(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// readChecksums reads the archive checksums file, in the format of sha256sum.
+func readChecksums(path string) (map[string][]byte, error) {
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading checksums: %w", err)
+	}
+	checksums := make(map[string][]byte)
+	for _, line := range strings.Split(string(blob), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("malformed checksum line: %q", line)
+		}
+		checksum, err := hex.DecodeString(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("malformed checksum of %s: %w", fields[1], err)
+		}
+		checksums[fields[1]] = checksum
+	}
+	return checksums, nil
+}
+
 // ImportPreimages imports a batch of exported hash preimages into the database.
 // It's a part of the deprecated functionality, should be removed in the future.
 func ImportPreimages(db qrldb.Database, fn string) error {
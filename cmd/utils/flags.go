@@ -20,6 +20,7 @@ package utils
 import (
 	"context"
 	"crypto/ecdsa"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -41,6 +42,7 @@ import (
 	"github.com/theQRL/go-zond/common/fdlimit"
 	"github.com/theQRL/go-zond/core"
 	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/txpool/largepool"
 	"github.com/theQRL/go-zond/core/txpool/legacypool"
 	"github.com/theQRL/go-zond/core/vm"
 	"github.com/theQRL/go-zond/crypto"
@@ -106,6 +108,11 @@ var (
 		Usage:    "Root directory for ancient data (default = inside chaindata)",
 		Category: flags.QRLCategory,
 	}
+	CompactBodiesFlag = &cli.BoolFlag{
+		Name:     "db.compactbodies",
+		Usage:    "Store transaction public keys once per sender and strip them from frozen block bodies",
+		Category: flags.QRLCategory,
+	}
 	MinFreeDiskSpaceFlag = &flags.DirectoryFlag{
 		Name:     "datadir.minfreedisk",
 		Usage:    "Minimum free disk space in MB, once reached triggers auto shut down (default = --cache.gc converted to MB, 0 = disabled)",
@@ -258,6 +265,12 @@ var (
 		Value:    qrlconfig.Defaults.StateHistory,
 		Category: flags.StateCategory,
 	}
+	ChainHistoryFlag = &cli.Uint64Flag{
+		Name:     "history.chain",
+		Usage:    "Number of recent blocks to retain block bodies and receipts for (default = 0, entire chain)",
+		Value:    qrlconfig.Defaults.ChainHistory,
+		Category: flags.StateCategory,
+	}
 	TransactionHistoryFlag = &cli.Uint64Flag{
 		Name:     "history.transactions",
 		Usage:    "Number of recent blocks to maintain transactions index for (default = about one year, 0 = entire chain)",
@@ -283,10 +296,15 @@ var (
 	}
 	TxPoolRejournalFlag = &cli.DurationFlag{
 		Name:     "txpool.rejournal",
-		Usage:    "Time interval to regenerate the local transaction journal",
+		Usage:    "Time interval to regenerate the local transaction journal and remote snapshot",
 		Value:    qrlconfig.Defaults.TxPool.Rejournal,
 		Category: flags.TxPoolCategory,
 	}
+	TxPoolPersistFlag = &cli.BoolFlag{
+		Name:     "txpool.persist",
+		Usage:    "Persist remote transactions to disk to survive node restarts",
+		Category: flags.TxPoolCategory,
+	}
 	TxPoolPriceLimitFlag = &cli.Uint64Flag{
 		Name:     "txpool.pricelimit",
 		Usage:    "Minimum gas price tip to enforce for acceptance into the pool",
@@ -329,6 +347,25 @@ var (
 		Value:    qrlconfig.Defaults.TxPool.Lifetime,
 		Category: flags.TxPoolCategory,
 	}
+	// Large transaction pool settings
+	LargePoolDataDirFlag = &cli.StringFlag{
+		Name:     "largepool.datadir",
+		Usage:    "Data directory to store large transactions in",
+		Value:    qrlconfig.Defaults.LargePool.Datadir,
+		Category: flags.LargePoolCategory,
+	}
+	LargePoolDataCapFlag = &cli.Uint64Flag{
+		Name:     "largepool.datacap",
+		Usage:    "Disk space to allocate for pending large transactions (soft limit)",
+		Value:    qrlconfig.Defaults.LargePool.Datacap,
+		Category: flags.LargePoolCategory,
+	}
+	LargePoolPriceBumpFlag = &cli.Uint64Flag{
+		Name:     "largepool.pricebump",
+		Usage:    "Price bump percentage to replace an already existing large transaction",
+		Value:    qrlconfig.Defaults.LargePool.PriceBump,
+		Category: flags.LargePoolCategory,
+	}
 	// Performance tuning settings
 	CacheFlag = &cli.IntFlag{
 		Name:     "cache",
@@ -411,6 +448,18 @@ var (
 		Usage:    "Q prefixed public address for the pending block producer (not used for actual block production)",
 		Category: flags.MinerCategory,
 	}
+	MinerOrderingFlag = &cli.StringFlag{
+		Name:     "miner.ordering",
+		Usage:    `Transaction ordering strategy for built payloads ("local", "price", "fifo" or "fair")`,
+		Value:    qrlconfig.Defaults.Miner.Ordering,
+		Category: flags.MinerCategory,
+	}
+	MinerMaxTxsPerSenderFlag = &cli.IntFlag{
+		Name:     "miner.maxtxspersender",
+		Usage:    "Maximum number of transactions included per sender with the fair ordering",
+		Value:    qrlconfig.Defaults.Miner.MaxTxsPerSender,
+		Category: flags.MinerCategory,
+	}
 
 	// Account settings
 	UnlockedAccountFlag = &cli.StringFlag{
@@ -443,6 +492,16 @@ var (
 		Usage:    "Record information useful for VM and contract debugging",
 		Category: flags.VMCategory,
 	}
+	VMTraceFlag = &cli.StringFlag{
+		Name:     "vmtrace",
+		Usage:    "Name of tracer which should record internal VM operations during block import (costly)",
+		Category: flags.VMCategory,
+	}
+	VMTraceJsonConfigFlag = &cli.StringFlag{
+		Name:     "vmtrace.jsonconfig",
+		Usage:    "Tracer configuration (JSON)",
+		Category: flags.VMCategory,
+	}
 
 	// API options.
 	RPCGlobalGasCapFlag = &cli.Uint64Flag{
@@ -507,6 +566,11 @@ var (
 		TakesFile: true,
 		Category:  flags.MiscCategory,
 	}
+	FollowFlag = &cli.StringFlag{
+		Name:     "follow",
+		Usage:    "Follow the finalized chain of an era archive directory or a node's RPC endpoint without a consensus client (dev feature)",
+		Category: flags.MiscCategory,
+	}
 
 	// RPC settings
 	IPCDisabledFlag = &cli.BoolFlag{
@@ -1349,6 +1413,9 @@ func setTxPool(ctx *cli.Context, cfg *legacypool.Config) {
 	if ctx.IsSet(TxPoolRejournalFlag.Name) {
 		cfg.Rejournal = ctx.Duration(TxPoolRejournalFlag.Name)
 	}
+	if ctx.IsSet(TxPoolPersistFlag.Name) {
+		cfg.Persist = ctx.Bool(TxPoolPersistFlag.Name)
+	}
 	if ctx.IsSet(TxPoolPriceLimitFlag.Name) {
 		cfg.PriceLimit = ctx.Uint64(TxPoolPriceLimitFlag.Name)
 	}
@@ -1372,6 +1439,18 @@ func setTxPool(ctx *cli.Context, cfg *legacypool.Config) {
 	}
 }
 
+func setLargePool(ctx *cli.Context, cfg *largepool.Config) {
+	if ctx.IsSet(LargePoolDataDirFlag.Name) {
+		cfg.Datadir = ctx.String(LargePoolDataDirFlag.Name)
+	}
+	if ctx.IsSet(LargePoolDataCapFlag.Name) {
+		cfg.Datacap = ctx.Uint64(LargePoolDataCapFlag.Name)
+	}
+	if ctx.IsSet(LargePoolPriceBumpFlag.Name) {
+		cfg.PriceBump = ctx.Uint64(LargePoolPriceBumpFlag.Name)
+	}
+}
+
 func setMiner(ctx *cli.Context, cfg *miner.Config) {
 	if ctx.IsSet(MinerExtraDataFlag.Name) {
 		cfg.ExtraData = []byte(ctx.String(MinerExtraDataFlag.Name))
@@ -1385,6 +1464,15 @@ func setMiner(ctx *cli.Context, cfg *miner.Config) {
 	if ctx.IsSet(MinerRecommitIntervalFlag.Name) {
 		cfg.Recommit = ctx.Duration(MinerRecommitIntervalFlag.Name)
 	}
+	if ctx.IsSet(MinerOrderingFlag.Name) {
+		cfg.Ordering = ctx.String(MinerOrderingFlag.Name)
+	}
+	if ctx.IsSet(MinerMaxTxsPerSenderFlag.Name) {
+		cfg.MaxTxsPerSender = ctx.Int(MinerMaxTxsPerSenderFlag.Name)
+	}
+	if _, err := miner.NewOrderingStrategy(cfg); err != nil {
+		Fatalf("Invalid miner ordering: %v", err)
+	}
 }
 
 func setRequiredBlocks(ctx *cli.Context, cfg *qrlconfig.Config) {
@@ -1456,11 +1544,13 @@ func SetQRLConfig(ctx *cli.Context, stack *node.Node, cfg *qrlconfig.Config) {
 	// Avoid conflicting network flags
 	CheckExclusive(ctx, MainnetFlag, DeveloperFlag, BetaNetFlag, TestnetFlag)
 	CheckExclusive(ctx, DeveloperFlag, ExternalSignerFlag) // Can't use both ephemeral unlocked and external signer
+	CheckExclusive(ctx, DeveloperFlag, FollowFlag)
 
 	// Set configurations from CLI flags
 	setEtherbase(ctx, cfg)
 	setGPO(ctx, &cfg.GPO)
 	setTxPool(ctx, &cfg.TxPool)
+	setLargePool(ctx, &cfg.LargePool)
 	setMiner(ctx, &cfg.Miner)
 	setRequiredBlocks(ctx, cfg)
 
@@ -1487,6 +1577,11 @@ func SetQRLConfig(ctx *cli.Context, stack *node.Node, cfg *qrlconfig.Config) {
 	if ctx.IsSet(SyncModeFlag.Name) {
 		cfg.SyncMode = *flags.GlobalTextMarshaler(ctx, SyncModeFlag.Name).(*downloader.SyncMode)
 	}
+	if ctx.IsSet(FollowFlag.Name) && cfg.SyncMode != downloader.FullSync {
+		// The followed blocks are executed, which is only possible in full sync
+		log.Info("Following a chain requires full sync, overriding sync mode", "syncmode", cfg.SyncMode)
+		cfg.SyncMode = downloader.FullSync
+	}
 	if ctx.IsSet(NetworkIdFlag.Name) {
 		cfg.NetworkId = ctx.Uint64(NetworkIdFlag.Name)
 	}
@@ -1497,6 +1592,9 @@ func SetQRLConfig(ctx *cli.Context, stack *node.Node, cfg *qrlconfig.Config) {
 	if ctx.IsSet(AncientFlag.Name) {
 		cfg.DatabaseFreezer = ctx.String(AncientFlag.Name)
 	}
+	if ctx.IsSet(CompactBodiesFlag.Name) {
+		cfg.CompactBodies = ctx.Bool(CompactBodiesFlag.Name)
+	}
 
 	if gcmode := ctx.String(GCModeFlag.Name); gcmode != "full" && gcmode != "archive" {
 		Fatalf("--%s must be either 'full' or 'archive'", GCModeFlag.Name)
@@ -1516,6 +1614,9 @@ func SetQRLConfig(ctx *cli.Context, stack *node.Node, cfg *qrlconfig.Config) {
 	if ctx.IsSet(StateHistoryFlag.Name) {
 		cfg.StateHistory = ctx.Uint64(StateHistoryFlag.Name)
 	}
+	if ctx.IsSet(ChainHistoryFlag.Name) {
+		cfg.ChainHistory = ctx.Uint64(ChainHistoryFlag.Name)
+	}
 	// Parse state scheme, abort the process if it's not compatible.
 	chaindb := tryMakeReadOnlyDatabase(ctx, stack)
 	scheme, err := ParseStateScheme(ctx, chaindb)
@@ -1560,6 +1661,12 @@ func SetQRLConfig(ctx *cli.Context, stack *node.Node, cfg *qrlconfig.Config) {
 		// TODO(fjl): force-enable this in --dev mode
 		cfg.EnablePreimageRecording = ctx.Bool(VMEnableDebugFlag.Name)
 	}
+	if ctx.IsSet(VMTraceFlag.Name) {
+		if name := ctx.String(VMTraceFlag.Name); name != "" {
+			cfg.VMTrace = name
+			cfg.VMTraceJsonConfig = ctx.String(VMTraceJsonConfigFlag.Name)
+		}
+	}
 
 	if ctx.IsSet(RPCGlobalGasCapFlag.Name) {
 		cfg.RPCGasCap = ctx.Uint64(RPCGlobalGasCapFlag.Name)
@@ -1728,6 +1835,25 @@ func RegisterFullSyncTester(stack *node.Node, qrl *qrl.QRL, target common.Hash)
 	log.Info("Registered full-sync tester", "hash", target)
 }
 
+// RegisterLocalFollower adds the local follower service into node, following
+// either the RPC endpoint of another node, or the era archives in a directory.
+func RegisterLocalFollower(stack *node.Node, qrl *qrl.QRL, from string) {
+	var (
+		source catalyst.FollowerSource
+		err    error
+	)
+	if strings.Contains(from, "://") {
+		source, err = catalyst.NewRPCSource(context.Background(), from)
+	} else {
+		source, err = catalyst.NewArchiveSource(from, historyNetwork(qrl.BlockChain().Genesis().Hash()))
+	}
+	if err != nil {
+		Fatalf("Failed to create follower source: %v", err)
+	}
+	catalyst.RegisterLocalFollower(stack, qrl, source)
+	log.Info("Registered local follower", "source", from)
+}
+
 func SetupMetrics(ctx *cli.Context) {
 	if metrics.Enabled {
 		log.Info("Enabling metrics collection")
@@ -1899,6 +2025,9 @@ func MakeChain(ctx *cli.Context, stack *node.Node, readonly bool) (*core.BlockCh
 		gspec   = MakeGenesis(ctx)
 		chainDb = MakeChainDatabase(ctx, stack, readonly)
 	)
+	if !readonly && ctx.IsSet(CompactBodiesFlag.Name) {
+		rawdb.WriteCompactBodies(chainDb, ctx.Bool(CompactBodiesFlag.Name))
+	}
 	engine := qrlconfig.CreateConsensusEngine()
 	if gcmode := ctx.String(GCModeFlag.Name); gcmode != "full" && gcmode != "archive" {
 		Fatalf("--%s must be either 'full' or 'archive'", GCModeFlag.Name)
@@ -1937,6 +2066,19 @@ func MakeChain(ctx *cli.Context, stack *node.Node, readonly bool) (*core.BlockCh
 		cache.TrieDirtyLimit = ctx.Int(CacheFlag.Name) * ctx.Int(CacheGCFlag.Name) / 100
 	}
 	vmcfg := vm.Config{EnablePreimageRecording: ctx.Bool(VMEnableDebugFlag.Name)}
+	if ctx.IsSet(VMTraceFlag.Name) {
+		if name := ctx.String(VMTraceFlag.Name); name != "" {
+			var config json.RawMessage
+			if ctx.IsSet(VMTraceJsonConfigFlag.Name) {
+				config = json.RawMessage(ctx.String(VMTraceJsonConfigFlag.Name))
+			}
+			t, err := tracers.LiveDirectory.New(name, config)
+			if err != nil {
+				Fatalf("Failed to create tracer %q: %v", name, err)
+			}
+			vmcfg.LiveTracer = t
+		}
+	}
 
 	// Disable transaction indexing/unindexing by default.
 	chain, err := core.NewBlockChain(chainDb, cache, gspec, engine, vmcfg, nil)
@@ -0,0 +1,116 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/consensus/beacon"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/crypto"
+	"github.com/theQRL/go-zond/params"
+)
+
+// Tests that the chain history can be exported into archives and imported into
+// a fresh node from them.
+func TestHistoryImportAndExport(t *testing.T) {
+	var (
+		key, _  = crypto.GenerateMLDSA87Key()
+		address = key.GetAddress()
+		genesis = &core.Genesis{
+			Config:  params.TestChainConfig,
+			Alloc:   core.GenesisAlloc{address: {Balance: big.NewInt(1000000000000000000)}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		signer = types.LatestSigner(genesis.Config)
+	)
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, beacon.NewFaker(), 64, func(i int, b *core.BlockGen) {
+		tx, err := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+			Nonce:     b.TxNonce(address),
+			To:        &common.Address{0x11},
+			Value:     big.NewInt(1000),
+			Gas:       params.TxGas,
+			GasFeeCap: b.BaseFee(),
+		})
+		if err != nil {
+			t.Fatalf("failed to sign transaction: %v", err)
+		}
+		b.AddTx(tx)
+	})
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, genesis, beacon.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	dir := t.TempDir()
+	if err := ExportHistory(chain, dir, 0, 100); err != nil {
+		t.Fatalf("failed to export history: %v", err)
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil || len(files) != 2 {
+		t.Fatalf("unexpected export output: %v %v", files, err)
+	}
+	// Import the history into a fresh node
+	db, err := rawdb.NewDatabaseWithFreezer(rawdb.NewMemoryDatabase(), t.TempDir(), "", false)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer db.Close()
+
+	imported, err := core.NewBlockChain(db, nil, genesis, beacon.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer imported.Stop()
+	if err := ImportHistory(imported, dir); err != nil {
+		t.Fatalf("failed to import history: %v", err)
+	}
+	if head := imported.CurrentSnapBlock().Number.Uint64(); head != 64 {
+		t.Fatalf("snap head mismatch: have %d, want 64", head)
+	}
+	for _, want := range blocks {
+		block := imported.GetBlockByNumber(want.NumberU64())
+		if block == nil || block.Hash() != want.Hash() {
+			t.Fatalf("block %d missing after import", want.NumberU64())
+		}
+		receipts := imported.GetReceiptsByHash(want.Hash())
+		if len(receipts) != 1 || receipts[0].TxHash != want.Transactions()[0].Hash() {
+			t.Fatalf("receipts %d missing after import", want.NumberU64())
+		}
+	}
+	// Re-importing is a noop, but tampered archives are rejected
+	if err := ImportHistory(imported, dir); err != nil {
+		t.Fatalf("failed to re-import history: %v", err)
+	}
+	checksums := filepath.Join(dir, historyChecksums)
+	blob, _ := os.ReadFile(checksums)
+	os.WriteFile(checksums, []byte(strings.Repeat("0", 64)+string(blob[64:])), 0644)
+	if err := ImportHistory(imported, dir); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("tampered archive not rejected: %v", err)
+	}
+}
@@ -137,6 +137,14 @@ func (beacon *Beacon) verifyHeader(chain consensus.ChainHeaderReader, header, pa
 	if header.WithdrawalsHash == nil {
 		return errors.New("missing withdrawalsHash")
 	}
+	// Verify existence / non-existence of parentBeaconRoot.
+	prague := chain.Config().IsPrague(header.Time)
+	if !prague && header.ParentBeaconRoot != nil {
+		return fmt.Errorf("invalid parentBeaconRoot, have %#x, expected nil", *header.ParentBeaconRoot)
+	}
+	if prague && header.ParentBeaconRoot == nil {
+		return errors.New("header is missing beaconRoot")
+	}
 
 	if beacon.fakeDelay != nil {
 		time.Sleep(*beacon.fakeDelay)
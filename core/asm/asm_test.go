@@ -20,6 +20,8 @@ import (
 	"testing"
 
 	"encoding/hex"
+
+	"github.com/theQRL/go-zond/core/vm"
 )
 
 // Tests disassembling the instructions for valid qrvm code
@@ -72,3 +74,20 @@ func TestInstructionIteratorEmpty(t *testing.T) {
 		t.Errorf("Expected 0, but got %v instead.", cnt)
 	}
 }
+
+// Tests disassembling the instructions of the memory copy opcode
+func TestInstructionIteratorMcopy(t *testing.T) {
+	script, _ := hex.DecodeString("6020600160025e")
+
+	var ops []vm.OpCode
+	it := NewInstructionIterator(script)
+	for it.Next() {
+		ops = append(ops, it.Op())
+	}
+	if err := it.Error(); err != nil {
+		t.Fatalf("Expected no error, but encountered %v instead.", err)
+	}
+	if len(ops) != 4 || ops[3] != vm.MCOPY {
+		t.Errorf("Expected PUSH1 PUSH1 PUSH1 MCOPY, but got %v instead.", ops)
+	}
+}
@@ -54,6 +54,15 @@ func TestCompiler(t *testing.T) {
 `,
 			output: "6300000006565b",
 		},
+		{
+			input: `
+	PUSH 32
+	PUSH 1
+	PUSH 2
+	MCOPY
+`,
+			output: "602060016002" + "5e",
+		},
 	}
 	for _, test := range tests {
 		ch := Lex([]byte(test.input), false)
@@ -20,7 +20,6 @@ import (
 	"math/big"
 	"testing"
 
-	walletmldsa87 "github.com/theQRL/go-qrllib/wallet/ml_dsa_87"
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/common/math"
 	"github.com/theQRL/go-zond/consensus/beacon"
@@ -78,7 +77,7 @@ func genValueTx(nbytes int) func(int, *BlockGen) {
 		toaddr := common.Address{}
 		data := make([]byte, nbytes)
 		gas, _ := IntrinsicGas(data, nil, false)
-		signer := types.MakeSigner(gen.config)
+		signer := types.MakeSigner(gen.config, gen.header.Time)
 		baseFee := big.NewInt(0)
 		if gen.header.BaseFee != nil {
 			baseFee = gen.header.BaseFee
@@ -96,7 +95,7 @@ func genValueTx(nbytes int) func(int, *BlockGen) {
 }
 
 var (
-	ringKeys  = make([]*walletmldsa87.Wallet, 1000)
+	ringKeys  = make([]pqcrypto.Wallet, 1000)
 	ringAddrs = make([]common.Address, len(ringKeys))
 )
 
@@ -122,7 +121,7 @@ func genTxRing(naccounts int) func(int, *BlockGen) {
 		if gen.header.BaseFee != nil {
 			baseFee = gen.header.BaseFee
 		}
-		signer := types.MakeSigner(gen.config)
+		signer := types.MakeSigner(gen.config, gen.header.Time)
 		for {
 			gas -= params.TxGas
 			if gas < params.TxGas {
@@ -35,6 +35,7 @@ import (
 	"github.com/theQRL/go-zond/core/rawdb"
 	"github.com/theQRL/go-zond/core/state"
 	"github.com/theQRL/go-zond/core/state/snapshot"
+	"github.com/theQRL/go-zond/core/tracing"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/core/vm"
 	"github.com/theQRL/go-zond/event"
@@ -136,6 +137,7 @@ type CacheConfig struct {
 	SnapshotLimit       int           // Memory allowance (MB) to use for caching snapshot entries in memory
 	Preimages           bool          // Whether to store preimage of trie key to the disk
 	StateHistory        uint64        // Number of blocks from head whose state histories are reserved.
+	ChainHistory        uint64        // Number of blocks from head whose bodies and receipts are reserved (0 = entire chain).
 	StateScheme         string        // Scheme used to store qrl states and merkle tree nodes on top
 
 	SnapshotNoBuild bool // Whether the background generation is allowed
@@ -247,6 +249,7 @@ type BlockChain struct {
 	prefetcher Prefetcher
 	processor  Processor // Block transaction processor interface
 	vmConfig   vm.Config
+	logger     *tracing.Hooks // Live tracer notified of imported blocks, nil if not tracing
 }
 
 // NewBlockChain returns a fully initialised block chain using information
@@ -288,6 +291,7 @@ func NewBlockChain(db qrldb.Database, cacheConfig *CacheConfig, genesis *Genesis
 		blockCache:    lru.NewCache[common.Hash, *types.Block](blockCacheLimit),
 		engine:        engine,
 		vmConfig:      vmConfig,
+		logger:        vmConfig.LiveTracer,
 	}
 	bc.flushInterval.Store(int64(cacheConfig.TrieTimeLimit))
 	bc.stateCache = state.NewDatabaseWithNodeDB(bc.db, bc.triedb)
@@ -1002,6 +1006,10 @@ func (bc *BlockChain) Stop() {
 			}
 		}
 	}
+	// Let the live tracer release its resources, no more blocks will follow.
+	if bc.logger != nil && bc.logger.OnClose != nil {
+		bc.logger.OnClose()
+	}
 	// Close the trie database, release all the held resources as the last step.
 	if err := bc.triedb.Close(); err != nil {
 		log.Error("Failed to close trie database", "err", err)
@@ -1463,6 +1471,14 @@ func (bc *BlockChain) InsertChain(chain types.Blocks) (int, error) {
 	return bc.insertChain(chain, true)
 }
 
+// traceBlockEnd notifies the live tracer that the processing of the current
+// block finished, successfully or not.
+func (bc *BlockChain) traceBlockEnd(err error) {
+	if bc.logger != nil && bc.logger.OnBlockEnd != nil {
+		bc.logger.OnBlockEnd(err)
+	}
+}
+
 // insertChain is the internal implementation of InsertChain, which assumes that
 // 1) chains are contiguous, and 2) The chain mutex is held.
 //
@@ -1478,7 +1494,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool) (int, error)
 	}
 
 	// Start a parallel signature recovery (signer will fluke on fork transition, minimal perf loss)
-	SenderCacher.RecoverFromBlocks(types.MakeSigner(bc.chainConfig), chain)
+	SenderCacher.RecoverFromBlocks(types.MakeSigner(bc.chainConfig, chain[0].Time()), chain)
 
 	var (
 		stats     = insertStats{startTime: mclock.Now()}
@@ -1629,6 +1645,12 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool) (int, error)
 		statedb.StartPrefetcher("chain")
 		activeState = statedb
 
+		// Report the block and its state changes to the live tracer
+		statedb.SetLogger(bc.logger)
+		if bc.logger != nil && bc.logger.OnBlockStart != nil {
+			bc.logger.OnBlockStart(block)
+		}
+
 		// If we have a followup block, run that against the current state to pre-cache
 		// transactions and probabilistically some of the account/storage trie nodes.
 		var followupInterrupt atomic.Bool
@@ -1652,6 +1674,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool) (int, error)
 		receipts, logs, usedGas, err := bc.processor.Process(block, statedb, bc.vmConfig)
 		if err != nil {
 			bc.reportBlock(block, receipts, err)
+			bc.traceBlockEnd(err)
 			followupInterrupt.Store(true)
 			return it.index, err
 		}
@@ -1660,6 +1683,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool) (int, error)
 		vstart := time.Now()
 		if err := bc.validator.ValidateState(block, statedb, receipts, usedGas); err != nil {
 			bc.reportBlock(block, receipts, err)
+			bc.traceBlockEnd(err)
 			followupInterrupt.Store(true)
 			return it.index, err
 		}
@@ -1693,6 +1717,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool) (int, error)
 		} else {
 			status, err = bc.writeBlockAndSetHead(block, receipts, logs, statedb, false)
 		}
+		bc.traceBlockEnd(err)
 		followupInterrupt.Store(true)
 		if err != nil {
 			return it.index, err
@@ -2099,20 +2124,31 @@ func (bc *BlockChain) indexBlocks(tail *uint64, head uint64, done chan struct{})
 	if head == 0 {
 		return
 	}
+	// Drop the block history beyond the retention limit first, which also
+	// unindexes the transactions of the pruned blocks.
+	if bc.cacheConfig.ChainHistory != 0 && head >= bc.cacheConfig.ChainHistory {
+		if _, err := rawdb.PruneChainHistory(bc.db, head-bc.cacheConfig.ChainHistory+1, bc.quit); err != nil {
+			log.Warn("Failed to prune chain history", "err", err)
+		}
+		tail = rawdb.ReadTxIndexTail(bc.db)
+	}
+	// Blocks below the history tail have no bodies left to index. The error
+	// is ignored as it only signals the lack of an ancient store.
+	pruned, _ := bc.db.Tail()
 
 	// The tail flag is not existent, it means the node is just initialized
 	// and all blocks(may from ancient store) are not indexed yet.
 	if tail == nil {
-		from := uint64(0)
+		from := pruned
 		if bc.txLookupLimit != 0 && head >= bc.txLookupLimit {
-			from = head - bc.txLookupLimit + 1
+			from = max(from, head-bc.txLookupLimit+1)
 		}
 		rawdb.IndexTransactions(bc.db, from, head+1, bc.quit)
 		return
 	}
 	// The tail flag is existent, but the whole chain is required to be indexed.
 	if bc.txLookupLimit == 0 || head < bc.txLookupLimit {
-		if *tail > 0 {
+		if *tail > pruned {
 			// It can happen when chain is rewound to a historical point which
 			// is even lower than the indexes tail, recap the indexing target
 			// to new head to avoid reading non-existent block bodies.
@@ -2120,14 +2156,14 @@ func (bc *BlockChain) indexBlocks(tail *uint64, head uint64, done chan struct{})
 			if end > head+1 {
 				end = head + 1
 			}
-			rawdb.IndexTransactions(bc.db, 0, end, bc.quit)
+			rawdb.IndexTransactions(bc.db, pruned, end, bc.quit)
 		}
 		return
 	}
 	// Update the transaction index to the new chain state
-	if head-bc.txLookupLimit+1 < *tail {
+	if from := max(head-bc.txLookupLimit+1, pruned); from < *tail {
 		// Reindex a part of missing indices and rewind index tail to HEAD-limit
-		rawdb.IndexTransactions(bc.db, head-bc.txLookupLimit+1, *tail, bc.quit)
+		rawdb.IndexTransactions(bc.db, from, *tail, bc.quit)
 	} else {
 		// Unindex a part of stale indices and forward index tail to HEAD-limit
 		rawdb.UnindexTransactions(bc.db, *tail, head-bc.txLookupLimit+1, bc.quit)
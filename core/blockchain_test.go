@@ -17,6 +17,7 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 	"math/rand"
@@ -30,6 +31,7 @@ import (
 	"github.com/theQRL/go-zond/consensus/beacon"
 	"github.com/theQRL/go-zond/core/rawdb"
 	"github.com/theQRL/go-zond/core/state"
+	"github.com/theQRL/go-zond/core/tracing"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/core/vm"
 	"github.com/theQRL/go-zond/crypto"
@@ -2124,6 +2126,74 @@ func TestTransactionIndices(t *testing.T) {
 	}
 }
 
+// Tests that the chain history beyond the retention limit is pruned by the tx
+// indexer, keeping the headers but dropping the bodies, receipts and indices.
+func TestChainHistoryPruning(t *testing.T) {
+	var (
+		key, _  = pqcrypto.HexToWallet("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = key.GetAddress()
+		gspec   = &Genesis{
+			Config:  params.TestChainConfig,
+			Alloc:   GenesisAlloc{address: {Balance: big.NewInt(100000000000000000)}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	_, blocks, receipts := GenerateChainWithGenesis(gspec, beacon.NewFaker(), 128, func(i int, block *BlockGen) {
+		tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+			Nonce:     block.TxNonce(address),
+			To:        &common.Address{0x00},
+			Value:     big.NewInt(1000),
+			Gas:       params.TxGas,
+			GasFeeCap: block.header.BaseFee,
+		}), signer, key)
+		if err != nil {
+			panic(err)
+		}
+		block.AddTx(tx)
+	})
+	ancientDb, _ := rawdb.NewDatabaseWithFreezer(rawdb.NewMemoryDatabase(), t.TempDir(), "", false)
+	defer ancientDb.Close()
+	rawdb.WriteAncientBlocks(ancientDb, append([]*types.Block{gspec.ToBlock()}, blocks...), append([]types.Receipts{{}}, receipts...))
+
+	cache := *defaultCacheConfig
+	cache.ChainHistory = 32
+
+	// Run the indexer by hand, the background one is only started with a lookup limit
+	chain, err := NewBlockChain(ancientDb, &cache, gspec, beacon.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	chain.indexBlocks(rawdb.ReadTxIndexTail(ancientDb), 128, make(chan struct{}))
+	chain.Stop()
+
+	if tail, _ := ancientDb.Tail(); tail != 97 {
+		t.Fatalf("history tail mismatch: have %d, want 97", tail)
+	}
+	if tail := rawdb.ReadTxIndexTail(ancientDb); tail == nil || *tail != 97 {
+		t.Fatalf("tx index tail mismatch: have %v, want 97", tail)
+	}
+	for i, block := range blocks {
+		number := uint64(i + 1)
+		if rawdb.ReadHeaderNumber(ancientDb, block.Hash()) == nil || rawdb.ReadCanonicalHash(ancientDb, number) != block.Hash() {
+			t.Fatalf("block %d: header missing", number)
+		}
+		pruned := number < 97
+		if have := rawdb.ReadBlock(ancientDb, block.Hash(), number) == nil; have != pruned {
+			t.Fatalf("block %d: body pruned mismatch: have %v, want %v", number, have, pruned)
+		}
+		if have := rawdb.ReadTxLookupEntry(ancientDb, block.Transactions()[0].Hash()) == nil; have != pruned {
+			t.Fatalf("block %d: tx index pruned mismatch: have %v, want %v", number, have, pruned)
+		}
+	}
+	// The chain can be reopened with the genesis body pruned from the freezer
+	chain, err = NewBlockChain(ancientDb, &cache, gspec, beacon.NewFaker(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to reopen pruned chain: %v", err)
+	}
+	chain.Stop()
+}
+
 func TestSkipStaleTxIndicesInSnapSync(t *testing.T) {
 	testSkipStaleTxIndicesInSnapSync(t, rawdb.HashScheme)
 	testSkipStaleTxIndicesInSnapSync(t, rawdb.PathScheme)
@@ -2532,6 +2602,138 @@ func testEIP2718Transition(t *testing.T, scheme string) {
 	}
 }
 
+// Tests that a live tracer attached to the chain observes the imported blocks,
+// and that the reported state changes account for reverted call frames.
+func TestLiveTracer(t *testing.T) {
+	var (
+		aa, _  = common.NewAddressFromString("Q000000000000000000000000000000000000aaaa")
+		bb, _  = common.NewAddressFromString("Q000000000000000000000000000000000000bbbb")
+		engine = beacon.NewFaker()
+
+		// A sender who makes transactions, has some funds
+		key, _  = pqcrypto.HexToWallet("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = key.GetAddress()
+		funds   = big.NewInt(1000000000000000)
+		gspec   = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc: GenesisAlloc{
+				address: {Balance: funds},
+				// The address 0xAAAA stores 1 into slot 0, sends 1 planck to
+				// 0xBBBB and then reverts
+				aa: {
+					Code: []byte{
+						byte(vm.PUSH1), 0x1, byte(vm.PUSH1), 0x0, byte(vm.SSTORE),
+						byte(vm.PUSH1), 0x0, byte(vm.PUSH1), 0x0, byte(vm.PUSH1), 0x0, byte(vm.PUSH1), 0x0,
+						byte(vm.PUSH1), 0x1, byte(vm.PUSH2), 0xbb, 0xbb, byte(vm.GAS), byte(vm.CALL), byte(vm.POP),
+						byte(vm.PUSH1), 0x0, byte(vm.PUSH1), 0x0, byte(vm.REVERT),
+					},
+					Balance: big.NewInt(10),
+				},
+			},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 2, func(i int, b *BlockGen) {
+		b.SetCoinbase(common.Address{1})
+		tx, _ := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   gspec.Config.ChainID,
+			Nonce:     b.TxNonce(address),
+			To:        &aa,
+			Gas:       100000,
+			GasFeeCap: b.header.BaseFee,
+		})
+		b.AddTx(tx)
+		tx, _ = types.SignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   gspec.Config.ChainID,
+			Nonce:     b.TxNonce(address),
+			To:        &common.Address{2},
+			Value:     big.NewInt(1000),
+			Gas:       params.TxGas,
+			GasFeeCap: b.header.BaseFee,
+		})
+		b.AddTx(tx)
+	})
+	var (
+		blockStarts, blockEnds int
+		txStarts, txEnds       int
+		maxDepth               int
+		balances               = make(map[common.Address]*big.Int)
+		nonces                 = make(map[common.Address]uint64)
+		slots                  = make(map[common.Hash]common.Hash)
+		logger                 = &tracing.Hooks{
+			OnBlockStart: func(block *types.Block) { blockStarts++ },
+			OnBlockEnd: func(err error) {
+				if err != nil {
+					t.Errorf("unexpected block error: %v", err)
+				}
+				blockEnds++
+			},
+			OnTxStart: func(tx *types.Transaction, from common.Address) {
+				if from != address {
+					t.Errorf("sender mismatch: have %v, want %v", from, address)
+				}
+				txStarts++
+			},
+			OnTxEnd: func(receipt *types.Receipt, err error) {
+				if receipt == nil || err != nil {
+					t.Errorf("unexpected transaction failure: %v", err)
+				}
+				txEnds++
+			},
+			OnEnter: func(depth int, typ byte, from, to common.Address, input []byte, gas uint64, value *big.Int) {
+				if depth > maxDepth {
+					maxDepth = depth
+				}
+			},
+			OnBalanceChange: func(addr common.Address, prev, new *big.Int) {
+				balances[addr] = new
+			},
+			OnNonceChange: func(addr common.Address, prev, new uint64) {
+				nonces[addr] = new
+			},
+			OnStorageChange: func(addr common.Address, slot, prev, new common.Hash) {
+				if addr == aa {
+					slots[slot] = new
+				}
+			},
+		}
+	)
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, engine, vm.Config{LiveTracer: logger}, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	if blockStarts != 2 || blockEnds != 2 {
+		t.Errorf("block event mismatch: have %d starts and %d ends, want 2", blockStarts, blockEnds)
+	}
+	if txStarts != 4 || txEnds != 4 {
+		t.Errorf("transaction event mismatch: have %d starts and %d ends, want 4", txStarts, txEnds)
+	}
+	if maxDepth != 1 {
+		t.Errorf("call depth mismatch: have %d, want 1", maxDepth)
+	}
+	// The last reported values must match the final state
+	state, _ := chain.State()
+	for addr, balance := range balances {
+		if have := state.GetBalance(addr); have.Cmp(balance) != 0 {
+			t.Errorf("balance mismatch for %v: reported %v, state %v", addr, balance, have)
+		}
+	}
+	if balances[bb] == nil || balances[bb].Sign() != 0 {
+		t.Errorf("reverted transfer not reported as undone: %v", balances[bb])
+	}
+	if nonces[address] != 4 {
+		t.Errorf("nonce mismatch: have %d, want 4", nonces[address])
+	}
+	if slot := slots[common.Hash{}]; slot != (common.Hash{}) {
+		t.Errorf("reverted storage write not reported as undone: %v", slot)
+	}
+}
+
 // TestEIP1559Transition tests the following:
 //
 //  1. A transaction whose gasFeeCap is greater than the baseFee is valid.
@@ -3209,3 +3411,53 @@ func TestEIP3651(t *testing.T) {
 		t.Fatalf("sender balance incorrect: expected %d, got %d", expected, actual)
 	}
 }
+
+// Tests that a block containing a transaction with a valid public key but a
+// forged signature is rejected on import.
+func TestInsertChainForgedSignature(t *testing.T) {
+	var (
+		engine  = beacon.NewFaker()
+		key, _  = pqcrypto.HexToWallet("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr    = key.GetAddress()
+		funds   = new(big.Int).Mul(common.Big1, big.NewInt(params.Quanta))
+		gspec   = &Genesis{Config: params.AllBeaconProtocolChanges, Alloc: GenesisAlloc{addr: {Balance: funds}}}
+		signer  = types.LatestSigner(gspec.Config)
+		to, _   = common.NewAddressFromString("Q000000000000000000000000000000000000aaaa")
+		txdata  = &types.DynamicFeeTx{ChainID: gspec.Config.ChainID, Nonce: 0, To: &to, Gas: params.TxGas, GasFeeCap: newShor(5), GasTipCap: big.NewInt(2), Value: big.NewInt(1)}
+		tx, err = types.SignTx(types.NewTx(txdata), signer, key)
+	)
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 1, func(i int, b *BlockGen) {
+		b.AddTx(tx)
+	})
+	// Replace the transaction with a copy whose signature has been tampered
+	// with, fixing up the transaction root so only the signature is invalid.
+	sig := common.CopyBytes(tx.RawSignatureValue())
+	sig[0] ^= 0xff
+	forged, err := tx.WithSignaturePublicKeyAndDescriptor(signer, sig, tx.RawPublicKeyValue(), tx.RawDescriptorValue())
+	if err != nil {
+		t.Fatalf("failed to attach forged signature: %v", err)
+	}
+	header := blocks[0].Header()
+	header.TxHash = types.DeriveSha(types.Transactions{forged}, trie.NewStackTrie(nil))
+	block := types.NewBlockWithHeader(header).WithBody(types.Body{Transactions: []*types.Transaction{forged}, Withdrawals: blocks[0].Withdrawals()})
+
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(types.Blocks{block}); !errors.Is(err, types.ErrInvalidSig) {
+		t.Fatalf("expected error %v, got %v", types.ErrInvalidSig, err)
+	}
+	if head := chain.CurrentBlock().Number.Uint64(); head != 0 {
+		t.Fatalf("chain head advanced to %d", head)
+	}
+	// The untampered block must still import fine.
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert genuine block: %v", err)
+	}
+}
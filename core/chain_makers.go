@@ -68,6 +68,22 @@ func (b *BlockGen) SetExtra(data []byte) {
 	b.header.Extra = data
 }
 
+// SetParentBeaconRoot sets the parent beacon root field of the generated
+// block, storing it into the beacon roots contract.
+func (b *BlockGen) SetParentBeaconRoot(root common.Hash) {
+	b.header.ParentBeaconRoot = &root
+	b.processBeaconRoot()
+}
+
+// processBeaconRoot applies the beacon root system call of the generated block.
+func (b *BlockGen) processBeaconRoot() {
+	var (
+		blockContext = NewQRVMBlockContext(b.header, nil, &b.header.Coinbase)
+		vmenv        = vm.NewQRVM(blockContext, vm.TxContext{}, b.statedb, b.config, vm.Config{})
+	)
+	ProcessBeaconBlockRoot(*b.header.ParentBeaconRoot, vmenv, b.statedb)
+}
+
 // addTx adds a transaction to the generated block. If no coinbase has
 // been set, the block's coinbase is set to the zero address.
 //
@@ -235,6 +251,11 @@ func GenerateChain(config *params.ChainConfig, parent *types.Block, engine conse
 		b := &BlockGen{i: i, chain: blocks, parent: parent, statedb: statedb, config: config, engine: engine}
 		b.header = makeHeader(chainreader, parent, statedb)
 
+		// Store the parent beacon root into the state before executing the block
+		DeployBeaconRoots(config, parent.Time(), b.header.Time, statedb)
+		if b.header.ParentBeaconRoot != nil {
+			b.processBeaconRoot()
+		}
 		// Execute any user modifications to the block
 		if gen != nil {
 			gen(i, b)
@@ -306,7 +327,9 @@ func makeHeader(chain consensus.ChainReader, parent *types.Block, state *state.S
 		Time:       time,
 		BaseFee:    eip1559.CalcBaseFee(chain.Config(), parent.Header()),
 	}
-
+	if chain.Config().IsPrague(header.Time) {
+		header.ParentBeaconRoot = new(common.Hash)
+	}
 	return header
 }
 
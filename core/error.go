@@ -97,4 +97,16 @@ var (
 
 	// ErrSenderNoEOA is returned if the sender of a transaction is a contract.
 	ErrSenderNoEOA = errors.New("sender not an eoa")
+
+	// ErrSenderNoContract is returned if the sender of an account abstraction
+	// transaction has no code that could validate it.
+	ErrSenderNoContract = errors.New("sender not a contract")
+
+	// ErrValidationGasTooHigh is returned if the validation gas of an account
+	// abstraction transaction exceeds its gas limit.
+	ErrValidationGasTooHigh = errors.New("validation gas higher than gas limit")
+
+	// ErrValidationFailed is returned if the sender contract of an account
+	// abstraction transaction did not approve it.
+	ErrValidationFailed = errors.New("account abstraction validation failed")
 )
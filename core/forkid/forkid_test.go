@@ -172,9 +172,10 @@ func TestTimeBasedForkInGenesis(t *testing.T) {
 		time       = uint64(1690475657)
 		genesis    = types.NewBlockWithHeader(&types.Header{Time: time})
 		forkidHash = checksumToBytes(crc32.ChecksumIEEE(genesis.Hash().Bytes()))
-		config     = func() *params.ChainConfig {
+		config     = func(cancun *uint64) *params.ChainConfig {
 			return &params.ChainConfig{
-				ChainID: big.NewInt(1337),
+				ChainID:    big.NewInt(1337),
+				CancunTime: cancun,
 			}
 		}
 	)
@@ -182,19 +183,17 @@ func TestTimeBasedForkInGenesis(t *testing.T) {
 		config *params.ChainConfig
 		want   ID
 	}{
-		// NOTE(rgeraldes24): revisit upon new fork
-		/*
-			// Shanghai active before genesis, skip
-			{config(), ID{Hash: forkidHash, Next: time + 1}},
+		// Cancun active before genesis, skip
+		{config(u64(time - 1)), ID{Hash: forkidHash, Next: 0}},
 
-			// Shanghai active at genesis, skip
-			{config(), ID{Hash: forkidHash, Next: time + 1}},
+		// Cancun active at genesis, skip
+		{config(&time), ID{Hash: forkidHash, Next: 0}},
+
+		// Cancun not active, announce
+		{config(u64(time + 1)), ID{Hash: forkidHash, Next: time + 1}},
 
-			// Shanghai not active, skip
-			{config(), ID{Hash: forkidHash, Next: time + 1}},
-		*/
 		// No forks
-		{config(), ID{Hash: forkidHash, Next: 0}},
+		{config(nil), ID{Hash: forkidHash, Next: 0}},
 	}
 	for _, tt := range tests {
 		if have := NewID(tt.config, genesis, 0, time); have != tt.want {
@@ -202,3 +201,5 @@ func TestTimeBasedForkInGenesis(t *testing.T) {
 		}
 	}
 }
+
+func u64(val uint64) *uint64 { return &val }
@@ -24,7 +24,9 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/consensus/beacon"
 	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/vm"
 	"github.com/theQRL/go-zond/params"
 	"github.com/theQRL/go-zond/qrldb"
 	"github.com/theQRL/go-zond/trie"
@@ -40,14 +42,14 @@ func testSetupGenesis(t *testing.T, scheme string) {
 	var (
 		customghash = common.HexToHash("0x512a0d99941f1551db550852bdec6c9e213595356ede9dd23d1572199a8d66ba")
 		customg     = Genesis{
-			Config: &params.ChainConfig{},
+			Config: &params.ChainConfig{CancunTime: u64(30)},
 			Alloc: GenesisAlloc{
 				{1}: {Balance: big.NewInt(1), Storage: map[common.Hash]common.Hash{{1}: {1}}},
 			},
 		}
 		oldcustomg = customg
 	)
-	oldcustomg.Config = &params.ChainConfig{}
+	oldcustomg.Config = &params.ChainConfig{CancunTime: u64(20)}
 
 	tests := []struct {
 		name       string
@@ -119,35 +121,32 @@ func testSetupGenesis(t *testing.T, scheme string) {
 			wantHash:   customghash,
 			wantConfig: customg.Config,
 		},
-		// NOTE(rgeraldes24): not valid for now
-		/*
-			{
-				name: "incompatible config in DB",
-				fn: func(db qrldb.Database) (*params.ChainConfig, common.Hash, error) {
-					// Commit the 'old' genesis block with Homestead transition at #2.
-					// Advance to block #4, past the homestead transition block of customg.
-					tdb := trie.NewDatabase(db, newDbConfig(scheme))
-					oldcustomg.Commit(db, tdb)
+		{
+			name: "incompatible config in DB",
+			fn: func(db qrldb.Database) (*params.ChainConfig, common.Hash, error) {
+				// Commit the 'old' genesis block with Cancun transition at time 20.
+				// Advance to block #4, past the Cancun transition time of customg.
+				tdb := trie.NewDatabase(db, newDbConfig(scheme))
+				oldcustomg.Commit(db, tdb)
 
-					bc, _ := NewBlockChain(db, DefaultCacheConfigWithScheme(scheme), &oldcustomg, beacon.NewFullFaker(), vm.Config{}, nil, nil)
-					defer bc.Stop()
+				bc, _ := NewBlockChain(db, DefaultCacheConfigWithScheme(scheme), &oldcustomg, beacon.NewFullFaker(), vm.Config{}, nil)
+				defer bc.Stop()
 
-					_, blocks, _ := GenerateChainWithGenesis(&oldcustomg, beacon.NewFaker(), 4, nil)
-					bc.InsertChain(blocks)
+				_, blocks, _ := GenerateChainWithGenesis(&oldcustomg, beacon.NewFaker(), 4, nil)
+				bc.InsertChain(blocks)
 
-					// This should return a compatibility error.
-					return SetupGenesisBlock(db, tdb, &customg)
-				},
-				wantHash:   customghash,
-				wantConfig: customg.Config,
-				wantErr: &params.ConfigCompatError{
-					What:          "Homestead fork block",
-					StoredBlock:   big.NewInt(2),
-					NewBlock:      big.NewInt(3),
-					RewindToBlock: 1,
-				},
+				// This should return a compatibility error.
+				return SetupGenesisBlock(db, tdb, &customg)
 			},
-		*/
+			wantHash:   customghash,
+			wantConfig: customg.Config,
+			wantErr: &params.ConfigCompatError{
+				What:         "Cancun fork timestamp",
+				StoredTime:   u64(20),
+				NewTime:      u64(30),
+				RewindToTime: 19,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -280,3 +279,5 @@ func newDbConfig(scheme string) *trie.Config {
 	}
 	return &trie.Config{PathDB: pathdb.Defaults}
 }
+
+func u64(val uint64) *uint64 { return &val }
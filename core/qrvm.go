@@ -21,8 +21,10 @@ import (
 
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/consensus"
+	"github.com/theQRL/go-zond/consensus/misc/eip1559"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/params"
 )
 
 // ChainContext supports retrieving headers and consensus parameters from the
@@ -65,6 +67,24 @@ func NewQRVMBlockContext(header *types.Header, chain ChainContext, author *commo
 	}
 }
 
+// NewQRVMPendingBlockContext creates the context of the block following head,
+// for executing transactions ahead of their inclusion. The block is assumed to
+// have the earliest timestamp allowed and to be produced by head's coinbase.
+func NewQRVMPendingBlockContext(config *params.ChainConfig, head *types.Header, chain ChainContext) vm.BlockContext {
+	header := &types.Header{
+		ParentHash: head.Hash(),
+		Coinbase:   head.Coinbase,
+		Number:     new(big.Int).Add(head.Number, common.Big1),
+		GasLimit:   head.GasLimit,
+		Time:       head.Time + 1,
+		Random:     head.Random,
+	}
+	if head.BaseFee != nil {
+		header.BaseFee = eip1559.CalcBaseFee(config, head)
+	}
+	return NewQRVMBlockContext(header, chain, &header.Coinbase)
+}
+
 // NewQRVMTxContext creates a new transaction context for a single transaction.
 func NewQRVMTxContext(msg *Message) vm.TxContext {
 	return vm.TxContext{
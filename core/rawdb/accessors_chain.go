@@ -444,9 +444,13 @@ func ReadBodyRLP(db qrldb.Reader, hash common.Hash, number uint64) rlp.RawValue
 		// Check if the data is in ancients
 		if isCanon(reader, number, hash) {
 			data, _ = reader.Ancient(ChainFreezerBodiesTable, number)
-			return nil
+			if len(data) > 0 {
+				data = expandAncientBody(db, number, data)
+				return nil
+			}
 		}
-		// If not, try reading from leveldb
+		// If not, try reading from leveldb. The genesis block is always kept
+		// there, even if its frozen copy was pruned away.
 		data, _ = db.Get(blockBodyKey(number, hash))
 		return nil
 	})
@@ -460,6 +464,7 @@ func ReadCanonicalBodyRLP(db qrldb.Reader, number uint64) rlp.RawValue {
 	db.ReadAncients(func(reader qrldb.AncientReaderOp) error {
 		data, _ = reader.Ancient(ChainFreezerBodiesTable, number)
 		if len(data) > 0 {
+			data = expandAncientBody(db, number, data)
 			return nil
 		}
 		// Block is not in ancients, read from leveldb by hash and number.
@@ -472,6 +477,17 @@ func ReadCanonicalBodyRLP(db qrldb.Reader, number uint64) rlp.RawValue {
 	return data
 }
 
+// expandAncientBody restores a block body read from the freezer, which might
+// have been compacted, into its RLP encoding.
+func expandAncientBody(db qrldb.KeyValueReader, number uint64, data []byte) rlp.RawValue {
+	body, err := expandBodyRLP(db, data)
+	if err != nil {
+		log.Error("Failed to expand frozen block body", "number", number, "err", err)
+		return nil
+	}
+	return body
+}
+
 // WriteBodyRLP stores an RLP encoded block body into the database.
 func WriteBodyRLP(db qrldb.KeyValueWriter, hash common.Hash, number uint64, rlp rlp.RawValue) {
 	if err := db.Put(blockBodyKey(number, hash), rlp); err != nil {
@@ -539,9 +555,12 @@ func ReadReceiptsRLP(db qrldb.Reader, hash common.Hash, number uint64) rlp.RawVa
 		// Check if the data is in ancients
 		if isCanon(reader, number, hash) {
 			data, _ = reader.Ancient(ChainFreezerReceiptTable, number)
-			return nil
+			if len(data) > 0 {
+				return nil
+			}
 		}
-		// If not, try reading from leveldb
+		// If not, try reading from leveldb. The genesis block is always kept
+		// there, even if its frozen copy was pruned away.
 		data, _ = db.Get(blockReceiptsKey(number, hash))
 		return nil
 	})
@@ -634,6 +653,7 @@ type storedReceiptRLP struct {
 	PostStateOrStatus []byte
 	CumulativeGasUsed uint64
 	Logs              []*types.Log
+	ValidationGasUsed uint64 `rlp:"optional"`
 }
 
 // ReceiptLogs is a barebone version of ReceiptForStorage which only keeps
@@ -721,9 +741,13 @@ func WriteBlock(db qrldb.KeyValueWriter, block *types.Block) {
 }
 
 // WriteAncientBlocks writes entire block data into ancient store and returns the total written size.
-func WriteAncientBlocks(db qrldb.AncientWriter, blocks []*types.Block, receipts []types.Receipts) (int64, error) {
+// If compact bodies are enabled, the public keys of the transactions are moved
+// into the key-value store.
+func WriteAncientBlocks(db qrldb.Database, blocks []*types.Block, receipts []types.Receipts) (int64, error) {
 	var (
 		stReceipts []*types.ReceiptForStorage
+		compact    = ReadCompactBodies(db)
+		batch      = db.NewBatch()
 	)
 	return db.ModifyAncients(func(op qrldb.AncientWriteOp) error {
 		for i, block := range blocks {
@@ -732,16 +756,30 @@ func WriteAncientBlocks(db qrldb.AncientWriter, blocks []*types.Block, receipts
 			for _, receipt := range receipts[i] {
 				stReceipts = append(stReceipts, (*types.ReceiptForStorage)(receipt))
 			}
+			body, err := rlp.EncodeToBytes(block.Body())
+			if err != nil {
+				return fmt.Errorf("can't encode block body %d: %v", block.NumberU64(), err)
+			}
+			if compact {
+				if body, err = compactBodyRLP(db, batch, body); err != nil {
+					return fmt.Errorf("can't compact block body %d: %v", block.NumberU64(), err)
+				}
+			}
 			header := block.Header()
-			if err := writeAncientBlock(op, block, header, stReceipts); err != nil {
+			if err := writeAncientBlock(op, block, header, body, stReceipts); err != nil {
 				return err
 			}
 		}
-		return nil
+		// Public keys must be durable before the compacted bodies referencing
+		// them are committed to the freezer.
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		return db.SyncKeyValue()
 	})
 }
 
-func writeAncientBlock(op qrldb.AncientWriteOp, block *types.Block, header *types.Header, receipts []*types.ReceiptForStorage) error {
+func writeAncientBlock(op qrldb.AncientWriteOp, block *types.Block, header *types.Header, body rlp.RawValue, receipts []*types.ReceiptForStorage) error {
 	num := block.NumberU64()
 	if err := op.AppendRaw(ChainFreezerHashTable, num, block.Hash().Bytes()); err != nil {
 		return fmt.Errorf("can't add block %d hash: %v", num, err)
@@ -749,7 +787,7 @@ func writeAncientBlock(op qrldb.AncientWriteOp, block *types.Block, header *type
 	if err := op.Append(ChainFreezerHeaderTable, num, header); err != nil {
 		return fmt.Errorf("can't append block header %d: %v", num, err)
 	}
-	if err := op.Append(ChainFreezerBodiesTable, num, block.Body()); err != nil {
+	if err := op.AppendRaw(ChainFreezerBodiesTable, num, body); err != nil {
 		return fmt.Errorf("can't append block body %d: %v", num, err)
 	}
 	if err := op.Append(ChainFreezerReceiptTable, num, receipts); err != nil {
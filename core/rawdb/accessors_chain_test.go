@@ -30,6 +30,7 @@ import (
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/crypto/pqcrypto"
 	"github.com/theQRL/go-zond/params"
+	"github.com/theQRL/go-zond/qrldb"
 	"github.com/theQRL/go-zond/rlp"
 	"golang.org/x/crypto/sha3"
 )
@@ -494,6 +495,119 @@ func TestAncientStorage(t *testing.T) {
 	}
 }
 
+// Tests that block bodies compacted in the freezer are transparently restored.
+func TestCompactAncientStorage(t *testing.T) {
+	var (
+		blocks   = makeTestBlocks(3, 4)
+		receipts = make([]types.Receipts, 3)
+	)
+	plain, err := NewDatabaseWithFreezer(NewMemoryDatabase(), t.TempDir(), "", false)
+	if err != nil {
+		t.Fatalf("failed to create database with ancient backend")
+	}
+	defer plain.Close()
+
+	compact, err := NewDatabaseWithFreezer(NewMemoryDatabase(), t.TempDir(), "", false)
+	if err != nil {
+		t.Fatalf("failed to create database with ancient backend")
+	}
+	defer compact.Close()
+	WriteCompactBodies(compact, true)
+
+	if _, err := WriteAncientBlocks(plain, blocks, receipts); err != nil {
+		t.Fatalf("failed to write plain ancient blocks: %v", err)
+	}
+	if _, err := WriteAncientBlocks(compact, blocks, receipts); err != nil {
+		t.Fatalf("failed to write compact ancient blocks: %v", err)
+	}
+	for _, block := range blocks {
+		hash, number := block.Hash(), block.NumberU64()
+
+		want := ReadBodyRLP(plain, hash, number)
+		if have := ReadBodyRLP(compact, hash, number); !bytes.Equal(have, want) {
+			t.Fatalf("block %d: body mismatch: have %x, want %x", number, have, want)
+		}
+		if have := ReadCanonicalBodyRLP(compact, number); !bytes.Equal(have, want) {
+			t.Fatalf("block %d: canonical body mismatch: have %x, want %x", number, have, want)
+		}
+		body := ReadBody(compact, hash, number)
+		if body == nil {
+			t.Fatalf("block %d: body not found", number)
+		}
+		for i, tx := range body.Transactions {
+			if tx.Hash() != block.Transactions()[i].Hash() {
+				t.Fatalf("block %d: transaction %d mismatch", number, i)
+			}
+		}
+	}
+	// The sender's public key must be stored once, outside of the bodies.
+	tx := blocks[0].Transactions()[0]
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		t.Fatalf("failed to derive sender: %v", err)
+	}
+	if pk := ReadPublicKey(compact, sender); !bytes.Equal(pk, tx.RawPublicKeyValue()) {
+		t.Fatalf("public key mismatch: have %x, want %x", pk, tx.RawPublicKeyValue())
+	}
+	plainSize, _ := plain.AncientSize(ChainFreezerBodiesTable)
+	compactSize, _ := compact.AncientSize(ChainFreezerBodiesTable)
+	if compactSize >= plainSize {
+		t.Fatalf("compact bodies not smaller: have %d, plain %d", compactSize, plainSize)
+	}
+}
+
+// syncRecorder is a key-value store running a callback whenever it is synced.
+type syncRecorder struct {
+	qrldb.KeyValueStore
+	onSync func()
+}
+
+func (r *syncRecorder) SyncKeyValue() error {
+	r.onSync()
+	return r.KeyValueStore.SyncKeyValue()
+}
+
+// Tests that the public keys of bodies compacted during an ancient import are
+// synced to disk before the bodies are committed to the freezer.
+func TestCompactAncientStorageSync(t *testing.T) {
+	var (
+		blocks   = makeTestBlocks(3, 4)
+		receipts = make([]types.Receipts, 3)
+		tx       = blocks[0].Transactions()[0]
+		kvdb     = &syncRecorder{KeyValueStore: NewMemoryDatabase()}
+	)
+	db, err := NewDatabaseWithFreezer(kvdb, t.TempDir(), "", false)
+	if err != nil {
+		t.Fatalf("failed to create database with ancient backend")
+	}
+	defer db.Close()
+	WriteCompactBodies(db, true)
+
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		t.Fatalf("failed to derive sender: %v", err)
+	}
+	var synced bool
+	kvdb.onSync = func() {
+		if frozen, _ := db.Ancients(); frozen != 0 {
+			t.Errorf("bodies committed before sync: have %d frozen", frozen)
+		}
+		if ReadPublicKey(db, sender) == nil {
+			t.Errorf("public key missing at sync")
+		}
+		synced = true
+	}
+	if _, err := WriteAncientBlocks(db, blocks, receipts); err != nil {
+		t.Fatalf("failed to write compact ancient blocks: %v", err)
+	}
+	if !synced {
+		t.Fatalf("public keys not synced")
+	}
+	if frozen, _ := db.Ancients(); frozen != uint64(len(blocks)) {
+		t.Fatalf("frozen count mismatch: have %d, want %d", frozen, len(blocks))
+	}
+}
+
 func TestCanonicalHashIteration(t *testing.T) {
 	var cases = []struct {
 		from, to uint64
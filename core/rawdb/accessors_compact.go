@@ -0,0 +1,191 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"fmt"
+
+	"github.com/theQRL/go-qrllib/wallet/common/descriptor"
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
+	"github.com/theQRL/go-zond/log"
+	"github.com/theQRL/go-zond/qrldb"
+	"github.com/theQRL/go-zond/rlp"
+)
+
+// compactBodyPrefix marks a compacted block body in the freezer. Plain block
+// bodies are RLP lists, so their first byte is always 0xc0 or above.
+const compactBodyPrefix = 0x01
+
+// compactBody is the freezer representation of a block body whose transaction
+// public keys have been moved into the public key table.
+type compactBody struct {
+	Transactions []compactTransaction
+	Rest         []rlp.RawValue `rlp:"tail"` // remaining body fields, stored verbatim
+}
+
+// compactTransaction is a transaction in a compacted block body.
+type compactTransaction struct {
+	Sender []byte // address the stripped public key is stored under, empty if inline
+	Tx     []byte // binary encoding of the transaction
+}
+
+// ReadCompactBodies retrieves whether block bodies are compacted when they are
+// moved into the freezer.
+func ReadCompactBodies(db qrldb.KeyValueReader) bool {
+	enabled, _ := db.Has(compactBodiesKey)
+	return enabled
+}
+
+// WriteCompactBodies stores whether block bodies are compacted when they are
+// moved into the freezer. Already frozen bodies are unaffected, readers handle
+// both representations.
+func WriteCompactBodies(db qrldb.KeyValueWriter, enabled bool) {
+	if !enabled {
+		if err := db.Delete(compactBodiesKey); err != nil {
+			log.Crit("Failed to remove compact bodies flag", "err", err)
+		}
+		return
+	}
+	if err := db.Put(compactBodiesKey, []byte{1}); err != nil {
+		log.Crit("Failed to store compact bodies flag", "err", err)
+	}
+}
+
+// ReadPublicKey retrieves the public key stored for the given address.
+func ReadPublicKey(db qrldb.KeyValueReader, address common.Address) []byte {
+	data, _ := db.Get(publicKeyKey(address))
+	return data
+}
+
+// WritePublicKey stores the public key of the given address. The mapping is
+// immutable as the address is derived from the key, so entries are never
+// deleted.
+func WritePublicKey(db qrldb.KeyValueWriter, address common.Address, pk []byte) {
+	if err := db.Put(publicKeyKey(address), pk); err != nil {
+		log.Crit("Failed to store public key", "err", err)
+	}
+}
+
+// compactBodyRLP converts an RLP encoded block body into its compacted form,
+// moving the public keys of the contained transactions into the public key
+// table. Keys not yet known are written into w.
+func compactBodyRLP(db qrldb.KeyValueReader, w qrldb.KeyValueWriter, data rlp.RawValue) (rlp.RawValue, error) {
+	content, _, err := rlp.SplitList(data)
+	if err != nil {
+		return nil, err
+	}
+	txs, rest, err := rlp.SplitList(content)
+	if err != nil {
+		return nil, err
+	}
+	var body compactBody
+	for len(txs) > 0 {
+		var enc []byte
+		if enc, txs, err = rlp.SplitString(txs); err != nil {
+			return nil, err
+		}
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(enc); err != nil {
+			return nil, err
+		}
+		body.Transactions = append(body.Transactions, stripPublicKey(db, w, &tx, enc))
+	}
+	for len(rest) > 0 {
+		_, _, remaining, err := rlp.Split(rest)
+		if err != nil {
+			return nil, err
+		}
+		body.Rest = append(body.Rest, rest[:len(rest)-len(remaining)])
+		rest = remaining
+	}
+	enc, err := rlp.EncodeToBytes(&body)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{compactBodyPrefix}, enc...), nil
+}
+
+// stripPublicKey removes the public key from a transaction if the sender address
+// can be derived from it, storing the key in the public key table instead.
+// Transactions that cannot be stripped are kept inline.
+func stripPublicKey(db qrldb.KeyValueReader, w qrldb.KeyValueWriter, tx *types.Transaction, enc []byte) compactTransaction {
+	pk := tx.RawPublicKeyValue()
+	if len(pk) == 0 {
+		return compactTransaction{Tx: enc}
+	}
+	desc, err := descriptor.FromBytes(tx.RawDescriptorValue())
+	if err != nil {
+		return compactTransaction{Tx: enc}
+	}
+	address, err := pqcrypto.PKToAddress(pk, desc)
+	if err != nil {
+		return compactTransaction{Tx: enc}
+	}
+	stripped, err := tx.WithPublicKey(nil).MarshalBinary()
+	if err != nil {
+		return compactTransaction{Tx: enc}
+	}
+	if has, _ := db.Has(publicKeyKey(address)); !has {
+		WritePublicKey(w, address, pk)
+	}
+	return compactTransaction{Sender: address.Bytes(), Tx: stripped}
+}
+
+// expandBodyRLP converts a block body read from the freezer back into its
+// canonical RLP encoding. Bodies that are not compacted are returned as is.
+func expandBodyRLP(db qrldb.KeyValueReader, data []byte) (rlp.RawValue, error) {
+	if len(data) == 0 || data[0] != compactBodyPrefix {
+		return data, nil
+	}
+	var body compactBody
+	if err := rlp.DecodeBytes(data[1:], &body); err != nil {
+		return nil, err
+	}
+	buf := rlp.NewEncoderBuffer(nil)
+	outer := buf.List()
+	inner := buf.List()
+	for _, ctx := range body.Transactions {
+		if len(ctx.Sender) == 0 {
+			buf.WriteBytes(ctx.Tx)
+			continue
+		}
+		address := common.BytesToAddress(ctx.Sender)
+		pk := ReadPublicKey(db, address)
+		if len(pk) == 0 {
+			return nil, fmt.Errorf("missing public key of %v", address)
+		}
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(ctx.Tx); err != nil {
+			return nil, err
+		}
+		enc, err := tx.WithPublicKey(pk).MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteBytes(enc)
+	}
+	buf.ListEnd(inner)
+	for _, field := range body.Rest {
+		if _, err := buf.Write(field); err != nil {
+			return nil, err
+		}
+	}
+	buf.ListEnd(outer)
+	return buf.ToBytes(), nil
+}
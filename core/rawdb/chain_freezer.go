@@ -253,6 +253,10 @@ func (f *chainFreezer) freeze(db qrldb.KeyValueStore) {
 func (f *chainFreezer) freezeRange(nfdb *nofreezedb, number, limit uint64) (hashes []common.Hash, err error) {
 	hashes = make([]common.Hash, 0, limit-number)
 
+	var (
+		compact = ReadCompactBodies(nfdb)
+		batch   = nfdb.NewBatch()
+	)
 	_, err = f.ModifyAncients(func(op qrldb.AncientWriteOp) error {
 		for ; number <= limit; number++ {
 			// Retrieve all the components of the canonical block.
@@ -268,6 +272,11 @@ func (f *chainFreezer) freezeRange(nfdb *nofreezedb, number, limit uint64) (hash
 			if len(body) == 0 {
 				return fmt.Errorf("block body missing, can't freeze block %d", number)
 			}
+			if compact {
+				if body, err = compactBodyRLP(nfdb, batch, body); err != nil {
+					return fmt.Errorf("can't compact block body %d: %v", number, err)
+				}
+			}
 			receipts := ReadReceiptsRLP(nfdb, hash, number)
 			if len(receipts) == 0 {
 				return fmt.Errorf("block receipts missing, can't freeze block %d", number)
@@ -289,7 +298,13 @@ func (f *chainFreezer) freezeRange(nfdb *nofreezedb, number, limit uint64) (hash
 
 			hashes = append(hashes, hash)
 		}
-		return nil
+		// Public keys must be durable before the compacted bodies referencing
+		// them are committed to the freezer, as the original bodies are deleted
+		// from the key-value store afterwards.
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		return nfdb.SyncKeyValue()
 	})
 
 	return hashes, err
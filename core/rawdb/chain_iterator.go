@@ -17,6 +17,7 @@
 package rawdb
 
 import (
+	"errors"
 	"runtime"
 	"sync/atomic"
 	"time"
@@ -353,3 +354,46 @@ func UnindexTransactions(db qrldb.Database, from uint64, to uint64, interrupt ch
 func unindexTransactionsForTesting(db qrldb.Database, from uint64, to uint64, interrupt chan struct{}, hook func(uint64) bool) {
 	unindexTransactions(db, from, to, interrupt, hook)
 }
+
+// errHistoryPruneInterrupted is returned if the history pruning was interrupted
+// while dropping the transaction indices of the blocks to be pruned.
+var errHistoryPruneInterrupted = errors.New("history pruning interrupted")
+
+// PruneChainHistory discards the block bodies and receipts of the frozen blocks
+// below the given number. Headers and canonical hashes are retained, and so is
+// the genesis block which is always kept in the key-value store too. Since the
+// transaction indices can't be removed without the bodies, the indices of the
+// affected blocks are deleted first and the tx index tail is moved along.
+//
+// The pruning can only operate on the ancient store, blocks which are not yet
+// frozen are skipped. The new tail of the chain history is returned.
+func PruneChainHistory(db qrldb.Database, tail uint64, interrupt chan struct{}) (uint64, error) {
+	frozen, err := db.Ancients()
+	if err != nil {
+		return 0, err
+	}
+	if tail > frozen {
+		tail = frozen
+	}
+	old, err := db.Tail()
+	if err != nil {
+		return 0, err
+	}
+	if old >= tail {
+		return old, nil
+	}
+	// Remove the transaction indices of the pruned blocks. If the indices were
+	// never initialized, leave it to the indexer to start above the pruned tail.
+	if indexed := ReadTxIndexTail(db); indexed != nil && *indexed < tail {
+		// Bodies below the old tail are already gone, nothing to unindex there
+		unindexTransactions(db, max(*indexed, old), tail, interrupt, nil)
+		if indexed := ReadTxIndexTail(db); indexed == nil || *indexed < tail {
+			return old, errHistoryPruneInterrupted
+		}
+	}
+	if _, err := db.TruncateTail(tail); err != nil {
+		return old, err
+	}
+	log.Info("Pruned chain history", "from", old, "to", tail)
+	return tail, nil
+}
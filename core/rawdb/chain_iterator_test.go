@@ -206,3 +206,78 @@ func TestIndexTransactions(t *testing.T) {
 	verify(8, 11, true, 8)
 	verify(0, 8, false, 8)
 }
+
+// Tests that pruning the chain history drops the frozen bodies and receipts
+// along with their transaction indices, while keeping the headers around.
+func TestPruneChainHistory(t *testing.T) {
+	db, err := NewDatabaseWithFreezer(NewMemoryDatabase(), t.TempDir(), "", false)
+	if err != nil {
+		t.Fatalf("failed to create database with ancient backend: %v", err)
+	}
+	defer db.Close()
+
+	var (
+		to       = common.BytesToAddress([]byte{0x11})
+		blocks   []*types.Block
+		receipts []types.Receipts
+	)
+	for i := uint64(0); i <= 10; i++ {
+		var body *types.Body
+		if i > 0 {
+			tx := types.NewTx(&types.DynamicFeeTx{Nonce: i, GasFeeCap: big.NewInt(11111), Gas: 1111, To: &to})
+			body = &types.Body{Transactions: types.Transactions{tx}}
+		}
+		blocks = append(blocks, types.NewBlock(&types.Header{Number: new(big.Int).SetUint64(i)}, body, nil, newTestHasher()))
+		receipts = append(receipts, nil)
+	}
+	// The genesis block is retained in the key-value store next to the freezer
+	WriteBlock(db, blocks[0])
+	WriteReceipts(db, blocks[0].Hash(), 0, nil)
+	if _, err := WriteAncientBlocks(db, blocks, receipts); err != nil {
+		t.Fatalf("failed to write ancient blocks: %v", err)
+	}
+	IndexTransactions(db, 0, 11, nil)
+	if ReadTxLookupEntry(db, blocks[5].Transactions()[0].Hash()) == nil {
+		t.Fatalf("transactions not indexed")
+	}
+
+	// Pruning can't go beyond the frozen blocks
+	if tail, err := PruneChainHistory(db, 20, nil); err != nil || tail != 11 {
+		t.Fatalf("unexpected pruning result: tail %d, err %v", tail, err)
+	}
+	if tail, err := PruneChainHistory(db, 5, nil); err != nil || tail != 11 {
+		t.Fatalf("history tail moved backwards: tail %d, err %v", tail, err)
+	}
+	if tail := ReadTxIndexTail(db); tail == nil || *tail != 11 {
+		t.Fatalf("tx index tail mismatch: have %v, want 11", tail)
+	}
+	for _, block := range blocks {
+		hash, number := block.Hash(), block.NumberU64()
+		if ReadHeaderRLP(db, hash, number) == nil {
+			t.Fatalf("block %d: header pruned", number)
+		}
+		if ReadCanonicalHash(db, number) != hash {
+			t.Fatalf("block %d: canonical hash pruned", number)
+		}
+		if number == 0 {
+			if ReadBodyRLP(db, hash, number) == nil || ReadReceiptsRLP(db, hash, number) == nil {
+				t.Fatalf("genesis block pruned")
+			}
+			continue
+		}
+		if ReadBodyRLP(db, hash, number) != nil || ReadReceiptsRLP(db, hash, number) != nil {
+			t.Fatalf("block %d: body or receipts not pruned", number)
+		}
+		if ReadTxLookupEntry(db, block.Transactions()[0].Hash()) != nil {
+			t.Fatalf("block %d: transaction index not pruned", number)
+		}
+	}
+	// Further blocks can still be frozen after pruning
+	next := types.NewBlock(&types.Header{Number: big.NewInt(11)}, nil, nil, newTestHasher())
+	if _, err := WriteAncientBlocks(db, []*types.Block{next}, []types.Receipts{nil}); err != nil {
+		t.Fatalf("failed to write ancient block after pruning: %v", err)
+	}
+	if ReadBodyRLP(db, next.Hash(), 11) == nil {
+		t.Fatalf("block frozen after pruning is missing")
+	}
+}
@@ -32,7 +32,7 @@ import (
 	"github.com/theQRL/go-zond/rlp"
 )
 
-var freezerTestTableDef = map[string]bool{"test": true}
+var freezerTestTableDef = map[string]freezerTableConfig{"test": {noSnappy: true, prunable: true}}
 
 func TestFreezerModify(t *testing.T) {
 	t.Parallel()
@@ -48,7 +48,7 @@ func TestFreezerModify(t *testing.T) {
 		valuesRLP = append(valuesRLP, iv)
 	}
 
-	tables := map[string]bool{"raw": true, "rlp": false}
+	tables := map[string]freezerTableConfig{"raw": {noSnappy: true, prunable: true}, "rlp": {noSnappy: false, prunable: true}}
 	f, _ := newFreezerForTesting(t, tables)
 	defer f.Close()
 
@@ -112,7 +112,7 @@ func TestFreezerModifyRollback(t *testing.T) {
 	f.Close()
 
 	// Reopen and check that the rolled-back data doesn't reappear.
-	tables := map[string]bool{"test": true}
+	tables := map[string]freezerTableConfig{"test": {noSnappy: true, prunable: true}}
 	f2, err := NewFreezer(dir, "", false, 2049, tables)
 	if err != nil {
 		t.Fatalf("can't reopen freezer after failed ModifyAncients: %v", err)
@@ -250,7 +250,7 @@ func TestFreezerConcurrentModifyTruncate(t *testing.T) {
 }
 
 func TestFreezerReadonlyValidate(t *testing.T) {
-	tables := map[string]bool{"a": true, "b": true}
+	tables := map[string]freezerTableConfig{"a": {noSnappy: true, prunable: true}, "b": {noSnappy: true, prunable: true}}
 	dir := t.TempDir()
 	// Open non-readonly freezer and fill individual tables
 	// with different amount of data.
@@ -283,7 +283,59 @@ func TestFreezerReadonlyValidate(t *testing.T) {
 	}
 }
 
-func newFreezerForTesting(t *testing.T, tables map[string]bool) (*Freezer, string) {
+// Tests that tail truncation only affects the prunable tables, and that the
+// differing tails are accepted when the freezer is reopened.
+func TestFreezerTruncateTailPrunable(t *testing.T) {
+	tables := map[string]freezerTableConfig{"kept": {noSnappy: true}, "pruned": {noSnappy: true, prunable: true}}
+	f, dir := newFreezerForTesting(t, tables)
+
+	var item = make([]byte, 256)
+	_, err := f.ModifyAncients(func(op qrldb.AncientWriteOp) error {
+		for i := uint64(0); i < 20; i++ {
+			if err := op.AppendRaw("kept", i, item); err != nil {
+				return err
+			}
+			if err := op.AppendRaw("pruned", i, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	_, err = f.TruncateTail(10)
+	require.NoError(t, err)
+	check := func(f *Freezer) {
+		t.Helper()
+		if tail, _ := f.Tail(); tail != 10 {
+			t.Fatalf("tail mismatch: have %d, want 10", tail)
+		}
+		if _, err := f.Ancient("kept", 0); err != nil {
+			t.Fatalf("non-prunable table truncated: %v", err)
+		}
+		if _, err := f.Ancient("pruned", 9); err != errOutOfBounds {
+			t.Fatalf("prunable table not truncated: %v", err)
+		}
+		if _, err := f.Ancient("pruned", 10); err != nil {
+			t.Fatalf("prunable table truncated too much: %v", err)
+		}
+	}
+	check(f)
+	require.NoError(t, f.Close())
+
+	// Reopen the freezer both in read-write and readonly mode
+	f, err = NewFreezer(dir, "", false, 2049, tables)
+	require.NoError(t, err)
+	check(f)
+	require.NoError(t, f.Close())
+
+	f, err = NewFreezer(dir, "", true, 2049, tables)
+	require.NoError(t, err)
+	check(f)
+	require.NoError(t, f.Close())
+}
+
+func newFreezerForTesting(t *testing.T, tables map[string]freezerTableConfig) (*Freezer, string) {
 	t.Helper()
 
 	dir := t.TempDir()
@@ -410,7 +462,7 @@ func TestRenameWindows(t *testing.T) {
 
 func TestFreezerCloseSync(t *testing.T) {
 	t.Parallel()
-	f, _ := newFreezerForTesting(t, map[string]bool{"a": true, "b": true})
+	f, _ := newFreezerForTesting(t, map[string]freezerTableConfig{"a": {noSnappy: true, prunable: true}, "b": {noSnappy: true, prunable: true}})
 	defer f.Close()
 
 	// Now, close and sync. This mimics the behaviour if the node is shut down,
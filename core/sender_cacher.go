@@ -18,27 +18,41 @@ package core
 
 import (
 	"runtime"
+	"sync"
+	"time"
 
 	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/metrics"
 )
 
-// SenderCacher is a concurrent transaction sender recoverer and cacher.
+var (
+	senderVerifyTimer    = metrics.NewRegisteredTimer("core/sendercacher/verify", nil)
+	senderCacheHitMeter  = metrics.NewRegisteredMeter("core/sendercacher/hit", nil)
+	senderCacheMissMeter = metrics.NewRegisteredMeter("core/sendercacher/miss", nil)
+)
+
+// SenderCacher is a concurrent transaction signature verifier and sender cacher.
 var SenderCacher = newTxSenderCacher(runtime.NumCPU())
 
-// txSenderCacherRequest is a request for recovering transaction senders with a
-// specific signature scheme and caching it into the transactions themselves.
+// txSenderCacherRequest is a request for verifying transaction signatures with a
+// specific signature scheme and caching the derived senders into the transactions
+// themselves.
 //
 // The inc field defines the number of transactions to skip after each recovery,
 // which is used to feed the same underlying input array to different threads but
 // ensure they process the early transactions fast.
+//
+// The optional done field is signalled once the request has been fully processed,
+// allowing callers to wait for a batch to be verified.
 type txSenderCacherRequest struct {
 	signer types.Signer
 	txs    []*types.Transaction
 	inc    int
+	done   *sync.WaitGroup
 }
 
-// txSenderCacher is a helper structure to concurrently get transaction
-// senders from digital signatures on background threads.
+// txSenderCacher is a helper structure to concurrently verify transaction
+// signatures and derive their senders on background threads.
 type txSenderCacher struct {
 	threads int
 	tasks   chan *txSenderCacherRequest
@@ -57,20 +71,30 @@ func newTxSenderCacher(threads int) *txSenderCacher {
 	return cacher
 }
 
-// cache is an infinite loop, caching transaction senders from various forms of
-// data structures.
+// cache is an infinite loop, verifying and caching transaction senders from
+// various forms of data structures. Transactions whose sender has already been
+// verified with the requested signer are skipped.
 func (cacher *txSenderCacher) cache() {
 	for task := range cacher.tasks {
 		for i := 0; i < len(task.txs); i += task.inc {
-			types.Sender(task.signer, task.txs[i])
+			tx := task.txs[i]
+			if types.SenderCached(task.signer, tx) {
+				continue
+			}
+			start := time.Now()
+			types.Sender(task.signer, tx)
+			senderVerifyTimer.UpdateSince(start)
+		}
+		if task.done != nil {
+			task.done.Done()
 		}
 	}
 }
 
-// Recover recovers the senders from a batch of transactions and caches them
-// back into the same data structures. There is no validation being done, nor
-// any reaction to invalid signatures. That is up to calling code later.
-func (cacher *txSenderCacher) Recover(signer types.Signer, txs []*types.Transaction) {
+// schedule splits the transactions into interleaved tasks and feeds them to the
+// background threads. If done is non-nil, it is incremented for every scheduled
+// task.
+func (cacher *txSenderCacher) schedule(signer types.Signer, txs []*types.Transaction, done *sync.WaitGroup) {
 	// If there's nothing to recover, abort
 	if len(txs) == 0 {
 		return
@@ -80,19 +104,55 @@ func (cacher *txSenderCacher) Recover(signer types.Signer, txs []*types.Transact
 	if len(txs) < tasks*4 {
 		tasks = (len(txs) + 3) / 4
 	}
+	if done != nil {
+		done.Add(tasks)
+	}
 	for i := 0; i < tasks; i++ {
 		cacher.tasks <- &txSenderCacherRequest{
 			signer: signer,
 			txs:    txs[i:],
 			inc:    tasks,
+			done:   done,
 		}
 	}
 }
 
-// RecoverFromBlocks recovers the senders from a batch of blocks and caches them
-// back into the same data structures. There is no validation being done, nor
-// any reaction to invalid signatures. That is up to calling code later.
+// Recover verifies the signatures of a batch of transactions and caches the
+// derived senders back into the same data structures. There is no reaction to
+// invalid signatures, that is up to calling code later.
+func (cacher *txSenderCacher) Recover(signer types.Signer, txs []*types.Transaction) {
+	cacher.schedule(signer, txs, nil)
+}
+
+// RecoverFromBlocks verifies the signatures of the transactions in a batch of
+// blocks and caches the derived senders back into the same data structures.
+// There is no reaction to invalid signatures, that is up to calling code later.
 func (cacher *txSenderCacher) RecoverFromBlocks(signer types.Signer, blocks []*types.Block) {
+	cacher.Recover(signer, transactionsOfBlocks(blocks))
+}
+
+// Verify is like Recover, but blocks until the signatures of all transactions
+// in the batch have been verified. Both valid senders and signature failures are
+// cached in the transactions, so subsequent calls to types.Sender are cheap.
+func (cacher *txSenderCacher) Verify(signer types.Signer, txs []*types.Transaction) {
+	var done sync.WaitGroup
+	cacher.schedule(signer, txs, &done)
+	done.Wait()
+}
+
+// markSenderCache records whether the signature of a processed transaction was
+// served from the cache (hit) or had to be verified on the processing path (miss).
+// The cached state must be sampled before the sender is derived.
+func markSenderCache(cached bool) {
+	if cached {
+		senderCacheHitMeter.Mark(1)
+	} else {
+		senderCacheMissMeter.Mark(1)
+	}
+}
+
+// transactionsOfBlocks flattens the transactions of a batch of blocks.
+func transactionsOfBlocks(blocks []*types.Block) []*types.Transaction {
 	count := 0
 	for _, block := range blocks {
 		count += len(block.Transactions())
@@ -101,5 +161,5 @@ func (cacher *txSenderCacher) RecoverFromBlocks(signer types.Signer, blocks []*t
 	for _, block := range blocks {
 		txs = append(txs, block.Transactions()...)
 	}
-	cacher.Recover(signer, txs)
+	return txs
 }
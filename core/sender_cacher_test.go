@@ -0,0 +1,84 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
+	"github.com/theQRL/go-zond/params"
+)
+
+// Tests that the sender cacher verifies batches of transactions concurrently,
+// caching the senders of valid transactions and the failures of invalid ones.
+func TestSenderCacherVerify(t *testing.T) {
+	var (
+		key, _ = pqcrypto.HexToWallet("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		signer = types.LatestSigner(params.TestChainConfig)
+		cacher = newTxSenderCacher(4)
+		txs    = make([]*types.Transaction, 10)
+	)
+	for i := range txs {
+		tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+			ChainID:   params.TestChainConfig.ChainID,
+			Nonce:     uint64(i),
+			To:        &common.Address{},
+			Gas:       params.TxGas,
+			GasFeeCap: big.NewInt(1),
+			GasTipCap: big.NewInt(1),
+			Value:     big.NewInt(1),
+		}), signer, key)
+		if err != nil {
+			t.Fatalf("failed to sign transaction %d: %v", i, err)
+		}
+		// Forge the signature of every third transaction
+		if i%3 == 0 {
+			sig := common.CopyBytes(tx.RawSignatureValue())
+			sig[0] ^= 0xff
+			if tx, err = tx.WithSignaturePublicKeyAndDescriptor(signer, sig, tx.RawPublicKeyValue(), tx.RawDescriptorValue()); err != nil {
+				t.Fatalf("failed to forge transaction %d: %v", i, err)
+			}
+		}
+		txs[i] = tx
+	}
+	cacher.Verify(signer, txs)
+
+	for i, tx := range txs {
+		if !types.SenderCached(signer, tx) {
+			t.Errorf("tx %d: verification result not cached", i)
+		}
+		from, err := types.Sender(signer, tx)
+		if i%3 == 0 {
+			if err == nil {
+				t.Errorf("tx %d: forged signature accepted", i)
+			}
+		} else if err != nil || from != key.GetAddress() {
+			t.Errorf("tx %d: sender mismatch: have %x, %v, want %x", i, from, err, key.GetAddress())
+		}
+	}
+	// Re-verifying the transactions must not alter the cached results
+	cacher.Verify(signer, txs[:2])
+	if _, err := types.Sender(signer, txs[0]); err == nil {
+		t.Errorf("forged signature accepted after repeated verification")
+	}
+	if _, err := types.Sender(signer, txs[1]); err != nil {
+		t.Errorf("valid signature rejected after repeated verification: %v", err)
+	}
+}
@@ -133,6 +133,11 @@ type (
 		address *common.Address
 		slot    *common.Hash
 	}
+
+	transientStorageChange struct {
+		account       *common.Address
+		key, prevalue common.Hash
+	}
 )
 
 func (ch createObjectChange) revert(s *StateDB) {
@@ -175,7 +180,11 @@ func (ch touchChange) dirtied() *common.Address {
 }
 
 func (ch balanceChange) revert(s *StateDB) {
-	s.getStateObject(*ch.account).setBalance(ch.prev)
+	obj := s.getStateObject(*ch.account)
+	if s.logger != nil && s.logger.OnBalanceChange != nil {
+		s.logger.OnBalanceChange(*ch.account, obj.Balance(), ch.prev)
+	}
+	obj.setBalance(ch.prev)
 }
 
 func (ch balanceChange) dirtied() *common.Address {
@@ -183,7 +192,11 @@ func (ch balanceChange) dirtied() *common.Address {
 }
 
 func (ch nonceChange) revert(s *StateDB) {
-	s.getStateObject(*ch.account).setNonce(ch.prev)
+	obj := s.getStateObject(*ch.account)
+	if s.logger != nil && s.logger.OnNonceChange != nil {
+		s.logger.OnNonceChange(*ch.account, obj.Nonce(), ch.prev)
+	}
+	obj.setNonce(ch.prev)
 }
 
 func (ch nonceChange) dirtied() *common.Address {
@@ -191,7 +204,11 @@ func (ch nonceChange) dirtied() *common.Address {
 }
 
 func (ch codeChange) revert(s *StateDB) {
-	s.getStateObject(*ch.account).setCode(common.BytesToHash(ch.prevhash), ch.prevcode)
+	obj := s.getStateObject(*ch.account)
+	if s.logger != nil && s.logger.OnCodeChange != nil {
+		s.logger.OnCodeChange(*ch.account, common.BytesToHash(obj.CodeHash()), obj.Code(), common.BytesToHash(ch.prevhash), ch.prevcode)
+	}
+	obj.setCode(common.BytesToHash(ch.prevhash), ch.prevcode)
 }
 
 func (ch codeChange) dirtied() *common.Address {
@@ -199,7 +216,11 @@ func (ch codeChange) dirtied() *common.Address {
 }
 
 func (ch storageChange) revert(s *StateDB) {
-	s.getStateObject(*ch.account).setState(ch.key, ch.prevalue)
+	obj := s.getStateObject(*ch.account)
+	if s.logger != nil && s.logger.OnStorageChange != nil {
+		s.logger.OnStorageChange(*ch.account, ch.key, obj.GetState(ch.key), ch.prevalue)
+	}
+	obj.setState(ch.key, ch.prevalue)
 }
 
 func (ch storageChange) dirtied() *common.Address {
@@ -260,3 +281,11 @@ func (ch accessListAddSlotChange) revert(s *StateDB) {
 func (ch accessListAddSlotChange) dirtied() *common.Address {
 	return nil
 }
+
+func (ch transientStorageChange) revert(s *StateDB) {
+	s.setTransientState(*ch.account, ch.key, ch.prevalue)
+}
+
+func (ch transientStorageChange) dirtied() *common.Address {
+	return nil
+}
@@ -65,20 +65,40 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 		allLogs     []*types.Log
 		gp          = new(GasPool).AddGas(block.GasLimit())
 	)
+	// Report call frames to the live tracer attached to the state, if any
+	hooks := statedb.Logger()
+	if cfg.Tracer == nil {
+		if logger := newHooksLogger(hooks); logger != nil {
+			cfg.Tracer = logger
+		}
+	}
 	var (
 		context = NewQRVMBlockContext(header, p.bc, nil)
 		vmenv   = vm.NewQRVM(context, vm.TxContext{}, statedb, p.config, cfg)
-		signer  = types.MakeSigner(p.config)
+		signer  = types.MakeSigner(p.config, header.Time)
 	)
-
+	if parent := p.bc.GetHeader(block.ParentHash(), block.NumberU64()-1); parent != nil {
+		DeployBeaconRoots(p.config, parent.Time, header.Time, statedb)
+	}
+	if beaconRoot := block.BeaconRoot(); beaconRoot != nil {
+		ProcessBeaconBlockRoot(*beaconRoot, vmenv, statedb)
+	}
 	// Iterate over and process the individual transactions
 	for i, tx := range block.Transactions() {
+		cached := types.SenderCached(signer, tx)
 		msg, err := TransactionToMessage(tx, signer, header.BaseFee)
+		markSenderCache(cached)
 		if err != nil {
 			return nil, nil, 0, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
 		}
 		statedb.SetTxContext(tx.Hash(), i)
+		if hooks != nil && hooks.OnTxStart != nil {
+			hooks.OnTxStart(tx, msg.From)
+		}
 		receipt, err := applyTransaction(msg, gp, statedb, blockNumber, blockHash, tx, usedGas, vmenv)
+		if hooks != nil && hooks.OnTxEnd != nil {
+			hooks.OnTxEnd(receipt, err)
+		}
 		if err != nil {
 			return nil, nil, 0, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
 		}
@@ -118,6 +138,7 @@ func applyTransaction(msg *Message, gp *GasPool, statedb *state.StateDB, blockNu
 	}
 	receipt.TxHash = tx.Hash()
 	receipt.GasUsed = result.UsedGas
+	receipt.ValidationGasUsed = result.ValidationGasUsed
 
 	// If the transaction created a contract, store the creation address in the receipt.
 	if msg.To == nil {
@@ -138,7 +159,7 @@ func applyTransaction(msg *Message, gp *GasPool, statedb *state.StateDB, blockNu
 // for the transaction, gas used and an error if the transaction failed,
 // indicating the block was invalid.
 func ApplyTransaction(config *params.ChainConfig, bc ChainContext, author *common.Address, gp *GasPool, statedb *state.StateDB, header *types.Header, tx *types.Transaction, usedGas *uint64, cfg vm.Config) (*types.Receipt, error) {
-	msg, err := TransactionToMessage(tx, types.MakeSigner(config), header.BaseFee)
+	msg, err := TransactionToMessage(tx, types.MakeSigner(config, header.Time), header.BaseFee)
 	if err != nil {
 		return nil, err
 	}
@@ -147,3 +168,33 @@ func ApplyTransaction(config *params.ChainConfig, bc ChainContext, author *commo
 	vmenv := vm.NewQRVM(blockContext, vm.TxContext{}, statedb, config, cfg)
 	return applyTransaction(msg, gp, statedb, header.Number, header.Hash(), tx, usedGas, vmenv)
 }
+
+// DeployBeaconRoots injects the EIP-4788 beacon roots contract into the state if
+// the block at the given time is the first one of the Prague fork. Chains which
+// start on Prague must carry the contract in their genesis allocation instead,
+// as the developer genesis does.
+func DeployBeaconRoots(config *params.ChainConfig, parentTime, time uint64, statedb *state.StateDB) {
+	if !config.IsPrague(time) || config.IsPrague(parentTime) {
+		return
+	}
+	statedb.SetCode(params.BeaconRootsAddress, params.BeaconRootsCode)
+	statedb.SetNonce(params.BeaconRootsAddress, 1)
+}
+
+// ProcessBeaconBlockRoot applies the EIP-4788 system call to the beacon block root
+// contract. This method is exported to be used in tests.
+func ProcessBeaconBlockRoot(beaconRoot common.Hash, vmenv *vm.QRVM, statedb *state.StateDB) {
+	msg := &Message{
+		From:      params.SystemAddress,
+		GasLimit:  30_000_000,
+		GasPrice:  common.Big0,
+		GasFeeCap: common.Big0,
+		GasTipCap: common.Big0,
+		To:        &params.BeaconRootsAddress,
+		Data:      beaconRoot[:],
+	}
+	vmenv.Reset(NewQRVMTxContext(msg), statedb)
+	statedb.AddAddressToAccessList(params.BeaconRootsAddress)
+	_, _, _ = vmenv.Call(vm.AccountRef(msg.From), *msg.To, msg.Data, 30_000_000, common.Big0)
+	statedb.Finalise(true)
+}
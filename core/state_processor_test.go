@@ -17,11 +17,12 @@
 package core
 
 import (
+	"bytes"
 	"math"
 	"math/big"
+	"strings"
 	"testing"
 
-	walletmldsa87 "github.com/theQRL/go-qrllib/wallet/ml_dsa_87"
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/consensus"
 	"github.com/theQRL/go-zond/consensus/beacon"
@@ -49,7 +50,7 @@ func TestStateProcessorErrors(t *testing.T) {
 		key2, _ = pqcrypto.HexToWallet("a7b1a3005d9e110009c48d45deb43f0a0e31846ed2c5aaefb6d4238040ad4c08794ffe65585c13eb6948c2faf6db90c2")
 	)
 
-	var mkDynamicTx = func(key *walletmldsa87.Wallet, nonce uint64, to common.Address, value *big.Int, gasLimit uint64, gasTipCap, gasFeeCap *big.Int) *types.Transaction {
+	var mkDynamicTx = func(key pqcrypto.Wallet, nonce uint64, to common.Address, value *big.Int, gasLimit uint64, gasTipCap, gasFeeCap *big.Int) *types.Transaction {
 		tx, _ := types.SignTx(types.NewTx(&types.DynamicFeeTx{
 			Nonce:     nonce,
 			GasTipCap: gasTipCap,
@@ -305,6 +306,94 @@ func TestStateProcessorErrors(t *testing.T) {
 	}
 }
 
+// TestAccountAbstractionTransactions tests that account abstraction transactions
+// are executed once their sender contract approves them, charging the contract
+// for the validation phase, and that blocks with unapproved ones, or ones whose
+// validation phase tries to modify state, are rejected.
+func TestAccountAbstractionTransactions(t *testing.T) {
+	var (
+		config = params.TestChainConfig
+		signer = types.LatestSigner(config)
+
+		// approver returns the validateTransaction selector when called by the
+		// entry point, and accepts any other call.
+		approver = append(append(common.FromHex("3361aaaa14600957005b7f"), common.RightPadBytes(validationSelector, 32)...), common.FromHex("60005260206000f3")...)
+		// rejecter returns an empty word to any call.
+		rejecter = common.FromHex("60206000f3")
+		// writer stores a word before approving like the approver does.
+		writer = append(append(common.FromHex("60016000553361aaaa14600e57005b7f"), common.RightPadBytes(validationSelector, 32)...), common.FromHex("60005260206000f3")...)
+
+		approverAddr = common.BytesToAddress([]byte{0xaa, 0x01})
+		rejecterAddr = common.BytesToAddress([]byte{0xaa, 0x02})
+		writerAddr   = common.BytesToAddress([]byte{0xaa, 0x03})
+		recipient    = common.BytesToAddress([]byte{0xbb})
+		funds        = big.NewInt(1000000000000000000)
+
+		gspec = &Genesis{
+			Config: config,
+			Alloc: GenesisAlloc{
+				approverAddr: {Balance: funds, Code: approver, Nonce: 1},
+				rejecterAddr: {Balance: funds, Code: rejecter, Nonce: 1},
+				writerAddr:   {Balance: funds, Code: writer, Nonce: 1},
+			},
+		}
+	)
+	mkTx := func(sender common.Address) *types.Transaction {
+		tx, _ := types.NewTx(&types.AccountAbstractionTx{
+			ChainID:       config.ChainID,
+			Nonce:         1,
+			GasTipCap:     big.NewInt(0),
+			GasFeeCap:     big.NewInt(875000000),
+			Gas:           params.TxGas + 50000,
+			ValidationGas: 50000,
+			Sender:        sender,
+			To:            &recipient,
+			Value:         big.NewInt(1),
+		}).WithSignaturePublicKeyAndDescriptor(signer, []byte{0x01}, nil, nil)
+		return tx
+	}
+	db, blocks, receipts := GenerateChainWithGenesis(gspec, beacon.New(), 1, func(i int, b *BlockGen) {
+		b.AddTx(mkTx(approverAddr))
+	})
+	blockchain, _ := NewBlockChain(db, nil, gspec, beacon.New(), vm.Config{}, nil)
+	defer blockchain.Stop()
+	if _, err := blockchain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	receipt := receipts[0][0]
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatal("account abstraction transaction failed")
+	}
+	if receipt.ValidationGasUsed == 0 || receipt.ValidationGasUsed > 50000 {
+		t.Fatalf("unexpected validation gas used: %d", receipt.ValidationGasUsed)
+	}
+	if want := params.TxGas + receipt.ValidationGasUsed; receipt.GasUsed != want {
+		t.Fatalf("gas used mismatch: have %d, want %d", receipt.GasUsed, want)
+	}
+	state, _ := blockchain.State()
+	if nonce := state.GetNonce(approverAddr); nonce != 2 {
+		t.Fatalf("sender nonce mismatch: have %d, want 2", nonce)
+	}
+	if balance := state.GetBalance(recipient); balance.Cmp(common.Big1) != 0 {
+		t.Fatalf("recipient balance mismatch: have %v, want 1", balance)
+	}
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), blocks[0].BaseFee())
+	if want := new(big.Int).Sub(new(big.Int).Sub(funds, fee), common.Big1); state.GetBalance(approverAddr).Cmp(want) != 0 {
+		t.Fatalf("sender balance mismatch: have %v, want %v", state.GetBalance(approverAddr), want)
+	}
+
+	// Blocks containing transactions the sender contract didn't approve are invalid
+	block := GenerateBadBlock(blocks[0], beacon.New(), types.Transactions{mkTx(rejecterAddr)}, config)
+	if _, err := blockchain.InsertChain(types.Blocks{block}); err == nil || !strings.Contains(err.Error(), ErrValidationFailed.Error()) {
+		t.Fatalf("expected validation failure, got %v", err)
+	}
+	// The validation phase is a static call, so storage writes make it fail
+	block = GenerateBadBlock(blocks[0], beacon.New(), types.Transactions{mkTx(writerAddr)}, config)
+	if _, err := blockchain.InsertChain(types.Blocks{block}); err == nil || !strings.Contains(err.Error(), vm.ErrWriteProtection.Error()) {
+		t.Fatalf("expected write protection failure, got %v", err)
+	}
+}
+
 // GenerateBadBlock constructs a "block" which contains the transactions. The transactions are not expected to be
 // valid, and no proper post-state can be made. But from the perspective of the blockchain, the block is sufficiently
 // valid to be considered for import:
@@ -345,3 +434,118 @@ func GenerateBadBlock(parent *types.Block, engine consensus.Engine, txs types.Tr
 	body := &types.Body{Transactions: txs, Withdrawals: []*types.Withdrawal{}}
 	return types.NewBlock(header, body, receipts, trie.NewStackTrie(nil))
 }
+
+// Tests that the parent beacon block roots are stored in the beacon roots
+// contract after the Prague fork, and that headers without them are rejected.
+func TestProcessBeaconBlockRoot(t *testing.T) {
+	var (
+		config = *params.TestChainConfig
+		gspec  = &Genesis{
+			Config: &config,
+			Alloc: GenesisAlloc{
+				params.BeaconRootsAddress: {Nonce: 1, Code: params.BeaconRootsCode, Balance: common.Big0},
+			},
+		}
+		roots = []common.Hash{{0x01}, {0x02}, {0x03}}
+	)
+	config.PragueTime = new(uint64)
+
+	db, blocks, _ := GenerateChainWithGenesis(gspec, beacon.New(), len(roots), func(i int, b *BlockGen) {
+		b.SetParentBeaconRoot(roots[i])
+	})
+	blockchain, _ := NewBlockChain(db, nil, gspec, beacon.New(), vm.Config{}, nil)
+	defer blockchain.Stop()
+
+	// Headers missing the beacon root must be rejected post Prague
+	header := types.CopyHeader(blocks[0].Header())
+	header.ParentBeaconRoot = nil
+	if _, err := blockchain.InsertChain(types.Blocks{blocks[0].WithSeal(header)}); err == nil || !strings.Contains(err.Error(), "missing beaconRoot") {
+		t.Fatalf("block without beacon root not rejected: %v", err)
+	}
+	if _, err := blockchain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	statedb, _ := blockchain.State()
+	for i, block := range blocks {
+		if have := *block.BeaconRoot(); have != roots[i] {
+			t.Fatalf("block %d: beacon root mismatch: have %x, want %x", i, have, roots[i])
+		}
+		var (
+			timeIndex = common.BigToHash(new(big.Int).SetUint64(block.Time() % 8191))
+			rootIndex = common.BigToHash(new(big.Int).SetUint64(block.Time()%8191 + 8191))
+		)
+		if have := statedb.GetState(params.BeaconRootsAddress, timeIndex); have.Big().Uint64() != block.Time() {
+			t.Fatalf("block %d: stored timestamp mismatch: have %d, want %d", i, have.Big(), block.Time())
+		}
+		if have := statedb.GetState(params.BeaconRootsAddress, rootIndex); have != roots[i] {
+			t.Fatalf("block %d: stored root mismatch: have %x, want %x", i, have, roots[i])
+		}
+	}
+}
+
+// Tests that the beacon roots contract is deployed when a chain which doesn't
+// predeploy it in its genesis crosses the Prague fork, and that the roots are
+// stored from then on.
+func TestProcessBeaconBlockRootAtPrague(t *testing.T) {
+	var (
+		config = *params.TestChainConfig
+		gspec  = &Genesis{Config: &config}
+		root   = common.Hash{0x01}
+	)
+	config.PragueTime = new(uint64)
+	*config.PragueTime = 20 // Blocks are generated 10 seconds apart
+
+	db, blocks, _ := GenerateChainWithGenesis(gspec, beacon.New(), 2, func(i int, b *BlockGen) {
+		if i == 1 {
+			b.SetParentBeaconRoot(root)
+		}
+	})
+	if blocks[0].BeaconRoot() != nil || blocks[1].BeaconRoot() == nil {
+		t.Fatalf("fork transition mismatch: pre-Prague root %v, post-Prague root %v", blocks[0].BeaconRoot(), blocks[1].BeaconRoot())
+	}
+	blockchain, _ := NewBlockChain(db, nil, gspec, beacon.New(), vm.Config{}, nil)
+	defer blockchain.Stop()
+
+	if _, err := blockchain.InsertChain(blocks[:1]); err != nil {
+		t.Fatalf("failed to insert pre-Prague block: %v", err)
+	}
+	statedb, _ := blockchain.State()
+	if statedb.GetCodeSize(params.BeaconRootsAddress) != 0 {
+		t.Fatalf("beacon roots contract deployed before Prague")
+	}
+	if _, err := blockchain.InsertChain(blocks[1:]); err != nil {
+		t.Fatalf("failed to insert Prague block: %v", err)
+	}
+	statedb, _ = blockchain.State()
+	if code := statedb.GetCode(params.BeaconRootsAddress); !bytes.Equal(code, params.BeaconRootsCode) {
+		t.Fatalf("beacon roots contract not deployed at Prague")
+	}
+	rootIndex := common.BigToHash(new(big.Int).SetUint64(blocks[1].Time()%8191 + 8191))
+	if have := statedb.GetState(params.BeaconRootsAddress, rootIndex); have != root {
+		t.Fatalf("stored root mismatch: have %x, want %x", have, root)
+	}
+}
+
+// Tests that the beacon roots contract is only deployed at the Prague fork, not
+// on later blocks of chains which start on Prague without it in their genesis.
+func TestProcessBeaconBlockRootAfterPrague(t *testing.T) {
+	var (
+		config = *params.TestChainConfig
+		gspec  = &Genesis{Config: &config}
+	)
+	config.PragueTime = new(uint64)
+
+	db, blocks, _ := GenerateChainWithGenesis(gspec, beacon.New(), 2, func(i int, b *BlockGen) {
+		b.SetParentBeaconRoot(common.Hash{byte(i + 1)})
+	})
+	blockchain, _ := NewBlockChain(db, nil, gspec, beacon.New(), vm.Config{}, nil)
+	defer blockchain.Stop()
+
+	if _, err := blockchain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	statedb, _ := blockchain.State()
+	if statedb.GetCodeSize(params.BeaconRootsAddress) != 0 {
+		t.Fatalf("beacon roots contract deployed after Prague")
+	}
+}
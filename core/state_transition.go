@@ -17,6 +17,7 @@
 package core
 
 import (
+	"bytes"
 	"fmt"
 	"math"
 	"math/big"
@@ -25,15 +26,17 @@ import (
 	cmath "github.com/theQRL/go-zond/common/math"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/crypto"
 	"github.com/theQRL/go-zond/params"
 )
 
 // ExecutionResult includes all output after executing given qrvm
 // message no matter the execution itself is successful or not.
 type ExecutionResult struct {
-	UsedGas    uint64 // Total used gas but include the refunded gas
-	Err        error  // Any error encountered during the execution(listed in core/vm/errors.go)
-	ReturnData []byte // Returned data from qrvm(function result or data supplied with revert opcode)
+	UsedGas           uint64 // Total used gas but include the refunded gas
+	ValidationGasUsed uint64 // Gas used by the validation phase of account abstraction messages
+	Err               error  // Any error encountered during the execution(listed in core/vm/errors.go)
+	ReturnData        []byte // Returned data from qrvm(function result or data supplied with revert opcode)
 }
 
 // Unwrap returns the internal qrvm error which allows us for further
@@ -134,9 +137,18 @@ type Message struct {
 	AccessList types.AccessList
 
 	// When SkipAccountChecks is true, the message nonce is not checked against the
-	// account nonce in state. It also disables checking that the sender is an EOA.
+	// account nonce in state. It also disables checking that the sender is an EOA,
+	// and that the sender contract of an account abstraction message approves it.
 	// This field will be set to true for operations like RPC qrl_call.
 	SkipAccountChecks bool
+
+	// When IsAccountAbstraction is true, the sender is a contract which has to
+	// approve the message before it is executed. It is called with the signing
+	// hash and the authorization data, and may use at most ValidationGas.
+	IsAccountAbstraction bool
+	ValidationGas        uint64
+	SigHash              common.Hash
+	Authorization        []byte
 }
 
 // TransactionToMessage converts a transaction into a Message.
@@ -153,6 +165,12 @@ func TransactionToMessage(tx *types.Transaction, s types.Signer, baseFee *big.In
 		AccessList:        tx.AccessList(),
 		SkipAccountChecks: false,
 	}
+	if tx.Type() == types.AccountAbstractionTxType {
+		msg.IsAccountAbstraction = true
+		msg.ValidationGas = tx.ValidationGas()
+		msg.SigHash = s.Hash(tx)
+		msg.Authorization = tx.RawSignatureValue()
+	}
 	// If baseFee provided, set gasPrice to effectiveGasPrice.
 	if baseFee != nil {
 		msg.GasPrice = cmath.BigMin(msg.GasPrice.Add(msg.GasTipCap, baseFee), msg.GasFeeCap)
@@ -260,12 +278,27 @@ func (st *StateTransition) preCheck() error {
 			return fmt.Errorf("%w: address %v, nonce: %d", ErrNonceMax,
 				msg.From.Hex(), stNonce)
 		}
-		// Make sure the sender is an EOA
+		// Make sure the sender is an EOA, or a contract for account
+		// abstraction transactions
 		codeHash := st.state.GetCodeHash(msg.From)
-		if codeHash != (common.Hash{}) && codeHash != types.EmptyCodeHash {
+		isEOA := codeHash == (common.Hash{}) || codeHash == types.EmptyCodeHash
+		if !msg.IsAccountAbstraction && !isEOA {
 			return fmt.Errorf("%w: address %v, codehash: %s", ErrSenderNoEOA,
 				msg.From.Hex(), codeHash)
 		}
+		if msg.IsAccountAbstraction && isEOA {
+			return fmt.Errorf("%w: address %v", ErrSenderNoContract, msg.From.Hex())
+		}
+	}
+	if msg.IsAccountAbstraction {
+		if !st.qrvm.ChainConfig().IsCancun(st.qrvm.Context.Time) {
+			return fmt.Errorf("%w: address %v, type: %d", ErrTxTypeNotSupported,
+				msg.From.Hex(), types.AccountAbstractionTxType)
+		}
+		if msg.ValidationGas > msg.GasLimit {
+			return fmt.Errorf("%w: address %v, validationGas: %d, gas: %d", ErrValidationGasTooHigh,
+				msg.From.Hex(), msg.ValidationGas, msg.GasLimit)
+		}
 	}
 
 	// Make sure that transaction gasFeeCap is greater than the baseFee (post london)
@@ -358,6 +391,15 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 	// - prepare accessList
 	st.state.Prepare(rules, msg.From, st.qrvm.Context.Coinbase, msg.To, vm.ActivePrecompiles(rules), msg.AccessList)
 
+	// Let the sender contract of account abstraction messages approve them
+	// before anything is executed on their behalf.
+	var validationGasUsed uint64
+	if msg.IsAccountAbstraction {
+		if validationGasUsed, err = st.validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	var (
 		ret   []byte
 		vmerr error // vm errors do not effect consensus and are therefore not assigned to err
@@ -385,12 +427,97 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 	}
 
 	return &ExecutionResult{
-		UsedGas:    st.gasUsed(),
-		Err:        vmerr,
-		ReturnData: ret,
+		UsedGas:           st.gasUsed(),
+		ValidationGasUsed: validationGasUsed,
+		Err:               vmerr,
+		ReturnData:        ret,
 	}, nil
 }
 
+// validationSelector is the function selector of
+// validateTransaction(bytes32 sigHash, bytes authorization), which sender
+// contracts of account abstraction transactions have to implement. Contracts
+// approve a transaction by returning the selector, ERC-1271 style.
+var validationSelector = crypto.Keccak256([]byte("validateTransaction(bytes32,bytes)"))[:4]
+
+// validate runs the validation phase of an account abstraction message, calling
+// into the sender contract with at most the message validation gas. It returns
+// the gas used by the validation phase.
+func (st *StateTransition) validate() (uint64, error) {
+	msg := st.msg
+	if st.gasRemaining < msg.ValidationGas {
+		return 0, fmt.Errorf("%w: have %d, want %d", ErrIntrinsicGas, st.gasRemaining, msg.ValidationGas)
+	}
+	st.gasRemaining -= msg.ValidationGas
+
+	leftOver, err := runValidation(st.qrvm, msg.From, msg.SigHash, msg.Authorization, msg.ValidationGas)
+	st.gasRemaining += leftOver
+
+	// Simulated calls usually can't carry valid authorization data, as it would
+	// have to commit to the gas values being estimated. Charge for validation,
+	// but don't insist on the sender contract approving.
+	if msg.SkipAccountChecks {
+		return msg.ValidationGas - leftOver, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return msg.ValidationGas - leftOver, nil
+}
+
+// runValidation calls into the sender contract to validate a transaction and
+// checks that it approved it. The call is static, so the validation phase can't
+// modify any state that other transactions in the pool or block depend on.
+func runValidation(qrvm *vm.QRVM, sender common.Address, sigHash common.Hash, authorization []byte, gas uint64) (uint64, error) {
+	ret, leftOver, err := qrvm.StaticCall(vm.AccountRef(params.AccountAbstractionEntryPoint), sender, validationInput(sigHash, authorization), gas)
+	if err != nil {
+		return leftOver, fmt.Errorf("%w: address %v, err: %v", ErrValidationFailed, sender.Hex(), err)
+	}
+	if !bytes.Equal(ret, common.RightPadBytes(validationSelector, 32)) {
+		return leftOver, fmt.Errorf("%w: address %v, returned: %x", ErrValidationFailed, sender.Hex(), ret)
+	}
+	return leftOver, nil
+}
+
+// ValidateAccountAbstraction simulates the validation phase of an account
+// abstraction transaction on top of the given state, in the block described by
+// blockCtx. At most gasCap gas is made available to the sender contract. Any
+// change the simulation makes to the state is reverted. The transaction pool
+// uses it to reject transactions that would not be approved before they take
+// up any room.
+func ValidateAccountAbstraction(config *params.ChainConfig, blockCtx vm.BlockContext, statedb vm.StateDB, tx *types.Transaction, signer types.Signer, gasCap uint64) error {
+	from, err := types.Sender(signer, tx)
+	if err != nil {
+		return err
+	}
+	gas := tx.ValidationGas()
+	if gas > gasCap {
+		gas = gasCap
+	}
+	txCtx := vm.TxContext{
+		Origin:   from,
+		GasPrice: new(big.Int).Set(tx.GasFeeCap()),
+	}
+	qrvm := vm.NewQRVM(blockCtx, txCtx, statedb, config, vm.Config{})
+
+	snapshot := statedb.Snapshot()
+	defer statedb.RevertToSnapshot(snapshot)
+
+	_, err = runValidation(qrvm, from, signer.Hash(tx), tx.RawSignatureValue(), gas)
+	return err
+}
+
+// validationInput ABI-encodes a validateTransaction call.
+func validationInput(sigHash common.Hash, authorization []byte) []byte {
+	input := make([]byte, 0, 4+4*32+len(authorization))
+	input = append(input, validationSelector...)
+	input = append(input, sigHash[:]...)
+	input = append(input, common.LeftPadBytes([]byte{0x40}, 32)...)
+	input = append(input, common.LeftPadBytes(new(big.Int).SetUint64(uint64(len(authorization))).Bytes(), 32)...)
+	input = append(input, common.RightPadBytes(authorization, (len(authorization)+31)/32*32)...)
+	return input
+}
+
 func (st *StateTransition) refundGas(refundQuotient uint64) {
 	// Apply refund counter, capped to a refund quotient
 	refund := st.gasUsed() / refundQuotient
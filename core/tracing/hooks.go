@@ -0,0 +1,95 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package tracing defines the hooks a live tracer can install to observe the
+// chain as blocks are imported.
+package tracing
+
+import (
+	"math/big"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/types"
+)
+
+type (
+	// BlockStartHook is called before executing a block.
+	BlockStartHook = func(block *types.Block)
+
+	// BlockEndHook is called after executing a block. The error is non-nil if
+	// the block failed to be processed, validated or written, in which case
+	// all changes reported for it must be discarded.
+	BlockEndHook = func(err error)
+
+	// CloseHook is called when the chain is stopped, after the last block was
+	// processed. Tracers release their resources in it.
+	CloseHook = func()
+
+	// TxStartHook is called before the execution of a transaction starts.
+	TxStartHook = func(tx *types.Transaction, from common.Address)
+
+	// TxEndHook is called after the execution of a transaction ends. The
+	// receipt is nil if the transaction failed to apply.
+	TxEndHook = func(receipt *types.Receipt, err error)
+
+	// EnterHook is invoked when the processing of a message starts. The top
+	// level call frame has depth 0.
+	EnterHook = func(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int)
+
+	// ExitHook is invoked when the processing of a message ends. The reverted
+	// flag is set if the call frame failed, in which case its state changes
+	// are rolled back.
+	ExitHook = func(depth int, output []byte, gasUsed uint64, err error, reverted bool)
+
+	// BalanceChangeHook is called when the balance of an account changes.
+	BalanceChangeHook = func(addr common.Address, prev, new *big.Int)
+
+	// NonceChangeHook is called when the nonce of an account changes.
+	NonceChangeHook = func(addr common.Address, prev, new uint64)
+
+	// CodeChangeHook is called when the code of an account changes.
+	CodeChangeHook = func(addr common.Address, prevCodeHash common.Hash, prevCode []byte, codeHash common.Hash, code []byte)
+
+	// StorageChangeHook is called when the storage of an account changes.
+	StorageChangeHook = func(addr common.Address, slot common.Hash, prev, new common.Hash)
+
+	// LogHook is called when a log is emitted.
+	LogHook = func(log *types.Log)
+)
+
+// Hooks is the set of callbacks a live tracer installs. Any of them may be nil.
+//
+// State changes are reported as they happen, including the ones undoing the
+// changes of reverted call frames, so the last value reported for an item is
+// always its current value.
+type Hooks struct {
+	// Chain events
+	OnBlockStart BlockStartHook
+	OnBlockEnd   BlockEndHook
+	OnClose      CloseHook
+	// Transaction events
+	OnTxStart TxStartHook
+	OnTxEnd   TxEndHook
+	// VM events
+	OnEnter EnterHook
+	OnExit  ExitHook
+	// State events
+	OnBalanceChange BalanceChangeHook
+	OnNonceChange   NonceChangeHook
+	OnCodeChange    CodeChangeHook
+	OnStorageChange StorageChangeHook
+	OnLog           LogHook
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/tracing"
+	"github.com/theQRL/go-zond/core/vm"
+)
+
+// hooksLogger forwards the call frame events of the QRVM to the hooks of a
+// live tracer.
+type hooksLogger struct {
+	hooks *tracing.Hooks
+	depth int
+}
+
+// newHooksLogger returns a QRVM logger reporting to the given hooks, or nil if
+// the hooks are not interested in call frames.
+func newHooksLogger(hooks *tracing.Hooks) vm.QRVMLogger {
+	if hooks == nil || (hooks.OnEnter == nil && hooks.OnExit == nil) {
+		return nil
+	}
+	return &hooksLogger{hooks: hooks}
+}
+
+func (l *hooksLogger) CaptureTxStart(gasLimit uint64) {
+	l.depth = 0
+}
+
+func (l *hooksLogger) CaptureTxEnd(restGas uint64) {}
+
+func (l *hooksLogger) CaptureStart(env *vm.QRVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	if l.hooks.OnEnter == nil {
+		return
+	}
+	typ := vm.CALL
+	if create {
+		typ = vm.CREATE
+	}
+	l.hooks.OnEnter(0, byte(typ), from, to, input, gas, value)
+}
+
+func (l *hooksLogger) CaptureEnd(output []byte, gasUsed uint64, err error) {
+	if l.hooks.OnExit != nil {
+		l.hooks.OnExit(0, output, gasUsed, err, err != nil)
+	}
+}
+
+func (l *hooksLogger) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	l.depth++
+	if l.hooks.OnEnter != nil {
+		l.hooks.OnEnter(l.depth, byte(typ), from, to, input, gas, value)
+	}
+}
+
+func (l *hooksLogger) CaptureExit(output []byte, gasUsed uint64, err error) {
+	if l.hooks.OnExit != nil {
+		l.hooks.OnExit(l.depth, output, gasUsed, err, err != nil)
+	}
+	l.depth--
+}
+
+func (l *hooksLogger) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+}
+
+func (l *hooksLogger) CaptureFault(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+}
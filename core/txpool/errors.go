@@ -51,6 +51,12 @@ var (
 	// making the transaction invalid, rather a DOS protection.
 	ErrOversizedData = errors.New("oversized data")
 
+	// ErrValidationGasLimit is returned if an account abstraction transaction
+	// reserves more gas for its validation phase than the pool is willing to
+	// spend on transactions that might turn out to be invalid. This is not a
+	// consensus error, rather a DOS protection.
+	ErrValidationGasLimit = errors.New("exceeds validation gas limit")
+
 	// ErrFutureReplacePending is returned if a future transaction replaces a pending
 	// transaction. Future transactions should only be able to replace other future transactions.
 	ErrFutureReplacePending = errors.New("future transaction tries to replace pending")
@@ -0,0 +1,77 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"github.com/theQRL/go-zond/common"
+)
+
+// TxEventKind is the type of a transaction lifecycle change within the pool.
+type TxEventKind uint
+
+const (
+	TxEventAdded    TxEventKind = iota // Transaction entered the pool
+	TxEventReplaced                    // Transaction was replaced by a higher priced one
+	TxEventDropped                     // Transaction was evicted from the pool
+	TxEventIncluded                    // Transaction was included in a block
+	TxEventRequeued                    // Transaction was demoted or reinjected after a reorg
+)
+
+// String implements fmt.Stringer.
+func (k TxEventKind) String() string {
+	switch k {
+	case TxEventAdded:
+		return "added"
+	case TxEventReplaced:
+		return "replaced"
+	case TxEventDropped:
+		return "dropped"
+	case TxEventIncluded:
+		return "included"
+	case TxEventRequeued:
+		return "requeued"
+	default:
+		return "unknown"
+	}
+}
+
+// TxDropReason describes why a transaction was evicted from the pool.
+type TxDropReason string
+
+const (
+	DropUnderpriced   TxDropReason = "underpriced"        // Evicted in favour of better paying transactions
+	DropNonceTooLow   TxDropReason = "nonce too low"      // Nonce consumed by another transaction
+	DropNonceGap      TxDropReason = "nonce gap"          // Preceding transaction left, leaving a gap
+	DropInsufficient  TxDropReason = "insufficient funds" // Sender can't cover the transaction cost
+	DropGasLimit      TxDropReason = "gas limit exceeded" // Transaction exceeds the block gas limit
+	DropAccountLimit  TxDropReason = "account limit"      // Sender exceeded its allowance in the pool
+	DropPoolOverflow  TxDropReason = "pool overflow"      // Pool exceeded its global limits
+	DropLifetimeLimit TxDropReason = "expired"            // Transaction was stuck in the pool for too long
+)
+
+// TxEvent is a lifecycle change of a single transaction within the pool.
+type TxEvent struct {
+	Kind  TxEventKind
+	Hash  common.Hash    // Hash of the transaction the event is about
+	From  common.Address // Sender of the transaction
+	Nonce uint64         // Nonce of the transaction
+
+	Reason      TxDropReason // Reason of eviction for TxEventDropped
+	ReplacedBy  common.Hash  // Hash of the replacement for TxEventReplaced
+	BlockHash   common.Hash  // Hash of the including block for TxEventIncluded
+	BlockNumber uint64       // Number of the including block for TxEventIncluded
+}
@@ -0,0 +1,50 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package largepool
+
+import (
+	"github.com/theQRL/go-zond/log"
+)
+
+// Config are the configuration parameters of the large transaction pool.
+type Config struct {
+	Datadir   string // Data directory containing the pooled transactions ("" = in memory)
+	Datacap   uint64 // Soft-cap of the total size of the pooled transactions in bytes
+	PriceBump uint64 // Minimum price bump percentage to replace an already existing transaction (nonce)
+}
+
+// DefaultConfig contains the default configurations for the large transaction pool.
+var DefaultConfig = Config{
+	Datadir:   "largepool",
+	Datacap:   256 * 1024 * 1024,
+	PriceBump: 10,
+}
+
+// sanitize checks the provided user configurations and changes anything that's
+// unreasonable or unworkable.
+func (config *Config) sanitize() Config {
+	conf := *config
+	if conf.Datacap < txMaxSize {
+		log.Warn("Sanitizing invalid largepool storage cap", "provided", conf.Datacap, "updated", DefaultConfig.Datacap)
+		conf.Datacap = DefaultConfig.Datacap
+	}
+	if conf.PriceBump < 1 {
+		log.Warn("Sanitizing invalid largepool price bump", "provided", conf.PriceBump, "updated", DefaultConfig.PriceBump)
+		conf.PriceBump = DefaultConfig.PriceBump
+	}
+	return conf
+}
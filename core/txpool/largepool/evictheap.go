@@ -0,0 +1,133 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package largepool
+
+import (
+	"container/heap"
+	"math/big"
+
+	"github.com/theQRL/go-zond/common"
+)
+
+// evictHeap is a helper data structure to keep track of the cheapest account
+// in the pool in terms of fee paid per stored byte. When the pool runs out of
+// space, the tail transaction of the cheapest account is dropped first.
+//
+// The priority of an account is the lowest fee-per-byte across its pooled
+// transactions, since the account cannot be executed past that transaction
+// any faster than the miner is willing to include it. Local accounts are always
+// ordered after remote ones.
+type evictHeap struct {
+	index  map[common.Address][]*txMetadata // Pool's index of transactions, shared
+	locals map[common.Address]struct{}      // Pool's set of local accounts, shared
+
+	addrs []common.Address       // Heap of addresses to retrieve the cheapest out of
+	slots map[common.Address]int // Position of each address in the heap
+}
+
+// newEvictHeap creates a new heap of cheapest accounts in the large pool to
+// evict from in case of oversaturation.
+func newEvictHeap(index map[common.Address][]*txMetadata, locals map[common.Address]struct{}) *evictHeap {
+	h := &evictHeap{
+		index:  index,
+		locals: locals,
+		addrs:  make([]common.Address, 0, len(index)),
+		slots:  make(map[common.Address]int, len(index)),
+	}
+	for addr := range index {
+		h.slots[addr] = len(h.addrs)
+		h.addrs = append(h.addrs, addr)
+	}
+	heap.Init(h)
+	return h
+}
+
+// priority returns the eviction priority of an account, the lowest fee-per-byte
+// of any of its pooled transactions.
+func (h *evictHeap) priority(addr common.Address) *big.Int {
+	var lowest *big.Int
+	for _, meta := range h.index[addr] {
+		if lowest == nil || meta.feePerByte.Cmp(lowest) < 0 {
+			lowest = meta.feePerByte
+		}
+	}
+	return lowest
+}
+
+// local returns whether an account is exempt from eviction.
+func (h *evictHeap) local(addr common.Address) bool {
+	_, ok := h.locals[addr]
+	return ok
+}
+
+// Len implements sort.Interface as part of heap.Interface, returning the number
+// of accounts in the pool which can be considered for eviction.
+func (h *evictHeap) Len() int {
+	return len(h.addrs)
+}
+
+// Less implements sort.Interface as part of heap.Interface, returning which of
+// the two requested accounts has a cheaper bottleneck.
+func (h *evictHeap) Less(i, j int) bool {
+	if ilocal, jlocal := h.local(h.addrs[i]), h.local(h.addrs[j]); ilocal != jlocal {
+		return jlocal
+	}
+	return h.priority(h.addrs[i]).Cmp(h.priority(h.addrs[j])) < 0
+}
+
+// Swap implements sort.Interface as part of heap.Interface, moving two accounts
+// in the heap.
+func (h *evictHeap) Swap(i, j int) {
+	h.slots[h.addrs[i]], h.slots[h.addrs[j]] = h.slots[h.addrs[j]], h.slots[h.addrs[i]]
+	h.addrs[i], h.addrs[j] = h.addrs[j], h.addrs[i]
+}
+
+// Push implements heap.Interface, appending an item to the end of the account
+// ordering as well as the address to item slot mapping.
+func (h *evictHeap) Push(x any) {
+	addr := x.(common.Address)
+	h.slots[addr] = len(h.addrs)
+	h.addrs = append(h.addrs, addr)
+}
+
+// Pop implements heap.Interface, removing and returning the last element of the
+// heap.
+//
+// Note, use `heap.Pop`, not `evictHeap.Pop`. This method is used by Golang's
+// heap as a callback, not meant to be called by the pool directly.
+func (h *evictHeap) Pop() any {
+	addr := h.addrs[len(h.addrs)-1]
+	delete(h.slots, addr)
+	h.addrs = h.addrs[:len(h.addrs)-1]
+	return addr
+}
+
+// update adds, repositions or removes an account in the heap after its list of
+// pooled transactions changed.
+func (h *evictHeap) update(addr common.Address) {
+	slot, tracked := h.slots[addr]
+	switch {
+	case len(h.index[addr]) == 0 && tracked:
+		heap.Remove(h, slot)
+	case len(h.index[addr]) == 0:
+		// Account not tracked and nothing to track, noop
+	case tracked:
+		heap.Fix(h, slot)
+	default:
+		heap.Push(h, addr)
+	}
+}
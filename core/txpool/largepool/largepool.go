@@ -0,0 +1,1003 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package largepool implements the transaction pool for transactions carrying
+// large amounts of calldata.
+package largepool
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/state"
+	"github.com/theQRL/go-zond/core/txpool"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/event"
+	"github.com/theQRL/go-zond/log"
+	"github.com/theQRL/go-zond/metrics"
+	"github.com/theQRL/go-zond/params"
+	"github.com/theQRL/go-zond/qrldb"
+	"github.com/theQRL/go-zond/qrldb/leveldb"
+	"github.com/theQRL/go-zond/qrldb/memorydb"
+)
+
+const (
+	// largeDataSize is the amount of calldata above which a transaction is
+	// considered large and is routed into this pool instead of the legacy one.
+	// Note, the size of the signature and public key is deliberately not part
+	// of the calculation, as that is the same for every transaction.
+	//
+	// An account can only have transactions pooled in one subpool at a time,
+	// so the limit is kept above the maximum initcode size. That way contract
+	// deployments stay in the legacy pool and can be followed up by calls from
+	// the same account straight away.
+	largeDataSize = 64 * 1024
+
+	// txMaxSize is the maximum size a single transaction can have. Since the
+	// pool keeps the transactions on disk, it can afford to be more lenient
+	// than the legacy pool.
+	txMaxSize = 256 * 1024
+
+	// maxTxsPerAccount is the maximum number of large transactions a single
+	// account may have pooled at the same time.
+	maxTxsPerAccount = 16
+
+	// storeCache and storeHandles are the resources allocated to the database
+	// backing the pool when it's persisted on disk.
+	storeCache   = 16
+	storeHandles = 16
+)
+
+var (
+	// datausedGauge tracks the total size of the transactions in the pool.
+	datausedGauge = metrics.NewRegisteredGauge("largepool/dataused", nil)
+
+	// txsGauge tracks the number of transactions in the pool.
+	txsGauge = metrics.NewRegisteredGauge("largepool/txs", nil)
+
+	// evictMeter tracks the transactions dropped due to the pool being full.
+	evictMeter = metrics.NewRegisteredMeter("largepool/evict", nil)
+)
+
+// IsLarge returns whether a transaction carries enough calldata to be handled
+// by the large transaction pool.
+func IsLarge(tx *types.Transaction) bool {
+	return tx.Type() == types.DynamicFeeTxType && len(tx.Data()) >= largeDataSize
+}
+
+// BlockChain defines the minimal set of methods needed to back a large pool with
+// a chain. Exists to allow mocking the live chain out of tests.
+type BlockChain interface {
+	// Config retrieves the chain's fork configuration.
+	Config() *params.ChainConfig
+
+	// CurrentBlock returns the current head of the chain.
+	CurrentBlock() *types.Header
+
+	// GetBlock retrieves a specific block, used during pool resets.
+	GetBlock(hash common.Hash, number uint64) *types.Block
+
+	// StateAt returns a state database for a given root hash (generally the head).
+	StateAt(root common.Hash) (*state.StateDB, error)
+}
+
+// txMetadata is the minimal subset of transaction fields needed to keep the
+// pool's indices up to date without loading the transaction from disk.
+type txMetadata struct {
+	hash  common.Hash // Transaction hash, also the key in the backing store
+	nonce uint64      // Transaction nonce to keep the account list ordered
+	size  uint64      // Byte size of the transaction in the store
+	time  time.Time   // Time when the transaction was first seen
+
+	costCap    *big.Int // Needed to validate cumulative balance sufficiency
+	execTipCap *big.Int // Needed to prioritize inclusion and to check replacements
+	execFeeCap *big.Int // Needed to validate replacements
+	execGas    uint64   // Needed to check inclusion validity before reading the tx
+
+	feePerByte *big.Int // Maximum fee paid per stored byte, the eviction priority
+}
+
+// newTxMetadata assembles the metadata of a transaction stored in the given
+// number of bytes.
+func newTxMetadata(tx *types.Transaction, size uint64) *txMetadata {
+	fee := new(big.Int).Mul(tx.GasFeeCap(), new(big.Int).SetUint64(tx.Gas()))
+	return &txMetadata{
+		hash:       tx.Hash(),
+		nonce:      tx.Nonce(),
+		size:       size,
+		time:       tx.Time(),
+		costCap:    tx.Cost(),
+		execTipCap: tx.GasTipCap(),
+		execFeeCap: tx.GasFeeCap(),
+		execGas:    tx.Gas(),
+		feePerByte: fee.Div(fee, new(big.Int).SetUint64(size)),
+	}
+}
+
+// LargePool is the transaction pool dedicated to transactions with large amounts
+// of calldata. Its main goal is to keep a handful of heavy
+// transactions from flushing the cheap transfers out of the legacy pool.
+//
+// The pool differs from the legacy one in a few important ways:
+//   - Transactions are accounted by their byte size instead of by slots, and
+//     the pool is capped by the total size of the transactions it holds.
+//   - Transactions are kept in a database on disk (doubling as the journal),
+//     only the metadata needed for maintenance is held in memory.
+//   - Transactions are not allowed to be gapped, so there's no queue.
+//   - When full, the transactions of the account paying the least fee per
+//     stored byte are evicted first. Local accounts are never evicted.
+type LargePool struct {
+	config  Config                 // Pool configuration
+	reserve txpool.AddressReserver // Address reserver to ensure exclusivity across subpools
+
+	store  qrldb.KeyValueStore // Persistent data store for the pooled transactions
+	signer types.Signer        // Transaction signer to use for sender recovery
+	chain  BlockChain          // Chain object to access the state through
+
+	head       *types.Header         // Current head of the chain
+	state      *state.StateDB        // Current state at the head of the chain
+	validation *txpool.ValidationEnv // Environment to validate account abstraction transactions in
+	gasTip     *big.Int              // Currently accepted minimum gas tip
+
+	lookup map[common.Hash]common.Address   // Lookup table mapping hashes to tx senders
+	index  map[common.Address][]*txMetadata // Transactions grouped by account, sorted by nonce
+	spent  map[common.Address]*big.Int      // Expenditure tracking for individual accounts
+	locals map[common.Address]struct{}      // Accounts exempt from the pricing and eviction rules
+	evict  *evictHeap                       // Heap of cheapest accounts for eviction
+	stored uint64                           // Useful data size of all transactions on disk
+
+	insertFeed event.Feed // Event feed to send out new tx events on pool inclusion
+
+	txEventFeed  event.Feed              // Event feed to send out transaction lifecycle events
+	txEventScope event.SubscriptionScope // Subscription scope to track lifecycle subscribers
+	txEvents     []*txpool.TxEvent       // Lifecycle events waiting to be posted
+
+	lock sync.RWMutex // Mutex protecting the pool during reorg handling
+}
+
+// New creates a new large transaction pool to gather, sort and filter inbound
+// large transactions from the network.
+func New(config Config, chain BlockChain) *LargePool {
+	// Sanitize the input to ensure no vulnerable gas prices are set
+	config = (&config).sanitize()
+
+	// Create the transaction pool with its initial settings
+	return &LargePool{
+		config: config,
+		signer: types.LatestSigner(chain.Config()),
+		chain:  chain,
+		lookup: make(map[common.Hash]common.Address),
+		index:  make(map[common.Address][]*txMetadata),
+		spent:  make(map[common.Address]*big.Int),
+		locals: make(map[common.Address]struct{}),
+	}
+}
+
+// Filter returns whether the given transaction can be consumed by the large
+// pool.
+func (p *LargePool) Filter(tx *types.Transaction) bool {
+	return IsLarge(tx)
+}
+
+// Init sets the gas price needed to keep a transaction in the pool and the chain
+// head to allow balance / nonce checks. The transactions persisted on disk by a
+// previous run will be loaded and filtered based on the provided starting
+// settings.
+func (p *LargePool) Init(gasTip *big.Int, head *types.Header, reserve txpool.AddressReserver) error {
+	p.reserve = reserve
+
+	if p.config.Datadir == "" {
+		p.store = memorydb.New()
+	} else {
+		store, err := leveldb.New(p.config.Datadir, storeCache, storeHandles, "largepool/db/", false)
+		if err != nil {
+			return err
+		}
+		p.store = store
+	}
+	// Initialize the state with head block, or fallback to empty one in
+	// case the head state is not available (might occur when node is not
+	// fully synced).
+	statedb, err := p.chain.StateAt(head.Root)
+	if err != nil {
+		statedb, err = p.chain.StateAt(types.EmptyRootHash)
+	}
+	if err != nil {
+		p.store.Close()
+		return err
+	}
+	p.head, p.state = head, statedb
+	p.validation = txpool.NewValidationEnv(p.chain.Config(), head, statedb, p.chain)
+	p.gasTip = new(big.Int).Set(gasTip)
+
+	// Index all transactions on disk and delete anything unprocessable
+	p.load()
+
+	p.evict = newEvictHeap(p.index, p.locals)
+	p.updateStorageMetrics()
+
+	log.Info("Large transaction pool started", "txs", len(p.lookup), "size", common.StorageSize(p.stored))
+	return nil
+}
+
+// load iterates over all the transactions persisted by a previous run, and
+// reinserts the ones still executable in the current state into the indices.
+// Everything else is deleted from the store.
+func (p *LargePool) load() {
+	var (
+		txs   = make(map[common.Address][]*types.Transaction)
+		sizes = make(map[common.Hash]uint64)
+		drop  []common.Hash
+	)
+	it := p.store.NewIterator(nil, nil)
+	for it.Next() {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(it.Value()); err != nil {
+			log.Error("Failed to decode pooled large transaction", "key", common.BytesToHash(it.Key()), "err", err)
+			drop = append(drop, common.BytesToHash(it.Key()))
+			continue
+		}
+		from, err := types.Sender(p.signer, tx)
+		if err != nil || !p.Filter(tx) {
+			drop = append(drop, tx.Hash())
+			continue
+		}
+		txs[from] = append(txs[from], tx)
+		sizes[tx.Hash()] = uint64(len(it.Value()))
+	}
+	it.Release()
+
+	for from, list := range txs {
+		sort.Slice(list, func(i, j int) bool { return list[i].Nonce() < list[j].Nonce() })
+
+		var (
+			next    = p.state.GetNonce(from)
+			balance = p.state.GetBalance(from)
+			spent   = new(big.Int)
+			metas   []*txMetadata
+		)
+		for _, tx := range list {
+			if tx.Nonce() < next {
+				drop = append(drop, tx.Hash())
+				continue
+			}
+			cost := tx.Cost()
+			if tx.Nonce() != next || len(metas) >= maxTxsPerAccount || tx.GasTipCapIntCmp(p.gasTip) < 0 || new(big.Int).Add(spent, cost).Cmp(balance) > 0 {
+				// Transaction gapped, over the limits or unaffordable, drop the
+				// rest of the account too
+				drop = append(drop, tx.Hash())
+				next = math.MaxUint64
+				continue
+			}
+			spent.Add(spent, cost)
+			metas = append(metas, newTxMetadata(tx, sizes[tx.Hash()]))
+			next++
+		}
+		if len(metas) == 0 {
+			continue
+		}
+		if err := p.reserve(from, true); err != nil {
+			for _, meta := range metas {
+				drop = append(drop, meta.hash)
+			}
+			continue
+		}
+		p.index[from] = metas
+		p.spent[from] = spent
+		for _, meta := range metas {
+			p.lookup[meta.hash] = from
+			p.stored += meta.size
+		}
+	}
+	if len(drop) > 0 {
+		log.Info("Dropping unprocessable large transactions", "count", len(drop))
+		for _, hash := range drop {
+			if err := p.store.Delete(hash.Bytes()); err != nil {
+				log.Error("Failed to delete large transaction", "hash", hash, "err", err)
+			}
+		}
+	}
+}
+
+// Close closes down the underlying persistent store.
+func (p *LargePool) Close() error {
+	p.txEventScope.Close()
+	return p.store.Close()
+}
+
+// Reset implements txpool.SubPool, allowing the large pool's internal state to be
+// kept in sync with the main transaction pool's internal state.
+func (p *LargePool) Reset(oldHead, newHead *types.Header) {
+	// If anyone's interested in the transaction lifecycles, gather the inclusions
+	// to tell them apart from transactions invalidated by a nonce reuse
+	reinject, blocks := p.reorged(oldHead, newHead, p.txEventScope.Count() > 0)
+
+	included := make(map[common.Hash]*types.Header)
+	for _, block := range blocks {
+		for _, tx := range block.Transactions() {
+			included[tx.Hash()] = block.Header()
+		}
+	}
+
+	statedb, err := p.chain.StateAt(newHead.Root)
+	if err != nil {
+		log.Error("Failed to reset largepool state", "err", err)
+		return
+	}
+	p.lock.Lock()
+
+	p.head, p.state = newHead, statedb
+	p.validation = txpool.NewValidationEnv(p.chain.Config(), newHead, statedb, p.chain)
+
+	// Run the revalidation on all accounts, dropping anything included or not
+	// affordable any more
+	for addr := range p.index {
+		p.recheck(addr, included)
+	}
+	// Inject any transactions discarded due to reorgs
+	log.Debug("Reinjecting stale large transactions", "count", len(reinject))
+	core.SenderCacher.Recover(p.signer, reinject)
+
+	var added []*types.Transaction
+	for _, tx := range reinject {
+		if err := p.add(tx, false); err != nil {
+			log.Trace("Failed to reinject large transaction", "hash", tx.Hash(), "err", err)
+			continue
+		}
+		added = append(added, tx)
+		p.pushTxEvent(tx, &txpool.TxEvent{Kind: txpool.TxEventRequeued})
+	}
+	p.updateStorageMetrics()
+	events := p.txEvents
+	p.txEvents = nil
+	p.lock.Unlock()
+
+	if len(added) > 0 {
+		p.insertFeed.Send(core.NewTxsEvent{Txs: added})
+	}
+	if len(events) > 0 {
+		p.txEventFeed.Send(events)
+	}
+}
+
+// reorged returns the large transactions that were included in the old chain
+// but not in the new one, if the pool is reset across a shallow reorg. Alongside,
+// it returns the blocks of the new chain segment, which are only retrieved when
+// extending the chain if track is set.
+func (p *LargePool) reorged(oldHead, newHead *types.Header, track bool) ([]*types.Transaction, []*types.Block) {
+	if oldHead == nil {
+		return nil, nil
+	}
+	if oldHead.Hash() == newHead.ParentHash {
+		if !track {
+			return nil, nil
+		}
+		if block := p.chain.GetBlock(newHead.Hash(), newHead.Number.Uint64()); block != nil {
+			return nil, []*types.Block{block}
+		}
+		return nil, nil
+	}
+	// If the reorg is too deep, avoid doing it (will happen during snap sync)
+	oldNum := oldHead.Number.Uint64()
+	newNum := newHead.Number.Uint64()
+
+	if depth := uint64(math.Abs(float64(oldNum) - float64(newNum))); depth > 64 {
+		log.Debug("Skipping deep large transaction reorg", "depth", depth)
+		return nil, nil
+	}
+	var (
+		rem = p.chain.GetBlock(oldHead.Hash(), oldHead.Number.Uint64())
+		add = p.chain.GetBlock(newHead.Hash(), newHead.Number.Uint64())
+	)
+	if rem == nil || add == nil {
+		// Either a setHead was performed or the new head is already gone,
+		// either way there's nothing sensible to reinject
+		return nil, nil
+	}
+	var (
+		discarded, included types.Transactions
+		blocks              []*types.Block
+	)
+	for rem.NumberU64() > add.NumberU64() {
+		discarded = append(discarded, rem.Transactions()...)
+		if rem = p.chain.GetBlock(rem.ParentHash(), rem.NumberU64()-1); rem == nil {
+			log.Error("Unrooted old chain seen by large pool", "block", oldHead.Number, "hash", oldHead.Hash())
+			return nil, nil
+		}
+	}
+	for add.NumberU64() > rem.NumberU64() {
+		included = append(included, add.Transactions()...)
+		blocks = append(blocks, add)
+		if add = p.chain.GetBlock(add.ParentHash(), add.NumberU64()-1); add == nil {
+			log.Error("Unrooted new chain seen by large pool", "block", newHead.Number, "hash", newHead.Hash())
+			return nil, nil
+		}
+	}
+	for rem.Hash() != add.Hash() {
+		discarded = append(discarded, rem.Transactions()...)
+		if rem = p.chain.GetBlock(rem.ParentHash(), rem.NumberU64()-1); rem == nil {
+			log.Error("Unrooted old chain seen by large pool", "block", oldHead.Number, "hash", oldHead.Hash())
+			return nil, nil
+		}
+		included = append(included, add.Transactions()...)
+		blocks = append(blocks, add)
+		if add = p.chain.GetBlock(add.ParentHash(), add.NumberU64()-1); add == nil {
+			log.Error("Unrooted new chain seen by large pool", "block", newHead.Number, "hash", newHead.Hash())
+			return nil, nil
+		}
+	}
+	var lost []*types.Transaction
+	for _, tx := range types.TxDifference(discarded, included) {
+		if p.Filter(tx) {
+			lost = append(lost, tx)
+		}
+	}
+	// Reinject in nonce order, otherwise the gapless insertion would reject
+	// all but the first transaction of each account
+	sort.SliceStable(lost, func(i, j int) bool { return lost[i].Nonce() < lost[j].Nonce() })
+	return lost, blocks
+}
+
+// recheck verifies the pool's content for a specific account and drops anything
+// that was included in the chain, became gapped or is no longer affordable. The
+// inclusions of the new chain segment are used to report the dropped ones.
+func (p *LargePool) recheck(addr common.Address, blocks map[common.Hash]*types.Header) {
+	var (
+		txs  = p.index[addr]
+		next = p.state.GetNonce(addr)
+	)
+	// Drop all transactions included in the chain
+	var included int
+	for included < len(txs) && txs[included].nonce < next {
+		if header, ok := blocks[txs[included].hash]; ok {
+			p.pushTxEvent(nil, &txpool.TxEvent{
+				Kind:        txpool.TxEventIncluded,
+				Hash:        txs[included].hash,
+				From:        addr,
+				Nonce:       txs[included].nonce,
+				BlockHash:   header.Hash(),
+				BlockNumber: header.Number.Uint64(),
+			})
+		} else {
+			p.dropTxEvents(addr, txs[included:included+1], txpool.DropNonceTooLow)
+		}
+		included++
+	}
+	p.forget(addr, txs[:included])
+	txs = txs[included:]
+
+	// If a reorg opened a nonce gap in front of the pooled transactions, none
+	// of them are executable any more
+	if len(txs) > 0 && txs[0].nonce != next {
+		p.dropTxEvents(addr, txs, txpool.DropNonceGap)
+		p.forget(addr, txs)
+		txs = nil
+	}
+	// Drop everything from the first transaction the account cannot afford
+	var (
+		balance = p.state.GetBalance(addr)
+		spent   = new(big.Int)
+	)
+	for i, meta := range txs {
+		if spent.Add(spent, meta.costCap).Cmp(balance) > 0 {
+			p.dropTxEvents(addr, txs[i:], txpool.DropInsufficient)
+			p.forget(addr, txs[i:])
+			txs = txs[:i]
+			break
+		}
+	}
+	p.index[addr] = txs
+	p.settle(addr)
+}
+
+// forget removes the given transactions of an account from the store and the
+// global indices. The account's own transaction list is left for the caller to
+// update.
+func (p *LargePool) forget(addr common.Address, metas []*txMetadata) {
+	for _, meta := range metas {
+		if err := p.store.Delete(meta.hash.Bytes()); err != nil {
+			log.Error("Failed to delete large transaction", "hash", meta.hash, "err", err)
+		}
+		delete(p.lookup, meta.hash)
+		p.stored -= meta.size
+		p.spent[addr].Sub(p.spent[addr], meta.costCap)
+	}
+}
+
+// settle updates the eviction heap after an account's transaction list changed,
+// releasing the account altogether if nothing remains pooled.
+func (p *LargePool) settle(addr common.Address) {
+	if len(p.index[addr]) > 0 {
+		p.evict.update(addr)
+		return
+	}
+	delete(p.index, addr)
+	delete(p.spent, addr)
+	p.evict.update(addr)
+
+	if err := p.reserve(addr, false); err != nil {
+		log.Error("Failed to release largepool account", "addr", addr, "err", err)
+	}
+}
+
+// SetGasTip implements txpool.SubPool, allowing the large pool's gas requirements
+// to be kept in sync with the main transaction pool's gas requirements.
+func (p *LargePool) SetGasTip(tip *big.Int) {
+	p.lock.Lock()
+
+	old := p.gasTip
+	p.gasTip = new(big.Int).Set(tip)
+
+	// If the min miner fee increased, remove transactions below the new threshold
+	if old == nil || p.gasTip.Cmp(old) > 0 {
+		for addr, txs := range p.index {
+			if _, ok := p.locals[addr]; ok {
+				continue
+			}
+			for i, meta := range txs {
+				if meta.execTipCap.Cmp(p.gasTip) < 0 {
+					p.dropTxEvents(addr, txs[i:], txpool.DropUnderpriced)
+					p.forget(addr, txs[i:])
+					p.index[addr] = txs[:i]
+					p.settle(addr)
+					break
+				}
+			}
+		}
+		p.updateStorageMetrics()
+	}
+	events := p.txEvents
+	p.txEvents = nil
+	p.lock.Unlock()
+
+	if len(events) > 0 {
+		p.txEventFeed.Send(events)
+	}
+	log.Info("Large pool tip threshold updated", "tip", tip)
+}
+
+// validateTx checks whether a transaction is valid according to the consensus
+// rules and adheres to some heuristic limits of the local node (price and size).
+func (p *LargePool) validateTx(tx *types.Transaction, local bool) error {
+	// Ensure the transaction adheres to basic pool filters (type, size, tip) and
+	// consensus rules
+	baseOpts := &txpool.ValidationOptions{
+		Config:  p.chain.Config(),
+		Accept:  1 << types.DynamicFeeTxType,
+		MaxSize: txMaxSize,
+		MinTip:  p.gasTip,
+	}
+	if local {
+		baseOpts.MinTip = new(big.Int)
+	}
+	if err := txpool.ValidateTransaction(tx, p.head, p.signer, baseOpts); err != nil {
+		return err
+	}
+	// Ensure the transaction adheres to the stateful pool filters (nonce, balance)
+	stateOpts := &txpool.ValidationOptionsWithState{
+		State:      p.state,
+		Validation: p.validation,
+
+		FirstNonceGap: func(addr common.Address) uint64 {
+			return p.state.GetNonce(addr) + uint64(len(p.index[addr]))
+		},
+		UsedAndLeftSlots: func(addr common.Address) (int, int) {
+			have := len(p.index[addr])
+			if have >= maxTxsPerAccount {
+				return have, 0
+			}
+			return have, maxTxsPerAccount - have
+		},
+		ExistingExpenditure: func(addr common.Address) *big.Int {
+			if spent := p.spent[addr]; spent != nil {
+				return new(big.Int).Set(spent)
+			}
+			return new(big.Int)
+		},
+		ExistingCost: func(addr common.Address, nonce uint64) *big.Int {
+			next := p.state.GetNonce(addr)
+			if uint64(len(p.index[addr])) > nonce-next {
+				return p.index[addr][int(nonce-next)].costCap
+			}
+			return nil
+		},
+	}
+	if err := txpool.ValidateTransactionWithState(tx, p.signer, stateOpts); err != nil {
+		return err
+	}
+	// If the transaction replaces an existing one, ensure that price bumps are
+	// adhered to.
+	var (
+		from, _ = types.Sender(p.signer, tx) // already validated above
+		next    = p.state.GetNonce(from)
+	)
+	if uint64(len(p.index[from])) > tx.Nonce()-next {
+		prev := p.index[from][int(tx.Nonce()-next)]
+
+		// Replacements require both the fee cap and the tip cap to be bumped,
+		// and to be strictly higher even if the percentage rounds to zero
+		var (
+			multiplier = big.NewInt(100 + int64(p.config.PriceBump))
+			onehundred = big.NewInt(100)
+
+			minGasFeeCap = new(big.Int).Div(new(big.Int).Mul(prev.execFeeCap, multiplier), onehundred)
+			minGasTipCap = new(big.Int).Div(new(big.Int).Mul(prev.execTipCap, multiplier), onehundred)
+		)
+		if tx.GasFeeCapIntCmp(prev.execFeeCap) <= 0 || tx.GasFeeCapIntCmp(minGasFeeCap) < 0 {
+			return fmt.Errorf("%w: new tx gas fee cap %v < %v queued + %d%% replacement penalty", txpool.ErrReplaceUnderpriced, tx.GasFeeCap(), prev.execFeeCap, p.config.PriceBump)
+		}
+		if tx.GasTipCapIntCmp(prev.execTipCap) <= 0 || tx.GasTipCapIntCmp(minGasTipCap) < 0 {
+			return fmt.Errorf("%w: new tx gas tip cap %v < %v queued + %d%% replacement penalty", txpool.ErrReplaceUnderpriced, tx.GasTipCap(), prev.execTipCap, p.config.PriceBump)
+		}
+	}
+	return nil
+}
+
+// Has returns an indicator whether subpool has a transaction cached with the
+// given hash.
+func (p *LargePool) Has(hash common.Hash) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	_, ok := p.lookup[hash]
+	return ok
+}
+
+// Get returns a transaction if it is contained in the pool, or nil otherwise.
+func (p *LargePool) Get(hash common.Hash) *types.Transaction {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.get(hash)
+}
+
+// get retrieves a transaction from the backing store. The caller must hold the
+// pool lock.
+func (p *LargePool) get(hash common.Hash) *types.Transaction {
+	if _, ok := p.lookup[hash]; !ok {
+		return nil
+	}
+	data, err := p.store.Get(hash.Bytes())
+	if err != nil {
+		log.Error("Tracked large transaction missing from store", "hash", hash, "err", err)
+		return nil
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(data); err != nil {
+		log.Error("Large transaction corrupted in store", "hash", hash, "err", err)
+		return nil
+	}
+	return tx
+}
+
+// Add inserts a set of large transactions into the pool if they pass validation
+// (both consensus validity and pool restrictions).
+//
+// If the transactions are marked as local, their senders are tracked as local
+// accounts, which go around the pricing and eviction rules. All insertions are
+// synchronous.
+func (p *LargePool) Add(txs []*types.Transaction, local bool, sync bool) []error {
+	var (
+		errs  = make([]error, len(txs))
+		added = make([]*types.Transaction, 0, len(txs))
+	)
+	p.lock.Lock()
+	for i, tx := range txs {
+		if errs[i] = p.add(tx, local); errs[i] == nil {
+			added = append(added, tx)
+			p.pushTxEvent(tx, &txpool.TxEvent{Kind: txpool.TxEventAdded})
+		}
+	}
+	p.updateStorageMetrics()
+	events := p.txEvents
+	p.txEvents = nil
+	p.lock.Unlock()
+
+	if len(added) > 0 {
+		p.insertFeed.Send(core.NewTxsEvent{Txs: added})
+	}
+	if len(events) > 0 {
+		p.txEventFeed.Send(events)
+	}
+	return errs
+}
+
+// add inserts a new large transaction into the pool if it passes validation
+// (both consensus validity and pool restrictions). Transactions of accounts
+// marked as local earlier are treated as local too. The caller must hold the
+// pool lock.
+func (p *LargePool) add(tx *types.Transaction, local bool) error {
+	hash := tx.Hash()
+	if _, ok := p.lookup[hash]; ok {
+		return txpool.ErrAlreadyKnown
+	}
+	// If the transaction is from a new account, reserve it across subpools
+	from, err := types.Sender(p.signer, tx)
+	if err != nil {
+		return txpool.ErrInvalidSender
+	}
+	if _, ok := p.index[from]; !ok {
+		if err := p.reserve(from, true); err != nil {
+			return err
+		}
+	}
+	if _, ok := p.locals[from]; ok {
+		local = true
+	}
+	if err := p.insert(from, tx, local); err != nil {
+		// If the account had nothing else pooled, release the reservation
+		if _, ok := p.index[from]; !ok {
+			if err := p.reserve(from, false); err != nil {
+				log.Error("Failed to release largepool account", "addr", from, "err", err)
+			}
+		}
+		return err
+	}
+	if _, ok := p.locals[from]; local && !ok {
+		log.Info("Setting new local account", "address", from)
+		p.locals[from] = struct{}{}
+		p.evict.update(from)
+	}
+	// If the pool went over its storage cap, evict the cheapest transactions
+	// until it fits again. Local accounts are sorted last, so stop at the first
+	// one.
+	for p.stored > p.config.Datacap && p.evict.Len() > 0 && !p.evict.local(p.evict.addrs[0]) {
+		if addr, meta := p.evictOne(); meta.hash != hash {
+			p.dropTxEvents(addr, []*txMetadata{meta}, txpool.DropUnderpriced)
+		}
+	}
+	if _, ok := p.lookup[hash]; !ok {
+		return fmt.Errorf("%w: pool full, fee per byte too low", txpool.ErrUnderpriced)
+	}
+	log.Trace("Pooled new large transaction", "hash", hash, "from", from, "nonce", tx.Nonce())
+	return nil
+}
+
+// insert validates a transaction of an already reserved account and, if it
+// passes, persists it and adds it to the indices. The caller must hold the
+// pool lock.
+func (p *LargePool) insert(from common.Address, tx *types.Transaction, local bool) error {
+	if err := p.validateTx(tx, local); err != nil {
+		log.Trace("Transaction validation failed", "hash", tx.Hash(), "from", from, "nonce", tx.Nonce(), "err", err)
+		return err
+	}
+	// Transaction valid, persist it and update the indices
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if err := p.store.Put(tx.Hash().Bytes(), data); err != nil {
+		return err
+	}
+	var (
+		meta   = newTxMetadata(tx, uint64(len(data)))
+		offset = int(tx.Nonce() - p.state.GetNonce(from))
+	)
+	if p.spent[from] == nil {
+		p.spent[from] = new(big.Int)
+	}
+	if offset < len(p.index[from]) {
+		old := p.index[from][offset]
+		p.pushTxEvent(nil, &txpool.TxEvent{
+			Kind:       txpool.TxEventReplaced,
+			Hash:       old.hash,
+			From:       from,
+			Nonce:      old.nonce,
+			ReplacedBy: meta.hash,
+		})
+		p.forget(from, p.index[from][offset:offset+1])
+		p.index[from][offset] = meta
+	} else {
+		p.index[from] = append(p.index[from], meta)
+	}
+	p.lookup[meta.hash] = from
+	p.spent[from].Add(p.spent[from], meta.costCap)
+	p.stored += meta.size
+	p.evict.update(from)
+	return nil
+}
+
+// evictOne drops the last transaction of the account paying the least fee per
+// stored byte, returning its sender and metadata.
+func (p *LargePool) evictOne() (common.Address, *txMetadata) {
+	var (
+		addr = p.evict.addrs[0]
+		txs  = p.index[addr]
+		last = txs[len(txs)-1]
+	)
+	log.Trace("Evicting large transaction due to pool saturation", "hash", last.hash, "from", addr, "nonce", last.nonce)
+	evictMeter.Mark(1)
+
+	p.forget(addr, txs[len(txs)-1:])
+	p.index[addr] = txs[:len(txs)-1]
+	p.settle(addr)
+
+	return addr, last
+}
+
+// Pending retrieves all currently processable transactions, grouped by origin
+// account and sorted by nonce.
+//
+// The transactions can also be pre-filtered by the dynamic fee components to
+// reduce allocations and load on downstream subsystems.
+func (p *LargePool) Pending(filter txpool.PendingFilter) map[common.Address][]*txpool.LazyTransaction {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	pending := make(map[common.Address][]*txpool.LazyTransaction, len(p.index))
+	for addr, txs := range p.index {
+		lazies := make([]*txpool.LazyTransaction, 0, len(txs))
+		for _, meta := range txs {
+			// If the miner requests tip enforcement, cap the lists now
+			if filter.MinTip != nil {
+				tip := meta.execTipCap
+				if filter.BaseFee != nil {
+					if capped := new(big.Int).Sub(meta.execFeeCap, filter.BaseFee); capped.Cmp(tip) < 0 {
+						tip = capped
+					}
+				}
+				if tip.Cmp(filter.MinTip) < 0 {
+					break
+				}
+			}
+			lazies = append(lazies, &txpool.LazyTransaction{
+				Pool:      p,
+				Hash:      meta.hash,
+				Time:      meta.time,
+				GasFeeCap: meta.execFeeCap,
+				GasTipCap: meta.execTipCap,
+				Gas:       meta.execGas,
+			})
+		}
+		if len(lazies) > 0 {
+			pending[addr] = lazies
+		}
+	}
+	return pending
+}
+
+// SubscribeTransactions registers a subscription for new transaction events,
+// supporting feeding only newly seen or also resurrected transactions.
+func (p *LargePool) SubscribeTransactions(ch chan<- core.NewTxsEvent) event.Subscription {
+	return p.insertFeed.Subscribe(ch)
+}
+
+// SubscribeTxEvents registers a subscription for transaction lifecycle events,
+// reporting additions, replacements, evictions and inclusions.
+func (p *LargePool) SubscribeTxEvents(ch chan<- []*txpool.TxEvent) event.Subscription {
+	return p.txEventScope.Track(p.txEventFeed.Subscribe(ch))
+}
+
+// pushTxEvent queues up a lifecycle event to be posted once the pool lock is
+// released. If a transaction is given, the event is filled in with its details.
+// Events are discarded if nobody listens. The caller must hold the pool lock.
+func (p *LargePool) pushTxEvent(tx *types.Transaction, ev *txpool.TxEvent) {
+	if p.txEventScope.Count() == 0 {
+		return
+	}
+	if tx != nil {
+		ev.Hash = tx.Hash()
+		ev.From, _ = types.Sender(p.signer, tx) // already validated
+		ev.Nonce = tx.Nonce()
+	}
+	p.txEvents = append(p.txEvents, ev)
+}
+
+// dropTxEvents queues up the eviction events of a batch of transactions of an
+// account. The caller must hold the pool lock.
+func (p *LargePool) dropTxEvents(addr common.Address, metas []*txMetadata, reason txpool.TxDropReason) {
+	for _, meta := range metas {
+		p.pushTxEvent(nil, &txpool.TxEvent{
+			Kind:   txpool.TxEventDropped,
+			Hash:   meta.hash,
+			From:   addr,
+			Nonce:  meta.nonce,
+			Reason: reason,
+		})
+	}
+}
+
+// Nonce returns the next nonce of an account, with all transactions executable
+// by the pool already applied on top.
+func (p *LargePool) Nonce(addr common.Address) uint64 {
+	// The state is cached internally on access, so hold the write lock
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if txs, ok := p.index[addr]; ok {
+		return txs[len(txs)-1].nonce + 1
+	}
+	return p.state.GetNonce(addr)
+}
+
+// Stats retrieves the current pool stats, namely the number of pending and the
+// number of queued (non-executable) transactions.
+func (p *LargePool) Stats() (int, int) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return len(p.lookup), 0
+}
+
+// Content retrieves the data content of the transaction pool, returning all the
+// pending as well as queued transactions, grouped by account and sorted by nonce.
+//
+// The large pool has no queue, all transactions are reported as pending.
+func (p *LargePool) Content() (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	pending := make(map[common.Address][]*types.Transaction, len(p.index))
+	for addr := range p.index {
+		pending[addr] = p.content(addr)
+	}
+	return pending, make(map[common.Address][]*types.Transaction)
+}
+
+// ContentFrom retrieves the data content of the transaction pool, returning the
+// pending as well as queued transactions of this address, grouped by nonce.
+func (p *LargePool) ContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.content(addr), []*types.Transaction{}
+}
+
+// content loads all the pooled transactions of an account from the store. The
+// caller must hold the pool lock.
+func (p *LargePool) content(addr common.Address) []*types.Transaction {
+	txs := make([]*types.Transaction, 0, len(p.index[addr]))
+	for _, meta := range p.index[addr] {
+		if tx := p.get(meta.hash); tx != nil {
+			txs = append(txs, tx)
+		}
+	}
+	return txs
+}
+
+// Locals retrieves the accounts currently considered local by the pool.
+func (p *LargePool) Locals() []common.Address {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	locals := make([]common.Address, 0, len(p.locals))
+	for addr := range p.locals {
+		locals = append(locals, addr)
+	}
+	return locals
+}
+
+// Status returns the known status (unknown/pending/queued) of a transaction
+// identified by their hashes.
+func (p *LargePool) Status(hash common.Hash) txpool.TxStatus {
+	if p.Has(hash) {
+		return txpool.TxStatusPending
+	}
+	return txpool.TxStatusUnknown
+}
+
+// updateStorageMetrics retrieves a bunch of stats from the pool and reports
+// them to the metrics subsystem. The caller must hold the pool lock.
+func (p *LargePool) updateStorageMetrics() {
+	datausedGauge.Update(int64(p.stored))
+	txsGauge.Update(int64(len(p.lookup)))
+}
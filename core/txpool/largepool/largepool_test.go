@@ -0,0 +1,549 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package largepool
+
+import (
+	crand "crypto/rand"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/state"
+	"github.com/theQRL/go-zond/core/txpool"
+	"github.com/theQRL/go-zond/core/txpool/legacypool"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/crypto"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
+	"github.com/theQRL/go-zond/event"
+	"github.com/theQRL/go-zond/params"
+	"github.com/theQRL/go-zond/trie"
+)
+
+// testBlockChain is a mock of the live chain for testing the pool.
+type testBlockChain struct {
+	config  *params.ChainConfig
+	statedb *state.StateDB
+	block   *types.Block // Block to serve if requested, empty one otherwise
+
+	chainHeadFeed event.Feed
+}
+
+func (bc *testBlockChain) Config() *params.ChainConfig {
+	return bc.config
+}
+
+func (bc *testBlockChain) CurrentBlock() *types.Header {
+	return &types.Header{
+		Number:   new(big.Int),
+		GasLimit: 30_000_000,
+	}
+}
+
+func (bc *testBlockChain) GetBlock(hash common.Hash, number uint64) *types.Block {
+	if bc.block != nil && bc.block.Hash() == hash {
+		return bc.block
+	}
+	return types.NewBlock(bc.CurrentBlock(), nil, nil, trie.NewStackTrie(nil))
+}
+
+func (bc *testBlockChain) StateAt(common.Hash) (*state.StateDB, error) {
+	return bc.statedb, nil
+}
+
+func (bc *testBlockChain) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription {
+	return bc.chainHeadFeed.Subscribe(ch)
+}
+
+// makeAddressReserver is a utility method to sanity check that accounts are
+// properly reserved by the largepool (no duplicate reserves or unreserves).
+func makeAddressReserver() txpool.AddressReserver {
+	var (
+		reserved = make(map[common.Address]struct{})
+		lock     sync.Mutex
+	)
+	return func(addr common.Address, reserve bool) error {
+		lock.Lock()
+		defer lock.Unlock()
+
+		_, exists := reserved[addr]
+		if reserve {
+			if exists {
+				panic("already reserved")
+			}
+			reserved[addr] = struct{}{}
+			return nil
+		}
+		if !exists {
+			panic("not reserved")
+		}
+		delete(reserved, addr)
+		return nil
+	}
+}
+
+// makeTx creates a signed large transaction with the given amount of random
+// calldata.
+func makeTx(nonce uint64, tip uint64, feeCap uint64, bytes int, key pqcrypto.Wallet) *types.Transaction {
+	data := make([]byte, bytes)
+	crand.Read(data)
+
+	tx, _ := types.SignNewTx(key, types.LatestSigner(params.TestChainConfig), &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     nonce,
+		GasTipCap: new(big.Int).SetUint64(tip),
+		GasFeeCap: new(big.Int).SetUint64(feeCap),
+		Gas:       params.TxGas + uint64(bytes)*params.TxDataNonZeroGasEIP2028,
+		To:        &common.Address{0x01},
+		Data:      data,
+	})
+	return tx
+}
+
+// newTestPool creates a large pool on top of a fresh state in which all the
+// given keys are funded.
+func newTestPool(t *testing.T, datadir string, keys ...pqcrypto.Wallet) (*LargePool, *testBlockChain) {
+	t.Helper()
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	for _, key := range keys {
+		statedb.AddBalance(key.GetAddress(), new(big.Int).Mul(big.NewInt(1000), big.NewInt(params.Quanta)))
+	}
+	chain := &testBlockChain{config: params.TestChainConfig, statedb: statedb}
+	return startTestPool(t, datadir, chain), chain
+}
+
+// startTestPool creates and initializes a large pool on top of the given chain.
+func startTestPool(t *testing.T, datadir string, chain *testBlockChain) *LargePool {
+	t.Helper()
+
+	config := DefaultConfig
+	config.Datadir = datadir
+	config.Datacap = txMaxSize
+
+	pool := New(config, chain)
+	if err := pool.Init(big.NewInt(1), chain.CurrentBlock(), makeAddressReserver()); err != nil {
+		t.Fatalf("failed to init pool: %v", err)
+	}
+	return pool
+}
+
+// verifyPoolInternals iterates over all the transactions in the pool and checks
+// that the indices are consistent with each other and with the store.
+func verifyPoolInternals(t *testing.T, pool *LargePool) {
+	t.Helper()
+
+	var (
+		count  int
+		stored uint64
+	)
+	for addr, txs := range pool.index {
+		if len(txs) == 0 {
+			t.Errorf("account %v tracked without transactions", addr)
+		}
+		next := pool.state.GetNonce(addr)
+		spent := new(big.Int)
+		for i, meta := range txs {
+			if meta.nonce != next+uint64(i) {
+				t.Errorf("account %v: transaction %d nonce mismatch: have %d, want %d", addr, i, meta.nonce, next+uint64(i))
+			}
+			if from, ok := pool.lookup[meta.hash]; !ok || from != addr {
+				t.Errorf("account %v: transaction %x missing from lookup", addr, meta.hash)
+			}
+			if ok, _ := pool.store.Has(meta.hash.Bytes()); !ok {
+				t.Errorf("account %v: transaction %x missing from store", addr, meta.hash)
+			}
+			spent.Add(spent, meta.costCap)
+			stored += meta.size
+			count++
+		}
+		if pool.spent[addr].Cmp(spent) != 0 {
+			t.Errorf("account %v: expenditure mismatch: have %v, want %v", addr, pool.spent[addr], spent)
+		}
+		if _, ok := pool.evict.slots[addr]; !ok {
+			t.Errorf("account %v missing from eviction heap", addr)
+		}
+	}
+	if len(pool.lookup) != count {
+		t.Errorf("lookup size mismatch: have %d, want %d", len(pool.lookup), count)
+	}
+	if pool.evict.Len() != len(pool.index) {
+		t.Errorf("eviction heap size mismatch: have %d, want %d", pool.evict.Len(), len(pool.index))
+	}
+	if pool.stored != stored {
+		t.Errorf("stored size mismatch: have %d, want %d", pool.stored, stored)
+	}
+}
+
+// Tests that only calldata heavy transactions are routed into the large pool.
+func TestFilter(t *testing.T) {
+	key, _ := crypto.GenerateMLDSA87Key()
+	signer := types.LatestSigner(params.TestChainConfig)
+
+	transfer, _ := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+		ChainID: params.TestChainConfig.ChainID,
+		To:      &common.Address{0x01},
+		Gas:     params.TxGas,
+	})
+	deploy, _ := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+		ChainID: params.TestChainConfig.ChainID,
+		Gas:     params.TxGas,
+		Data:    make([]byte, params.MaxInitCodeSize),
+	})
+	tests := []struct {
+		tx   *types.Transaction
+		want bool
+	}{
+		{transfer, false},
+		{deploy, false},
+		{makeTx(0, 1, 1, largeDataSize-1, key), false},
+		{makeTx(0, 1, 1, largeDataSize, key), true},
+	}
+	for i, tt := range tests {
+		if have := IsLarge(tt.tx); have != tt.want {
+			t.Errorf("test %d: filter mismatch: have %v, want %v", i, have, tt.want)
+		}
+	}
+}
+
+// Tests that transactions are only accepted gapless and that replacements need
+// to bump the fees.
+func TestAddAndReplace(t *testing.T) {
+	key, _ := crypto.GenerateMLDSA87Key()
+	pool, _ := newTestPool(t, "", key)
+	defer pool.Close()
+
+	if errs := pool.Add([]*types.Transaction{makeTx(1, 1, 1, largeDataSize, key)}, false, true); !errors.Is(errs[0], core.ErrNonceTooHigh) {
+		t.Fatalf("gapped transaction error mismatch: have %v, want %v", errs[0], core.ErrNonceTooHigh)
+	}
+	orig := makeTx(0, 1, 1, largeDataSize, key)
+	if errs := pool.Add([]*types.Transaction{orig, makeTx(1, 1, 1, largeDataSize, key)}, false, true); errs[0] != nil || errs[1] != nil {
+		t.Fatalf("failed to add transactions: %v", errs)
+	}
+	if errs := pool.Add([]*types.Transaction{orig}, false, true); !errors.Is(errs[0], txpool.ErrAlreadyKnown) {
+		t.Fatalf("duplicate transaction error mismatch: have %v, want %v", errs[0], txpool.ErrAlreadyKnown)
+	}
+	if errs := pool.Add([]*types.Transaction{makeTx(0, 1, 1, largeDataSize, key)}, false, true); !errors.Is(errs[0], txpool.ErrReplaceUnderpriced) {
+		t.Fatalf("underpriced replacement error mismatch: have %v, want %v", errs[0], txpool.ErrReplaceUnderpriced)
+	}
+	replacement := makeTx(0, 2, 2, largeDataSize, key)
+	if errs := pool.Add([]*types.Transaction{replacement}, false, true); errs[0] != nil {
+		t.Fatalf("failed to replace transaction: %v", errs[0])
+	}
+	if pool.Has(orig.Hash()) || !pool.Has(replacement.Hash()) {
+		t.Fatalf("replacement not tracked")
+	}
+	if tx := pool.Get(replacement.Hash()); tx == nil || tx.Hash() != replacement.Hash() {
+		t.Fatalf("failed to retrieve replacement from store")
+	}
+	if pending, queued := pool.Stats(); pending != 2 || queued != 0 {
+		t.Fatalf("stats mismatch: have %d/%d, want 2/0", pending, queued)
+	}
+	if nonce := pool.Nonce(key.GetAddress()); nonce != 2 {
+		t.Fatalf("nonce mismatch: have %d, want 2", nonce)
+	}
+	verifyPoolInternals(t, pool)
+}
+
+// Tests that if the pool grows over its storage cap, the transactions paying
+// the least fee per byte are evicted first.
+func TestEviction(t *testing.T) {
+	var (
+		cheap, _  = crypto.GenerateMLDSA87Key()
+		pricey, _ = crypto.GenerateMLDSA87Key()
+		size      = txMaxSize / 3
+	)
+	pool, _ := newTestPool(t, "", cheap, pricey)
+	defer pool.Close()
+
+	if errs := pool.Add([]*types.Transaction{makeTx(0, 1, 10, size, pricey), makeTx(0, 1, 5, size, cheap)}, false, true); errs[0] != nil || errs[1] != nil {
+		t.Fatalf("failed to add transactions: %v", errs)
+	}
+	// Adding a third transaction overflows the pool, dropping the cheapest one
+	if errs := pool.Add([]*types.Transaction{makeTx(1, 1, 10, size, pricey)}, false, true); errs[0] != nil {
+		t.Fatalf("failed to add transaction: %v", errs[0])
+	}
+	if _, ok := pool.index[cheap.GetAddress()]; ok {
+		t.Errorf("cheap account not evicted")
+	}
+	if have := len(pool.index[pricey.GetAddress()]); have != 2 {
+		t.Errorf("pricey account transaction count mismatch: have %d, want 2", have)
+	}
+	// Adding a transaction paying less than anything pooled gets rejected
+	if errs := pool.Add([]*types.Transaction{makeTx(0, 1, 1, size, cheap)}, false, true); !errors.Is(errs[0], txpool.ErrUnderpriced) {
+		t.Fatalf("underpriced transaction error mismatch: have %v, want %v", errs[0], txpool.ErrUnderpriced)
+	}
+	verifyPoolInternals(t, pool)
+}
+
+// Tests that local transactions go around the pricing rules, are never evicted
+// and that their senders are reported as local accounts.
+func TestLocals(t *testing.T) {
+	var (
+		local, _  = crypto.GenerateMLDSA87Key()
+		remote, _ = crypto.GenerateMLDSA87Key()
+		size      = txMaxSize / 3
+	)
+	pool, _ := newTestPool(t, "", local, remote)
+	defer pool.Close()
+
+	// Local transactions are accepted below the minimum tip
+	if errs := pool.Add([]*types.Transaction{makeTx(0, 0, 1, size, local)}, true, true); errs[0] != nil {
+		t.Fatalf("failed to add local transaction: %v", errs[0])
+	}
+	if errs := pool.Add([]*types.Transaction{makeTx(0, 0, 1, size, remote)}, false, true); !errors.Is(errs[0], txpool.ErrUnderpriced) {
+		t.Fatalf("underpriced remote transaction error mismatch: have %v, want %v", errs[0], txpool.ErrUnderpriced)
+	}
+	if locals := pool.Locals(); len(locals) != 1 || locals[0] != local.GetAddress() {
+		t.Fatalf("locals mismatch: have %v, want [%v]", locals, local.GetAddress())
+	}
+	// Remote transactions paying more are evicted before the local ones. Follow
+	// up transactions of a local account are local too, even if not marked so.
+	if errs := pool.Add([]*types.Transaction{makeTx(0, 1, 10, size, remote), makeTx(1, 0, 1, size, local)}, false, true); errs[0] != nil || errs[1] != nil {
+		t.Fatalf("failed to add transactions: %v", errs)
+	}
+	if errs := pool.Add([]*types.Transaction{makeTx(2, 0, 1, size, local)}, false, true); errs[0] != nil {
+		t.Fatalf("failed to add local transaction: %v", errs[0])
+	}
+	if _, ok := pool.index[remote.GetAddress()]; ok {
+		t.Errorf("remote account not evicted")
+	}
+	if have := len(pool.index[local.GetAddress()]); have != 3 {
+		t.Errorf("local account transaction count mismatch: have %d, want 3", have)
+	}
+	verifyPoolInternals(t, pool)
+}
+
+// Tests that a contract deployment can be followed up straight away by a call
+// from the same account, with both subpools running side by side.
+func TestDeployThenCall(t *testing.T) {
+	key, _ := crypto.GenerateMLDSA87Key()
+	large, chain := newTestPool(t, "", key)
+
+	config := legacypool.DefaultConfig
+	config.Journal = ""
+	legacy := legacypool.New(config, chain)
+
+	pool, err := txpool.New(big.NewInt(1), chain, []txpool.SubPool{legacy, large})
+	if err != nil {
+		t.Fatalf("failed to create transaction pool: %v", err)
+	}
+	defer pool.Close()
+
+	signer := types.LatestSigner(params.TestChainConfig)
+	deploy, _ := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     0,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(1),
+		Gas:       1_000_000,
+		Data:      make([]byte, params.MaxInitCodeSize),
+	})
+	call, _ := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     1,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(1),
+		Gas:       params.TxGas,
+		To:        &common.Address{0x01},
+	})
+	for i, err := range pool.Add([]*types.Transaction{deploy, call}, true, true) {
+		if err != nil {
+			t.Fatalf("transaction %d: failed to add: %v", i, err)
+		}
+	}
+	if pending, _ := pool.Stats(); pending != 2 {
+		t.Fatalf("pending count mismatch: have %d, want 2", pending)
+	}
+}
+
+// lateHeadChain is a chain whose head moves forward while the pool subscribes to
+// head events, without the pool receiving the event.
+type lateHeadChain struct {
+	*testBlockChain
+	head atomic.Pointer[types.Header]
+}
+
+func (bc *lateHeadChain) CurrentBlock() *types.Header {
+	return bc.head.Load()
+}
+
+func (bc *lateHeadChain) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription {
+	parent := bc.head.Load()
+	bc.head.Store(&types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		GasLimit:   parent.GasLimit,
+	})
+	return bc.testBlockChain.SubscribeChainHeadEvent(ch)
+}
+
+// Tests that a head imported in between the creation of the pool and its head
+// subscription is not missed. The window widens with every subpool to init.
+func TestMissedHead(t *testing.T) {
+	large, chain := newTestPool(t, "")
+
+	late := &lateHeadChain{testBlockChain: chain}
+	late.head.Store(chain.CurrentBlock())
+
+	pool, err := txpool.New(big.NewInt(1), late, []txpool.SubPool{large})
+	if err != nil {
+		t.Fatalf("failed to create transaction pool: %v", err)
+	}
+	defer pool.Close()
+
+	if err := pool.Sync(); err != nil {
+		t.Fatalf("failed to sync pool: %v", err)
+	}
+	large.lock.RLock()
+	head := large.head
+	large.lock.RUnlock()
+
+	if want := late.CurrentBlock(); head.Hash() != want.Hash() {
+		t.Fatalf("pool head mismatch: have #%d, want #%d", head.Number, want.Number)
+	}
+}
+
+// Tests that resetting the pool drops the included transactions.
+func TestReset(t *testing.T) {
+	key, _ := crypto.GenerateMLDSA87Key()
+	pool, chain := newTestPool(t, "", key)
+	defer pool.Close()
+
+	txs := []*types.Transaction{makeTx(0, 1, 1, largeDataSize, key), makeTx(1, 1, 1, largeDataSize, key)}
+	pool.Add(txs, false, true)
+
+	chain.statedb.SetNonce(key.GetAddress(), 1)
+	pool.Reset(nil, chain.CurrentBlock())
+
+	if pool.Has(txs[0].Hash()) || !pool.Has(txs[1].Hash()) {
+		t.Fatalf("included transaction not dropped")
+	}
+	verifyPoolInternals(t, pool)
+
+	chain.statedb.SetNonce(key.GetAddress(), 2)
+	pool.Reset(nil, chain.CurrentBlock())
+
+	if pending, _ := pool.Stats(); pending != 0 {
+		t.Fatalf("pending count mismatch: have %d, want 0", pending)
+	}
+	verifyPoolInternals(t, pool)
+}
+
+// Tests that the lifecycle events of transactions are reported, telling included
+// transactions apart from evicted ones.
+func TestTxEvents(t *testing.T) {
+	key, _ := crypto.GenerateMLDSA87Key()
+	pool, chain := newTestPool(t, "", key)
+	defer pool.Close()
+
+	events := make(chan []*txpool.TxEvent, 16)
+	sub := pool.SubscribeTxEvents(events)
+	defer sub.Unsubscribe()
+
+	var (
+		addr = key.GetAddress()
+		txs  = []*types.Transaction{
+			makeTx(0, 1, 1, largeDataSize, key),
+			makeTx(1, 1, 1, largeDataSize, key),
+			makeTx(2, 1, 1, largeDataSize, key),
+		}
+		replacement = makeTx(2, 2, 2, largeDataSize, key)
+	)
+	check := func(want ...*txpool.TxEvent) {
+		t.Helper()
+
+		select {
+		case have := <-events:
+			if len(have) != len(want) {
+				t.Fatalf("event count mismatch: have %d, want %d", len(have), len(want))
+			}
+			for i := range want {
+				if *have[i] != *want[i] {
+					t.Fatalf("event %d mismatch: have %+v, want %+v", i, have[i], want[i])
+				}
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("event timeout")
+		}
+	}
+	pool.Add(append(txs, replacement), false, true)
+	check(
+		&txpool.TxEvent{Kind: txpool.TxEventAdded, Hash: txs[0].Hash(), From: addr, Nonce: 0},
+		&txpool.TxEvent{Kind: txpool.TxEventAdded, Hash: txs[1].Hash(), From: addr, Nonce: 1},
+		&txpool.TxEvent{Kind: txpool.TxEventAdded, Hash: txs[2].Hash(), From: addr, Nonce: 2},
+		&txpool.TxEvent{Kind: txpool.TxEventReplaced, Hash: txs[2].Hash(), From: addr, Nonce: 2, ReplacedBy: replacement.Hash()},
+		&txpool.TxEvent{Kind: txpool.TxEventAdded, Hash: replacement.Hash(), From: addr, Nonce: 2},
+	)
+	// Include the first transaction in a block, but consume the nonce of the
+	// second one by something else
+	parent := chain.CurrentBlock()
+	chain.block = types.NewBlock(&types.Header{Number: big.NewInt(1), ParentHash: parent.Hash()}, &types.Body{Transactions: txs[:1]}, nil, trie.NewStackTrie(nil))
+	chain.statedb.SetNonce(addr, 2)
+
+	pool.Reset(parent, chain.block.Header())
+	check(
+		&txpool.TxEvent{Kind: txpool.TxEventIncluded, Hash: txs[0].Hash(), From: addr, Nonce: 0, BlockHash: chain.block.Hash(), BlockNumber: 1},
+		&txpool.TxEvent{Kind: txpool.TxEventDropped, Hash: txs[1].Hash(), From: addr, Nonce: 1, Reason: txpool.DropNonceTooLow},
+	)
+	verifyPoolInternals(t, pool)
+}
+
+// Tests that the pooled transactions survive a restart, and that anything no
+// longer executable is dropped while loading.
+func TestPersistence(t *testing.T) {
+	var (
+		key, _  = crypto.GenerateMLDSA87Key()
+		datadir = filepath.Join(t.TempDir(), "largepool")
+	)
+	pool, chain := newTestPool(t, datadir, key)
+
+	txs := []*types.Transaction{
+		makeTx(0, 1, 1, largeDataSize, key),
+		makeTx(1, 1, 1, largeDataSize, key),
+		makeTx(2, 1, 1, largeDataSize, key),
+	}
+	if errs := pool.Add(txs, false, true); errs[0] != nil || errs[1] != nil || errs[2] != nil {
+		t.Fatalf("failed to add transactions: %v", errs)
+	}
+	if err := pool.Close(); err != nil {
+		t.Fatalf("failed to close pool: %v", err)
+	}
+	chain.statedb.SetNonce(key.GetAddress(), 1)
+
+	pool = startTestPool(t, datadir, chain)
+	defer pool.Close()
+
+	if pool.Has(txs[0].Hash()) {
+		t.Errorf("included transaction reloaded")
+	}
+	for _, tx := range txs[1:] {
+		if !pool.Has(tx.Hash()) {
+			t.Errorf("transaction %x not reloaded", tx.Hash())
+		}
+	}
+	if ok, _ := pool.store.Has(txs[0].Hash().Bytes()); ok {
+		t.Errorf("included transaction not deleted from store")
+	}
+	verifyPoolInternals(t, pool)
+}
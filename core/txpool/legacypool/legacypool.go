@@ -124,7 +124,10 @@ type Config struct {
 	Locals    []common.Address // Addresses that should be treated by default as local
 	NoLocals  bool             // Whether local transaction handling should be disabled
 	Journal   string           // Journal of local transactions to survive node restarts
-	Rejournal time.Duration    // Time interval to regenerate the local transaction journal
+	Rejournal time.Duration    // Time interval to regenerate the local transaction journal and remote snapshot
+
+	Persist    bool   // Whether to snapshot remote transactions to survive node restarts
+	PersistDir string // Database directory to snapshot the remote transactions into
 
 	PriceLimit uint64 // Minimum gas price to enforce for acceptance into the pool
 	PriceBump  uint64 // Minimum price bump percentage to replace an already existing transaction (nonce)
@@ -142,6 +145,8 @@ var DefaultConfig = Config{
 	Journal:   "transactions.rlp",
 	Rejournal: time.Hour,
 
+	PersistDir: "txpool",
+
 	PriceLimit: 1,
 	PriceBump:  10,
 
@@ -210,10 +215,12 @@ type LegacyPool struct {
 
 	currentHead   atomic.Pointer[types.Header] // Current head of the blockchain
 	currentState  *state.StateDB               // Current state in the blockchain head
+	validation    *txpool.ValidationEnv        // Environment to validate account abstraction transactions in
 	pendingNonces *noncer                      // Pending state tracking virtual nonces
 
 	locals  *accountSet // Set of local transaction to exempt from eviction rules
 	journal *journal    // Journal of local transaction to back up to disk
+	persist *persister  // Snapshot of remote transactions to back up to disk
 
 	reserve txpool.AddressReserver       // Address reserver to ensure exclusivity across subpools
 	pending map[common.Address]*list     // All currently processable transactions
@@ -231,6 +238,12 @@ type LegacyPool struct {
 	initDoneCh      chan struct{}  // is closed once the pool is initialized (for tests)
 
 	changesSinceReorg int // A counter for how many drops we've performed in-between reorg.
+
+	txEventFeed  event.Feed                    // Feed of transaction lifecycle events
+	txEventScope event.SubscriptionScope       // Subscription scope to track lifecycle subscribers
+	txEvents     []*txpool.TxEvent             // Lifecycle events waiting to be posted
+	txEventsLock sync.Mutex                    // Lock protecting the waiting lifecycle events
+	included     map[common.Hash]*types.Header // Transactions included by the chain segment being reset to
 }
 
 type txpoolResetRequest struct {
@@ -277,7 +290,7 @@ func New(config Config, chain BlockChain) *LegacyPool {
 // pool.
 func (pool *LegacyPool) Filter(tx *types.Transaction) bool {
 	switch tx.Type() {
-	case types.DynamicFeeTxType:
+	case types.DynamicFeeTxType, types.AccountAbstractionTxType:
 		return true
 	default:
 		return false
@@ -307,6 +320,7 @@ func (pool *LegacyPool) Init(gasTip *big.Int, head *types.Header, reserve txpool
 	}
 	pool.currentHead.Store(head)
 	pool.currentState = statedb
+	pool.validation = txpool.NewValidationEnv(pool.chainconfig, head, statedb, pool.chain)
 	pool.pendingNonces = newNoncer(statedb)
 
 	// Start the reorg loop early, so it can handle requests generated during
@@ -323,6 +337,16 @@ func (pool *LegacyPool) Init(gasTip *big.Int, head *types.Header, reserve txpool
 			log.Warn("Failed to rotate transaction journal", "err", err)
 		}
 	}
+	// If remote transaction persistence is enabled, restore the last snapshot
+	if pool.config.Persist && pool.config.PersistDir != "" {
+		persist, err := newTxPersister(pool.config.PersistDir)
+		if err != nil {
+			log.Warn("Failed to open transaction pool snapshot", "err", err)
+		} else {
+			pool.persist = persist
+			pool.loadPersisted()
+		}
+	}
 	pool.wg.Add(1)
 	go pool.loop()
 	return nil
@@ -378,14 +402,16 @@ func (pool *LegacyPool) loop() {
 				if time.Since(pool.beats[addr]) > pool.config.Lifetime {
 					list := pool.queue[addr].Flatten()
 					for _, tx := range list {
+						pool.dropTxEvent(tx, txpool.DropLifetimeLimit)
 						pool.removeTx(tx.Hash(), true, true)
 					}
 					queuedEvictionMeter.Mark(int64(len(list)))
 				}
 			}
 			pool.mu.Unlock()
+			pool.postTxEvents()
 
-		// Handle local transaction journal rotation
+		// Handle local transaction journal rotation and remote snapshotting
 		case <-journal.C:
 			if pool.journal != nil {
 				pool.mu.Lock()
@@ -394,6 +420,17 @@ func (pool *LegacyPool) loop() {
 				}
 				pool.mu.Unlock()
 			}
+			if pool.persist != nil {
+				// Only gather the transactions under the lock, encoding and
+				// writing them out is slow and needn't block the pool
+				pool.mu.Lock()
+				remotes := pool.remote()
+				pool.mu.Unlock()
+
+				if err := pool.persist.snapshot(remotes); err != nil {
+					log.Warn("Failed to snapshot remote txs", "err", err)
+				}
+			}
 		}
 	}
 }
@@ -407,6 +444,18 @@ func (pool *LegacyPool) Close() error {
 	if pool.journal != nil {
 		pool.journal.close()
 	}
+	if pool.persist != nil {
+		pool.mu.Lock()
+		remotes := pool.remote()
+		pool.mu.Unlock()
+
+		if err := pool.persist.snapshot(remotes); err != nil {
+			log.Warn("Failed to snapshot remote txs", "err", err)
+		}
+		pool.persist.close()
+	}
+	pool.txEventScope.Close()
+
 	log.Info("Transaction pool stopped")
 	return nil
 }
@@ -428,9 +477,17 @@ func (pool *LegacyPool) SubscribeTransactions(ch chan<- core.NewTxsEvent) event.
 	return pool.txFeed.Subscribe(ch)
 }
 
+// SubscribeTxEvents registers a subscription for transaction lifecycle events,
+// reporting additions, replacements, evictions and inclusions.
+func (pool *LegacyPool) SubscribeTxEvents(ch chan<- []*txpool.TxEvent) event.Subscription {
+	return pool.txEventScope.Track(pool.txEventFeed.Subscribe(ch))
+}
+
 // SetGasTip updates the minimum gas tip required by the transaction pool for a
 // new transaction, and drops all transactions below this threshold.
 func (pool *LegacyPool) SetGasTip(tip *big.Int) {
+	defer pool.postTxEvents() // Runs after releasing the lock below
+
 	pool.mu.Lock()
 	defer pool.mu.Unlock()
 
@@ -442,6 +499,7 @@ func (pool *LegacyPool) SetGasTip(tip *big.Int) {
 		// pool.priced is sorted by GasFeeCap, so we have to iterate through pool.all instead
 		drop := pool.all.RemotesBelowTip(tip)
 		for _, tx := range drop {
+			pool.dropTxEvent(tx, txpool.DropUnderpriced)
 			pool.removeTx(tx.Hash(), false, true)
 		}
 		pool.priced.Removed(len(drop))
@@ -590,6 +648,58 @@ func (pool *LegacyPool) local() map[common.Address]types.Transactions {
 	return txs
 }
 
+// remote retrieves all currently known remote transactions, grouped by origin
+// account and sorted by nonce. The returned transaction set is a copy and can be
+// freely modified by calling code.
+func (pool *LegacyPool) remote() map[common.Address]types.Transactions {
+	txs := make(map[common.Address]types.Transactions)
+	for addr, list := range pool.pending {
+		if !pool.locals.contains(addr) {
+			txs[addr] = append(txs[addr], list.Flatten()...)
+		}
+	}
+	for addr, list := range pool.queue {
+		if !pool.locals.contains(addr) {
+			txs[addr] = append(txs[addr], list.Flatten()...)
+		}
+	}
+	return txs
+}
+
+// loadPersisted reinjects the remote transactions of the last snapshot into the
+// pool, revalidating them against the current head. The account heartbeats are
+// restored to the original arrival times, so non-executable transactions still
+// expire based on when they were first seen.
+func (pool *LegacyPool) loadPersisted() {
+	txs := pool.persist.load(pool.config.Lifetime)
+
+	dropped := 0
+	for _, err := range pool.addRemotesSync(txs) {
+		if err != nil {
+			log.Debug("Failed to add persisted transaction", "err", err)
+			dropped++
+		}
+	}
+	seen := make(map[common.Address]time.Time)
+	for _, tx := range txs {
+		from, _ := types.Sender(pool.signer, tx) // already validated during insertion
+		if tx.Time().After(seen[from]) {
+			seen[from] = tx.Time()
+		}
+	}
+	pool.mu.Lock()
+	for addr, beat := range seen {
+		if _, ok := pool.queue[addr]; ok {
+			pool.beats[addr] = beat
+		}
+	}
+	pool.mu.Unlock()
+
+	if dropped > 0 {
+		log.Info("Dropped invalid persisted transactions", "count", dropped)
+	}
+}
+
 // validateTxBasics checks whether a transaction is valid according to the consensus
 // rules, but does not check state-dependent validation such as sufficient balance.
 // This check is meant as an early check which only needs to be performed once,
@@ -598,7 +708,8 @@ func (pool *LegacyPool) validateTxBasics(tx *types.Transaction, local bool) erro
 	opts := &txpool.ValidationOptions{
 		Config: pool.chainconfig,
 		Accept: 0 |
-			1<<types.DynamicFeeTxType,
+			1<<types.DynamicFeeTxType |
+			1<<types.AccountAbstractionTxType,
 		MaxSize: txMaxSize,
 		MinTip:  pool.gasTip.Load(),
 	}
@@ -615,7 +726,8 @@ func (pool *LegacyPool) validateTxBasics(tx *types.Transaction, local bool) erro
 // rules and adheres to some heuristic limits of the local node (price and size).
 func (pool *LegacyPool) validateTx(tx *types.Transaction, local bool) error {
 	opts := &txpool.ValidationOptionsWithState{
-		State: pool.currentState,
+		State:      pool.currentState,
+		Validation: pool.validation,
 
 		FirstNonceGap: nil, // Pool allows arbitrary arrival order, don't invalidate nonce gaps
 		UsedAndLeftSlots: func(addr common.Address) (int, int) {
@@ -755,6 +867,7 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 			underpricedTxMeter.Mark(1)
 
 			sender, _ := types.Sender(pool.signer, tx)
+			pool.dropTxEvent(tx, txpool.DropUnderpriced)
 			dropped := pool.removeTx(tx.Hash(), false, sender != from) // Don't unreserve the sender of the tx being added if last from the acc
 
 			pool.changesSinceReorg += dropped
@@ -774,6 +887,7 @@ func (pool *LegacyPool) add(tx *types.Transaction, local bool) (replaced bool, e
 			pool.all.Remove(old.Hash())
 			pool.priced.Removed(1)
 			pendingReplaceMeter.Mark(1)
+			pool.replaceTxEvent(old, tx)
 		}
 		pool.all.Add(tx, isLocal)
 		pool.priced.Put(tx, isLocal)
@@ -849,6 +963,7 @@ func (pool *LegacyPool) enqueueTx(hash common.Hash, tx *types.Transaction, local
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed(1)
 		queuedReplaceMeter.Mark(1)
+		pool.replaceTxEvent(old, tx)
 	} else {
 		// Nothing was replaced, bump the queued counter
 		queuedGauge.Inc(1)
@@ -898,6 +1013,7 @@ func (pool *LegacyPool) promoteTx(addr common.Address, hash common.Hash, tx *typ
 		pool.all.Remove(hash)
 		pool.priced.Removed(1)
 		pendingDiscardMeter.Mark(1)
+		pool.replaceTxEvent(tx, list.txs.Get(tx.Nonce()))
 		return false
 	}
 	// Otherwise discard any previous transaction and mark this
@@ -905,6 +1021,7 @@ func (pool *LegacyPool) promoteTx(addr common.Address, hash common.Hash, tx *typ
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed(1)
 		pendingReplaceMeter.Mark(1)
+		pool.replaceTxEvent(old, tx)
 	} else {
 		// Nothing was replaced, bump the pending counter
 		pendingGauge.Inc(1)
@@ -968,8 +1085,9 @@ func (pool *LegacyPool) Add(txs []*types.Transaction, local, sync bool) []error
 
 	// Filter out known ones without obtaining the pool lock or recovering signatures
 	var (
-		errs = make([]error, len(txs))
-		news = make([]*types.Transaction, 0, len(txs))
+		errs    = make([]error, len(txs))
+		news    = make([]*types.Transaction, 0, len(txs))
+		unknown = make([]*types.Transaction, 0, len(txs))
 	)
 	for i, tx := range txs {
 		// If the transaction is known, pre-set the error slot
@@ -978,6 +1096,16 @@ func (pool *LegacyPool) Add(txs []*types.Transaction, local, sync bool) []error
 			knownTxMeter.Mark(1)
 			continue
 		}
+		unknown = append(unknown, tx)
+	}
+	// Verify the signatures of all unknown transactions concurrently, caching
+	// the senders for the validation below
+	core.SenderCacher.Verify(pool.signer, unknown)
+
+	for i, tx := range txs {
+		if errs[i] != nil {
+			continue
+		}
 		// Exclude transactions with basic errors, e.g invalid signatures and
 		// insufficient intrinsic gas as soon as possible and cache senders
 		// in transactions before obtaining lock
@@ -1000,13 +1128,19 @@ func (pool *LegacyPool) Add(txs []*types.Transaction, local, sync bool) []error
 	pool.mu.Unlock()
 
 	var nilSlot = 0
-	for _, err := range newErrs {
+	for i, err := range newErrs {
 		for errs[nilSlot] != nil {
 			nilSlot++
 		}
 		errs[nilSlot] = err
 		nilSlot++
+
+		if err == nil {
+			pool.pushTxEvent(news[i], &txpool.TxEvent{Kind: txpool.TxEventAdded})
+		}
 	}
+	pool.postTxEvents()
+
 	// Reorg the pool internals if needed and return
 	done := pool.requestPromoteExecutables(dirtyAddrs)
 	if sync {
@@ -1121,6 +1255,7 @@ func (pool *LegacyPool) removeTx(hash common.Hash, outofbound bool, unreserve bo
 			for _, tx := range invalids {
 				// Internal shuffle shouldn't touch the lookup set.
 				pool.enqueueTx(tx.Hash(), tx, false, false)
+				pool.pushTxEvent(tx, &txpool.TxEvent{Kind: txpool.TxEventRequeued})
 			}
 			// Update the account nonce if needed
 			pool.pendingNonces.setIfLower(addr, tx.Nonce())
@@ -1173,6 +1308,67 @@ func (pool *LegacyPool) queueTxEvent(tx *types.Transaction) {
 	}
 }
 
+// pushTxEvent queues up a lifecycle event of a transaction to be posted once the
+// current pool operation finishes. Events are discarded if nobody listens.
+func (pool *LegacyPool) pushTxEvent(tx *types.Transaction, ev *txpool.TxEvent) {
+	if pool.txEventScope.Count() == 0 {
+		return
+	}
+	ev.Hash = tx.Hash()
+	ev.From, _ = types.Sender(pool.signer, tx) // already validated
+	ev.Nonce = tx.Nonce()
+
+	pool.txEventsLock.Lock()
+	pool.txEvents = append(pool.txEvents, ev)
+	pool.txEventsLock.Unlock()
+}
+
+// dropTxEvent queues up the eviction event of a transaction.
+func (pool *LegacyPool) dropTxEvent(tx *types.Transaction, reason txpool.TxDropReason) {
+	pool.pushTxEvent(tx, &txpool.TxEvent{Kind: txpool.TxEventDropped, Reason: reason})
+}
+
+// replaceTxEvent queues up the replacement event of a transaction.
+func (pool *LegacyPool) replaceTxEvent(old *types.Transaction, tx *types.Transaction) {
+	pool.pushTxEvent(old, &txpool.TxEvent{Kind: txpool.TxEventReplaced, ReplacedBy: tx.Hash()})
+}
+
+// staleTxEvent queues up the event of a transaction removed due to its nonce
+// being used up, either by its own inclusion or by some other transaction.
+func (pool *LegacyPool) staleTxEvent(tx *types.Transaction) {
+	if header, ok := pool.included[tx.Hash()]; ok {
+		pool.pushTxEvent(tx, &txpool.TxEvent{
+			Kind:        txpool.TxEventIncluded,
+			BlockHash:   header.Hash(),
+			BlockNumber: header.Number.Uint64(),
+		})
+		return
+	}
+	pool.dropTxEvent(tx, txpool.DropNonceTooLow)
+}
+
+// unpayableTxEvent queues up the event of a transaction removed due to it not
+// fitting into a block or its sender not being able to pay for it.
+func (pool *LegacyPool) unpayableTxEvent(tx *types.Transaction, gasLimit uint64) {
+	if tx.Gas() > gasLimit {
+		pool.dropTxEvent(tx, txpool.DropGasLimit)
+		return
+	}
+	pool.dropTxEvent(tx, txpool.DropInsufficient)
+}
+
+// postTxEvents sends out all the queued transaction lifecycle events.
+func (pool *LegacyPool) postTxEvents() {
+	pool.txEventsLock.Lock()
+	events := pool.txEvents
+	pool.txEvents = nil
+	pool.txEventsLock.Unlock()
+
+	if len(events) > 0 {
+		pool.txEventFeed.Send(events)
+	}
+}
+
 // scheduleReorgLoop schedules runs of reset and promoteExecutables. Code above should not
 // call those methods directly, but request them being run using requestReset and
 // requestPromoteExecutables instead.
@@ -1303,8 +1499,12 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 
 	dropBetweenReorgHistogram.Update(int64(pool.changesSinceReorg))
 	pool.changesSinceReorg = 0 // Reset change counter
+	pool.included = nil        // Inclusions were all reported by now
 	pool.mu.Unlock()
 
+	// Notify subsystems of the lifecycle changes
+	pool.postTxEvents()
+
 	// Notify subsystems for newly added transactions
 	for _, tx := range promoted {
 		addr, _ := types.Sender(pool.signer, tx)
@@ -1328,6 +1528,15 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 	// If we're reorging an old state, reinject all dropped transactions
 	var reinject types.Transactions
 
+	// If anyone's interested in the transaction lifecycles, gather the inclusions
+	// to tell them apart from transactions invalidated by a nonce reuse
+	track := pool.txEventScope.Count() > 0
+
+	if oldHead != nil && oldHead.Hash() == newHead.ParentHash && track {
+		if block := pool.chain.GetBlock(newHead.Hash(), newHead.Number.Uint64()); block != nil {
+			pool.trackIncluded(block)
+		}
+	}
 	if oldHead != nil && oldHead.Hash() != newHead.ParentHash {
 		// If the reorg is too deep, avoid doing it (will happen during fast sync)
 		oldNum := oldHead.Number.Uint64()
@@ -1375,6 +1584,9 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 				}
 				for add.NumberU64() > rem.NumberU64() {
 					included = append(included, add.Transactions()...)
+					if track {
+						pool.trackIncluded(add)
+					}
 					if add = pool.chain.GetBlock(add.ParentHash(), add.NumberU64()-1); add == nil {
 						log.Error("Unrooted new chain seen by tx pool", "block", newHead.Number, "hash", newHead.Hash())
 						return
@@ -1387,6 +1599,9 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 						return
 					}
 					included = append(included, add.Transactions()...)
+					if track {
+						pool.trackIncluded(add)
+					}
 					if add = pool.chain.GetBlock(add.ParentHash(), add.NumberU64()-1); add == nil {
 						log.Error("Unrooted new chain seen by tx pool", "block", newHead.Number, "hash", newHead.Hash())
 						return
@@ -1413,12 +1628,30 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 	}
 	pool.currentHead.Store(newHead)
 	pool.currentState = statedb
+	pool.validation = txpool.NewValidationEnv(pool.chainconfig, newHead, statedb, pool.chain)
 	pool.pendingNonces = newNoncer(statedb)
 
 	// Inject any transactions discarded due to reorgs
 	log.Debug("Reinjecting stale transactions", "count", len(reinject))
 	core.SenderCacher.Recover(pool.signer, reinject)
-	pool.addTxsLocked(reinject, false)
+	errs, _ := pool.addTxsLocked(reinject, false)
+	for i, err := range errs {
+		if err == nil {
+			pool.pushTxEvent(reinject[i], &txpool.TxEvent{Kind: txpool.TxEventRequeued})
+		}
+	}
+}
+
+// trackIncluded marks the transactions of a block as included, to be reported
+// as such when they are removed from the pool by the ongoing reset.
+func (pool *LegacyPool) trackIncluded(block *types.Block) {
+	if pool.included == nil {
+		pool.included = make(map[common.Hash]*types.Header)
+	}
+	header := block.Header()
+	for _, tx := range block.Transactions() {
+		pool.included[tx.Hash()] = header
+	}
 }
 
 // promoteExecutables moves transactions that have become processable from the
@@ -1440,6 +1673,7 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 		for _, tx := range forwards {
 			hash := tx.Hash()
 			pool.all.Remove(hash)
+			pool.staleTxEvent(tx)
 		}
 		log.Trace("Removed old queued transactions", "count", len(forwards))
 		// Drop all transactions that are too costly (low balance or out of gas)
@@ -1447,6 +1681,7 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 		for _, tx := range drops {
 			hash := tx.Hash()
 			pool.all.Remove(hash)
+			pool.unpayableTxEvent(tx, gasLimit)
 		}
 		log.Trace("Removed unpayable queued transactions", "count", len(drops))
 		queuedNofundsMeter.Mark(int64(len(drops)))
@@ -1469,6 +1704,7 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 			for _, tx := range caps {
 				hash := tx.Hash()
 				pool.all.Remove(hash)
+				pool.dropTxEvent(tx, txpool.DropAccountLimit)
 				log.Trace("Removed cap-exceeding queued transaction", "hash", hash)
 			}
 			queuedRateLimitMeter.Mark(int64(len(caps)))
@@ -1534,6 +1770,7 @@ func (pool *LegacyPool) truncatePending() {
 						// Drop the transaction from the global pools too
 						hash := tx.Hash()
 						pool.all.Remove(hash)
+						pool.dropTxEvent(tx, txpool.DropPoolOverflow)
 
 						// Update the account nonce to the dropped transaction
 						pool.pendingNonces.setIfLower(offenders[i], tx.Nonce())
@@ -1561,6 +1798,7 @@ func (pool *LegacyPool) truncatePending() {
 					// Drop the transaction from the global pools too
 					hash := tx.Hash()
 					pool.all.Remove(hash)
+					pool.dropTxEvent(tx, txpool.DropPoolOverflow)
 
 					// Update the account nonce to the dropped transaction
 					pool.pendingNonces.setIfLower(addr, tx.Nonce())
@@ -1607,6 +1845,7 @@ func (pool *LegacyPool) truncateQueue() {
 		// Drop all transactions if they are less than the overflow
 		if size := uint64(list.Len()); size <= drop {
 			for _, tx := range list.Flatten() {
+				pool.dropTxEvent(tx, txpool.DropPoolOverflow)
 				pool.removeTx(tx.Hash(), true, true)
 			}
 			drop -= size
@@ -1616,6 +1855,7 @@ func (pool *LegacyPool) truncateQueue() {
 		// Otherwise drop only last few transactions
 		txs := list.Flatten()
 		for i := len(txs) - 1; i >= 0 && drop > 0; i-- {
+			pool.dropTxEvent(txs[i], txpool.DropPoolOverflow)
 			pool.removeTx(txs[i].Hash(), true, true)
 			drop--
 			queuedRateLimitMeter.Mark(1)
@@ -1641,6 +1881,7 @@ func (pool *LegacyPool) demoteUnexecutables() {
 		for _, tx := range olds {
 			hash := tx.Hash()
 			pool.all.Remove(hash)
+			pool.staleTxEvent(tx)
 			log.Trace("Removed old pending transaction", "hash", hash)
 		}
 		// Drop all transactions that are too costly (low balance or out of gas), and queue any invalids back for later
@@ -1649,6 +1890,7 @@ func (pool *LegacyPool) demoteUnexecutables() {
 			hash := tx.Hash()
 			log.Trace("Removed unpayable pending transaction", "hash", hash)
 			pool.all.Remove(hash)
+			pool.unpayableTxEvent(tx, gasLimit)
 		}
 		pendingNofundsMeter.Mark(int64(len(drops)))
 
@@ -1658,6 +1900,7 @@ func (pool *LegacyPool) demoteUnexecutables() {
 
 			// Internal shuffle shouldn't touch the lookup set.
 			pool.enqueueTx(hash, tx, false, false)
+			pool.pushTxEvent(tx, &txpool.TxEvent{Kind: txpool.TxEventRequeued})
 		}
 		pendingGauge.Dec(int64(len(olds) + len(drops) + len(invalids)))
 		if pool.locals.contains(addr) {
@@ -1672,6 +1915,7 @@ func (pool *LegacyPool) demoteUnexecutables() {
 
 				// Internal shuffle shouldn't touch the lookup set.
 				pool.enqueueTx(hash, tx, false, false)
+				pool.pushTxEvent(tx, &txpool.TxEvent{Kind: txpool.TxEventRequeued})
 			}
 			pendingGauge.Dec(int64(len(gapped)))
 		}
@@ -19,16 +19,16 @@ import (
 	"math/big"
 	"testing"
 
-	walletmldsa87 "github.com/theQRL/go-qrllib/wallet/ml_dsa_87"
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/core/rawdb"
 	"github.com/theQRL/go-zond/core/state"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/crypto"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
 	"github.com/theQRL/go-zond/event"
 )
 
-func dynamicFeeValuedTransaction(nonce uint64, value int64, gasLimit uint64, gasFeeCap *big.Int, key *walletmldsa87.Wallet) *types.Transaction {
+func dynamicFeeValuedTransaction(nonce uint64, value int64, gasLimit uint64, gasFeeCap *big.Int, key pqcrypto.Wallet) *types.Transaction {
 	tx := types.NewTx(&types.DynamicFeeTx{
 		Nonce:     nonce,
 		To:        &common.Address{},
@@ -28,7 +28,7 @@ import (
 	"testing"
 	"time"
 
-	walletmldsa87 "github.com/theQRL/go-qrllib/wallet/ml_dsa_87"
+	"github.com/theQRL/go-qrllib/wallet/common/wallettype"
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/core"
 	"github.com/theQRL/go-zond/core/rawdb"
@@ -36,6 +36,7 @@ import (
 	"github.com/theQRL/go-zond/core/txpool"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/crypto"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
 	"github.com/theQRL/go-zond/event"
 	"github.com/theQRL/go-zond/params"
 	"github.com/theQRL/go-zond/trie"
@@ -94,11 +95,11 @@ func (bc *testBlockChain) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent)
 	return bc.chainHeadFeed.Subscribe(ch)
 }
 
-func transaction(nonce uint64, gaslimit uint64, key *walletmldsa87.Wallet) *types.Transaction {
+func transaction(nonce uint64, gaslimit uint64, key pqcrypto.Wallet) *types.Transaction {
 	return dynamicFeeTx(nonce, gaslimit, big.NewInt(1), big.NewInt(1), key)
 }
 
-func dynamicFeeTx(nonce uint64, gaslimit uint64, gasFee *big.Int, tip *big.Int, key *walletmldsa87.Wallet) *types.Transaction {
+func dynamicFeeTx(nonce uint64, gaslimit uint64, gasFee *big.Int, tip *big.Int, key pqcrypto.Wallet) *types.Transaction {
 	tx, _ := types.SignNewTx(key, types.LatestSignerForChainID(params.TestChainConfig.ChainID), &types.DynamicFeeTx{
 		ChainID:    params.TestChainConfig.ChainID,
 		Nonce:      nonce,
@@ -113,7 +114,7 @@ func dynamicFeeTx(nonce uint64, gaslimit uint64, gasFee *big.Int, tip *big.Int,
 	return tx
 }
 
-func dynamicFeeDataTx(nonce uint64, gaslimit uint64, gasFee *big.Int, tip *big.Int, key *walletmldsa87.Wallet, bytes uint64) *types.Transaction {
+func dynamicFeeDataTx(nonce uint64, gaslimit uint64, gasFee *big.Int, tip *big.Int, key pqcrypto.Wallet, bytes uint64) *types.Transaction {
 	data := make([]byte, bytes)
 	crand.Read(data)
 
@@ -156,11 +157,11 @@ func makeAddressReserver() txpool.AddressReserver {
 	}
 }
 
-func setupPool() (*LegacyPool, *walletmldsa87.Wallet) {
+func setupPool() (*LegacyPool, pqcrypto.Wallet) {
 	return setupPoolWithConfig(params.TestChainConfig)
 }
 
-func setupPoolWithConfig(config *params.ChainConfig) (*LegacyPool, *walletmldsa87.Wallet) {
+func setupPoolWithConfig(config *params.ChainConfig) (*LegacyPool, pqcrypto.Wallet) {
 	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
 	blockchain := newTestBlockChain(config, 10000000, statedb, new(event.Feed))
 
@@ -357,6 +358,136 @@ func TestInvalidTransactions(t *testing.T) {
 	}
 }
 
+// Tests that a transaction carrying a valid public key but a forged signature
+// is rejected by the pool.
+func TestForgedSignature(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	tx := transaction(0, 100000, key)
+	from, _ := deriveSender(tx)
+	testAddBalance(pool, from, big.NewInt(0xffffffffffffff))
+
+	sig := common.CopyBytes(tx.RawSignatureValue())
+	sig[len(sig)/2] ^= 0x01
+	forged, err := tx.WithSignaturePublicKeyAndDescriptor(pool.signer, sig, tx.RawPublicKeyValue(), tx.RawDescriptorValue())
+	if err != nil {
+		t.Fatalf("failed to attach forged signature: %v", err)
+	}
+	if err := pool.addRemote(forged); !errors.Is(err, txpool.ErrInvalidSender) {
+		t.Errorf("want %v have %v", txpool.ErrInvalidSender, err)
+	}
+	if err := pool.addLocal(forged); !errors.Is(err, txpool.ErrInvalidSender) {
+		t.Errorf("want %v have %v", txpool.ErrInvalidSender, err)
+	}
+	if pending, queued := pool.Stats(); pending != 0 || queued != 0 {
+		t.Errorf("pool not empty: pending %d, queued %d", pending, queued)
+	}
+	// The genuine transaction must still be accepted.
+	if err := pool.addRemoteSync(tx); err != nil {
+		t.Errorf("failed to add genuine transaction: %v", err)
+	}
+}
+
+// Tests that account abstraction transactions are only accepted from contract
+// senders approving them, with a validation gas allowance within the pool's
+// limits.
+func TestAccountAbstractionTransactions(t *testing.T) {
+	t.Parallel()
+
+	pool, _ := setupPool()
+	defer pool.Close()
+
+	sender := common.BytesToAddress([]byte{0xaa, 0x01})
+	mkTx := func(gas, validationGas uint64) *types.Transaction {
+		return types.NewTx(&types.AccountAbstractionTx{
+			ChainID:       params.TestChainConfig.ChainID,
+			GasTipCap:     big.NewInt(1),
+			GasFeeCap:     big.NewInt(1),
+			Gas:           gas,
+			ValidationGas: validationGas,
+			Sender:        sender,
+			To:            &common.Address{},
+			Value:         big.NewInt(0),
+		})
+	}
+	testAddBalance(pool, sender, big.NewInt(0xffffffffffffff))
+
+	if err, want := pool.addRemote(mkTx(100000, 50000)), core.ErrSenderNoContract; !errors.Is(err, want) {
+		t.Errorf("want %v have %v", want, err)
+	}
+	// Senders not returning the validateTransaction selector, or running out of
+	// validation gas, must be rejected without entering the pool.
+	setCode := func(code []byte) {
+		pool.mu.Lock()
+		pool.currentState.SetCode(sender, code)
+		pool.validation.State.SetCode(sender, code)
+		pool.mu.Unlock()
+	}
+	setCode([]byte{0x00})
+	if err, want := pool.addRemote(mkTx(100000, 50000)), core.ErrValidationFailed; !errors.Is(err, want) {
+		t.Errorf("want %v have %v", want, err)
+	}
+	setCode(common.FromHex("5b600056")) // JUMPDEST, PUSH1 0, JUMP
+	if err, want := pool.addRemote(mkTx(100000, 50000)), core.ErrValidationFailed; !errors.Is(err, want) {
+		t.Errorf("want %v have %v", want, err)
+	}
+	// The sender approves only if validated in the block following the head,
+	// i.e. with a later timestamp and the head's hash available to BLOCKHASH.
+	selector := crypto.Keccak256([]byte("validateTransaction(bytes32,bytes)"))[:4]
+	approver := common.FromHex("600143034015603757" + "4215603757") // Jump to the end if BLOCKHASH(NUMBER-1) or TIMESTAMP is zero
+	approver = append(append(append(approver, 0x7f), common.RightPadBytes(selector, 32)...), common.FromHex("60005260206000f3")...)
+	setCode(append(approver, common.FromHex("5b00")...))
+
+	if err, want := pool.addRemote(mkTx(100000, 200000)), core.ErrValidationGasTooHigh; !errors.Is(err, want) {
+		t.Errorf("want %v have %v", want, err)
+	}
+	if err, want := pool.addRemote(mkTx(1000000, params.MaxValidationGas+1)), txpool.ErrValidationGasLimit; !errors.Is(err, want) {
+		t.Errorf("want %v have %v", want, err)
+	}
+	if err, want := pool.addRemote(mkTx(params.TxGas+1000, params.TxGas)), core.ErrIntrinsicGas; !errors.Is(err, want) {
+		t.Errorf("want %v have %v", want, err)
+	}
+	if err := pool.addRemoteSync(mkTx(100000, 50000)); err != nil {
+		t.Errorf("failed to add account abstraction transaction: %v", err)
+	}
+	if pending, _ := pool.Stats(); pending != 1 {
+		t.Errorf("pending transaction mismatch: have %d, want 1", pending)
+	}
+}
+
+// Tests that SPHINCS+-256s signed transactions are only accepted once Cancun is
+// active at the head of the pool.
+func TestSphincsPlusActivation(t *testing.T) {
+	t.Parallel()
+
+	key, _ := pqcrypto.GenerateWallet(wallettype.SPHINCSPLUS_256S)
+	tx := transaction(0, 100000, key)
+
+	cancunTime := uint64(10)
+	beforeCancun := *params.TestChainConfig
+	beforeCancun.CancunTime = &cancunTime
+
+	tests := []struct {
+		config *params.ChainConfig
+		want   error
+	}{
+		{&beforeCancun, types.ErrSchemeNotSupported},
+		{params.TestChainConfig, nil},
+	}
+	for i, tt := range tests {
+		pool, _ := setupPoolWithConfig(tt.config)
+		testAddBalance(pool, key.GetAddress(), big.NewInt(1000000))
+
+		if err := pool.addRemoteSync(tx); !errors.Is(err, tt.want) {
+			t.Errorf("test %d: want %v have %v", i, tt.want, err)
+		}
+		pool.Close()
+	}
+}
+
 func TestQueue(t *testing.T) {
 	t.Parallel()
 
@@ -713,7 +844,7 @@ func TestPostponing(t *testing.T) {
 	defer pool.Close()
 
 	// Create two test accounts to produce different gap profiles with
-	keys := make([]*walletmldsa87.Wallet, 2)
+	keys := make([]pqcrypto.Wallet, 2)
 	accs := make([]common.Address, len(keys))
 
 	for i := 0; i < len(keys); i++ {
@@ -930,7 +1061,7 @@ func testQueueGlobalLimiting(t *testing.T, nolocals bool) {
 	defer pool.Close()
 
 	// Create a number of test accounts and fund them (last one will be the local)
-	keys := make([]*walletmldsa87.Wallet, 5)
+	keys := make([]pqcrypto.Wallet, 5)
 	for i := 0; i < len(keys); i++ {
 		keys[i], _ = crypto.GenerateMLDSA87Key()
 		testAddBalance(pool, keys[i].GetAddress(), big.NewInt(1000000))
@@ -1209,7 +1340,7 @@ func TestPendingGlobalLimiting(t *testing.T) {
 	defer pool.Close()
 
 	// Create a number of test accounts and fund them
-	keys := make([]*walletmldsa87.Wallet, 5)
+	keys := make([]pqcrypto.Wallet, 5)
 	for i := 0; i < len(keys); i++ {
 		keys[i], _ = crypto.GenerateMLDSA87Key()
 		testAddBalance(pool, keys[i].GetAddress(), big.NewInt(1000000))
@@ -1347,7 +1478,7 @@ func TestPendingMinimumAllowance(t *testing.T) {
 	defer pool.Close()
 
 	// Create a number of test accounts and fund them
-	keys := make([]*walletmldsa87.Wallet, 5)
+	keys := make([]pqcrypto.Wallet, 5)
 	for i := 0; i < len(keys); i++ {
 		keys[i], _ = crypto.GenerateMLDSA87Key()
 		testAddBalance(pool, keys[i].GetAddress(), big.NewInt(1000000))
@@ -1438,7 +1569,7 @@ func TestRepricingDynamicFee(t *testing.T) {
 	defer sub.Unsubscribe()
 
 	// Create a number of test accounts and fund them
-	keys := make([]*walletmldsa87.Wallet, 4)
+	keys := make([]pqcrypto.Wallet, 4)
 	for i := 0; i < len(keys); i++ {
 		keys[i], _ = crypto.GenerateMLDSA87Key()
 		testAddBalance(pool, keys[i].GetAddress(), big.NewInt(1000000))
@@ -1561,7 +1692,7 @@ func TestRepricingKeepsLocals(t *testing.T) {
 	defer pool.Close()
 
 	// Create a number of test accounts and fund them
-	keys := make([]*walletmldsa87.Wallet, 3)
+	keys := make([]pqcrypto.Wallet, 3)
 	for i := 0; i < len(keys); i++ {
 		keys[i], _ = crypto.GenerateMLDSA87Key()
 		testAddBalance(pool, keys[i].GetAddress(), big.NewInt(100000*1000000))
@@ -1631,7 +1762,7 @@ func TestStableUnderpricing(t *testing.T) {
 	defer sub.Unsubscribe()
 
 	// Create a number of test accounts and fund them
-	keys := make([]*walletmldsa87.Wallet, 2)
+	keys := make([]pqcrypto.Wallet, 2)
 	for i := 0; i < len(keys); i++ {
 		keys[i], _ = crypto.GenerateMLDSA87Key()
 		testAddBalance(pool, keys[i].GetAddress(), big.NewInt(1000000))
@@ -1695,9 +1826,9 @@ func TestUnderpricingDynamicFee(t *testing.T) {
 	defer sub.Unsubscribe()
 
 	// Create a number of test accounts and fund them
-	keys := make([]*walletmldsa87.Wallet, 4)
+	keys := make([]pqcrypto.Wallet, 4)
 	for i := 0; i < len(keys); i++ {
-		keys[i], _ = walletmldsa87.NewWallet()
+		keys[i], _ = pqcrypto.GenerateWalletKey()
 		testAddBalance(pool, keys[i].GetAddress(), big.NewInt(1000000))
 	}
 
@@ -2134,6 +2265,148 @@ func testJournaling(t *testing.T, nolocals bool) {
 	pool.Close()
 }
 
+// Tests that remote transactions are persisted across pool restarts if enabled,
+// that they are revalidated against the new head and that they expire based on
+// their original arrival time.
+func TestPersistence(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	config := testTxPoolConfig
+	config.Persist = true
+	config.PersistDir = t.TempDir()
+
+	pool := New(config, blockchain)
+	pool.Init(new(big.Int).SetUint64(config.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
+
+	// Create an account with executable and one with gapped transactions
+	executable, _ := crypto.GenerateMLDSA87Key()
+	gapped, _ := crypto.GenerateMLDSA87Key()
+
+	testAddBalance(pool, executable.GetAddress(), big.NewInt(1000000000))
+	testAddBalance(pool, gapped.GetAddress(), big.NewInt(1000000000))
+
+	txs := []*types.Transaction{
+		dynamicFeeTx(0, 100000, big.NewInt(1), big.NewInt(1), executable),
+		dynamicFeeTx(1, 100000, big.NewInt(1), big.NewInt(1), executable),
+		dynamicFeeTx(1, 100000, big.NewInt(1), big.NewInt(1), gapped),
+	}
+	for i, err := range pool.addRemotesSync(txs) {
+		if err != nil {
+			t.Fatalf("failed to add remote transaction %d: %v", i, err)
+		}
+	}
+	seen := pool.Get(txs[1].Hash()).Time()
+
+	// Terminate the old pool, include the first transaction and ensure the rest
+	// of the transactions survive
+	pool.Close()
+	statedb.SetNonce(executable.GetAddress(), 1)
+	blockchain = newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	pool = New(config, blockchain)
+	pool.Init(new(big.Int).SetUint64(config.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
+
+	pending, queued := pool.Stats()
+	if pending != 1 {
+		t.Fatalf("pending transactions mismatched: have %d, want %d", pending, 1)
+	}
+	if queued != 1 {
+		t.Fatalf("queued transactions mismatched: have %d, want %d", queued, 1)
+	}
+	if tx := pool.Get(txs[1].Hash()); tx == nil || !tx.Time().Equal(seen) {
+		t.Fatalf("persisted transaction arrival time lost")
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+	// Restart the pool with a lifetime shorter than the age of the transactions
+	// and ensure they are all dropped
+	pool.Close()
+	time.Sleep(10 * time.Millisecond)
+	config.Lifetime = time.Millisecond
+
+	pool = New(config, blockchain)
+	pool.Init(new(big.Int).SetUint64(config.PriceLimit), blockchain.CurrentBlock(), makeAddressReserver())
+	defer pool.Close()
+
+	if pending, queued = pool.Stats(); pending != 0 || queued != 0 {
+		t.Fatalf("expired transactions loaded: pending %d, queued %d", pending, queued)
+	}
+}
+
+// Tests that the lifecycle events of transactions are reported along with the
+// reasons of their replacements and evictions.
+func TestTxEvents(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	events := make(chan []*txpool.TxEvent, 16)
+	sub := pool.SubscribeTxEvents(events)
+	defer sub.Unsubscribe()
+
+	addr := key.GetAddress()
+	testAddBalance(pool, addr, big.NewInt(1000000000))
+
+	check := func(want ...*txpool.TxEvent) {
+		t.Helper()
+
+		select {
+		case have := <-events:
+			if len(have) != len(want) {
+				t.Fatalf("event count mismatch: have %d, want %d", len(have), len(want))
+			}
+			for i := range want {
+				if *have[i] != *want[i] {
+					t.Fatalf("event %d mismatch: have %+v, want %+v", i, have[i], want[i])
+				}
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("event timeout")
+		}
+	}
+	// Add a few transactions and replace one of them
+	var (
+		tx0  = dynamicFeeTx(0, 100000, big.NewInt(1), big.NewInt(1), key)
+		tx1  = dynamicFeeTx(1, 100000, big.NewInt(1), big.NewInt(1), key)
+		tx1b = dynamicFeeTx(1, 100000, big.NewInt(2), big.NewInt(2), key)
+	)
+	if errs := pool.addRemotesSync([]*types.Transaction{tx0, tx1}); errs[0] != nil || errs[1] != nil {
+		t.Fatalf("failed to add transactions: %v", errs)
+	}
+	check(
+		&txpool.TxEvent{Kind: txpool.TxEventAdded, Hash: tx0.Hash(), From: addr, Nonce: 0},
+		&txpool.TxEvent{Kind: txpool.TxEventAdded, Hash: tx1.Hash(), From: addr, Nonce: 1},
+	)
+	if err := pool.addRemoteSync(tx1b); err != nil {
+		t.Fatalf("failed to replace transaction: %v", err)
+	}
+	check(
+		&txpool.TxEvent{Kind: txpool.TxEventReplaced, Hash: tx1.Hash(), From: addr, Nonce: 1, ReplacedBy: tx1b.Hash()},
+		&txpool.TxEvent{Kind: txpool.TxEventAdded, Hash: tx1b.Hash(), From: addr, Nonce: 1},
+	)
+	// Consume the nonce of the first transaction from outside the pool
+	testSetNonce(pool, addr, 1)
+	<-pool.requestReset(nil, nil)
+	check(&txpool.TxEvent{Kind: txpool.TxEventDropped, Hash: tx0.Hash(), From: addr, Nonce: 0, Reason: txpool.DropNonceTooLow})
+
+	// Drain the account so the remaining transaction cannot be paid for
+	pool.mu.Lock()
+	pool.currentState.SetBalance(addr, big.NewInt(0))
+	pool.mu.Unlock()
+
+	<-pool.requestReset(nil, nil)
+	check(&txpool.TxEvent{Kind: txpool.TxEventDropped, Hash: tx1b.Hash(), From: addr, Nonce: 1, Reason: txpool.DropInsufficient})
+
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
 // TestStatusCheck tests that the pool can correctly retrieve the
 // pending status of individual transactions.
 func TestStatusCheck(t *testing.T) {
@@ -2148,7 +2421,7 @@ func TestStatusCheck(t *testing.T) {
 	defer pool.Close()
 
 	// Create the test accounts to check various transaction statuses with
-	keys := make([]*walletmldsa87.Wallet, 3)
+	keys := make([]pqcrypto.Wallet, 3)
 	for i := 0; i < len(keys); i++ {
 		keys[i], _ = crypto.GenerateMLDSA87Key()
 		testAddBalance(pool, keys[i].GetAddress(), big.NewInt(1000000))
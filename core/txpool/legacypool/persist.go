@@ -0,0 +1,150 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package legacypool
+
+import (
+	"time"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/log"
+	"github.com/theQRL/go-zond/qrldb"
+	"github.com/theQRL/go-zond/qrldb/leveldb"
+	"github.com/theQRL/go-zond/rlp"
+)
+
+// persistedTx is the on-disk format of a snapshotted transaction, carrying the
+// time the transaction was first seen alongside its binary encoding.
+type persistedTx struct {
+	Time uint64 // Unix nanoseconds when the transaction was first seen
+	Tx   []byte // Binary encoding of the transaction
+}
+
+// persister is a database snapshot of the remote transactions in the pool with
+// the aim of allowing them to survive node restarts. Contrary to the journal,
+// it is not appended to on every insertion, rather it is regenerated on every
+// rotation and when the pool is stopped.
+type persister struct {
+	db qrldb.KeyValueStore // Database to store the transactions in, keyed by hash
+}
+
+// newTxPersister opens (or creates) the transaction snapshot database at the
+// given path.
+func newTxPersister(path string) (*persister, error) {
+	db, err := leveldb.New(path, 16, 16, "txpool/persist/", false)
+	if err != nil {
+		return nil, err
+	}
+	return &persister{db: db}, nil
+}
+
+// load retrieves all the transactions from the snapshot which were first seen
+// less than lifetime ago, restoring their original arrival times.
+func (p *persister) load(lifetime time.Duration) []*types.Transaction {
+	var (
+		txs     []*types.Transaction
+		expired int
+		corrupt int
+	)
+	it := p.db.NewIterator(nil, nil)
+	defer it.Release()
+
+	for it.Next() {
+		var entry persistedTx
+		if err := rlp.DecodeBytes(it.Value(), &entry); err != nil {
+			corrupt++
+			continue
+		}
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(entry.Tx); err != nil {
+			corrupt++
+			continue
+		}
+		seen := time.Unix(0, int64(entry.Time))
+		if time.Since(seen) > lifetime {
+			expired++
+			continue
+		}
+		tx.SetTime(seen)
+		txs = append(txs, tx)
+	}
+	if corrupt > 0 {
+		log.Warn("Skipped corrupted persisted transactions", "count", corrupt)
+	}
+	log.Info("Loaded persisted transaction pool", "transactions", len(txs), "expired", expired)
+	return txs
+}
+
+// snapshot regenerates the transaction snapshot based on the given contents of
+// the transaction pool. The contents must be a copy, as the pool lock is not
+// held while they are written out.
+func (p *persister) snapshot(all map[common.Address]types.Transactions) error {
+	batch := p.db.NewBatch()
+
+	// Delete everything not in the pool any more
+	keep := make(map[common.Hash]struct{})
+	for _, txs := range all {
+		for _, tx := range txs {
+			keep[tx.Hash()] = struct{}{}
+		}
+	}
+	it := p.db.NewIterator(nil, nil)
+	for it.Next() {
+		if _, ok := keep[common.BytesToHash(it.Key())]; !ok {
+			if err := batch.Delete(common.CopyBytes(it.Key())); err != nil {
+				it.Release()
+				return err
+			}
+		}
+	}
+	it.Release()
+
+	// Write out all the current transactions, overwriting any existing ones
+	persisted := 0
+	for _, txs := range all {
+		for _, tx := range txs {
+			blob, err := tx.MarshalBinary()
+			if err != nil {
+				return err
+			}
+			enc, err := rlp.EncodeToBytes(&persistedTx{Time: uint64(tx.Time().UnixNano()), Tx: blob})
+			if err != nil {
+				return err
+			}
+			if err := batch.Put(tx.Hash().Bytes(), enc); err != nil {
+				return err
+			}
+			if batch.ValueSize() > qrldb.IdealBatchSize {
+				if err := batch.Write(); err != nil {
+					return err
+				}
+				batch.Reset()
+			}
+		}
+		persisted += len(txs)
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	log.Debug("Regenerated persisted transaction pool", "transactions", persisted, "accounts", len(all))
+	return nil
+}
+
+// close flushes the snapshot database to disk and closes it.
+func (p *persister) close() error {
+	return p.db.Close()
+}
@@ -134,6 +134,10 @@ type SubPool interface {
 	// or also for reorged out ones.
 	SubscribeTransactions(ch chan<- core.NewTxsEvent) event.Subscription
 
+	// SubscribeTxEvents subscribes to transaction lifecycle events, detailing the
+	// transactions entering, being shuffled around in and leaving the pool.
+	SubscribeTxEvents(ch chan<- []*TxEvent) event.Subscription
+
 	// Nonce returns the next nonce of an account, with all transactions executable
 	// by the pool already applied on top.
 	Nonce(addr common.Address) uint64
@@ -195,6 +195,12 @@ func (p *TxPool) loop(head *types.Header, chain BlockChain) {
 		oldHead = head
 		newHead = oldHead
 	)
+	// If the chain moved forward between the pool creation and the subscription,
+	// the head event was missed. Catch up now, otherwise the subpools would sit
+	// on stale state until the next block arrives.
+	if current := chain.CurrentBlock(); current.Hash() != head.Hash() {
+		newHead = current
+	}
 	// Consume chain head events and start resets when none is running
 	var (
 		resetBusy = make(chan struct{}, 1) // Allow 1 reset to run concurrently
@@ -374,6 +380,17 @@ func (p *TxPool) SubscribeTransactions(ch chan<- core.NewTxsEvent) event.Subscri
 	return p.subs.Track(event.JoinSubscriptions(subs...))
 }
 
+// SubscribeTxEvents registers a subscription for transaction lifecycle events,
+// reporting additions, replacements, evictions and inclusions across all the
+// subpools.
+func (p *TxPool) SubscribeTxEvents(ch chan<- []*TxEvent) event.Subscription {
+	subs := make([]event.Subscription, len(p.subpools))
+	for i, subpool := range p.subpools {
+		subs[i] = subpool.SubscribeTxEvents(ch)
+	}
+	return p.subs.Track(event.JoinSubscriptions(subs...))
+}
+
 // Nonce returns the next nonce of an account, with all transactions executable
 // by the pool already applied on top.
 func (p *TxPool) Nonce(addr common.Address) uint64 {
@@ -20,10 +20,14 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/theQRL/go-qrllib/wallet/common/descriptor"
+	"github.com/theQRL/go-qrllib/wallet/common/wallettype"
 	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/consensus"
 	"github.com/theQRL/go-zond/core"
 	"github.com/theQRL/go-zond/core/state"
 	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/core/vm"
 	"github.com/theQRL/go-zond/log"
 	"github.com/theQRL/go-zond/params"
 )
@@ -82,14 +86,40 @@ func ValidateTransaction(tx *types.Transaction, head *types.Header, signer types
 	if _, err := types.Sender(signer, tx); err != nil {
 		return ErrInvalidSender
 	}
+	// Ensure SPHINCS+-256s signatures are only accepted after Cancun. The pool
+	// signer accepts every scheme the chain is configured to, not just the ones
+	// active at the current head.
+	if tx.Type() != types.AccountAbstractionTxType && !opts.Config.IsCancun(head.Time) {
+		d, err := descriptor.FromBytes(tx.RawDescriptorValue())
+		if err != nil {
+			return ErrInvalidSender
+		}
+		if wallettype.WalletType(d.Type()) == wallettype.SPHINCSPLUS_256S {
+			return fmt.Errorf("%w: wallet type %d rejected, pool not yet in Cancun", types.ErrSchemeNotSupported, d.Type())
+		}
+	}
+	// Ensure account abstraction transactions are only accepted after Cancun and
+	// that the work the pool's users can make block producers do for free is
+	// bounded: validation failures only surface once the tx is executed.
+	if tx.Type() == types.AccountAbstractionTxType {
+		if !opts.Config.IsCancun(head.Time) {
+			return fmt.Errorf("%w: type %d rejected, pool not yet in Cancun", core.ErrTxTypeNotSupported, tx.Type())
+		}
+		if tx.ValidationGas() > tx.Gas() {
+			return fmt.Errorf("%w: validation gas %v, gas %v", core.ErrValidationGasTooHigh, tx.ValidationGas(), tx.Gas())
+		}
+		if tx.ValidationGas() > params.MaxValidationGas {
+			return fmt.Errorf("%w: validation gas %v, limit %v", ErrValidationGasLimit, tx.ValidationGas(), params.MaxValidationGas)
+		}
+	}
 	// Ensure the transaction has more gas than the bare minimum needed to cover
-	// the transaction metadata
+	// the transaction metadata and the validation phase
 	intrGas, err := core.IntrinsicGas(tx.Data(), tx.AccessList(), tx.To() == nil)
 	if err != nil {
 		return err
 	}
-	if tx.Gas() < intrGas {
-		return fmt.Errorf("%w: gas %v, minimum needed %v", core.ErrIntrinsicGas, tx.Gas(), intrGas)
+	if tx.Gas() < intrGas || tx.Gas()-intrGas < tx.ValidationGas() {
+		return fmt.Errorf("%w: gas %v, minimum needed %v", core.ErrIntrinsicGas, tx.Gas(), intrGas+tx.ValidationGas())
 	}
 	// Ensure the gasprice is high enough to cover the requirement of the calling pool
 	if tx.GasTipCapIntCmp(opts.MinTip) < 0 {
@@ -99,11 +129,54 @@ func ValidateTransaction(tx *types.Transaction, head *types.Header, signer types
 	return nil
 }
 
+// ValidationEnv is the environment the validation phase of account abstraction
+// transactions is simulated in ahead of their inclusion. Pools prepare it once
+// per head, as preparing it for each transaction would copy the state each time.
+type ValidationEnv struct {
+	Config  *params.ChainConfig // Chain configuration to simulate validation with
+	Context vm.BlockContext     // Context of the block following the head
+	State   *state.StateDB      // Copy of the head state, simulations revert their changes
+}
+
+// NewValidationEnv prepares the environment to simulate account abstraction
+// validation in on top of the given head and its state.
+func NewValidationEnv(config *params.ChainConfig, head *types.Header, statedb *state.StateDB, chain BlockReader) *ValidationEnv {
+	return &ValidationEnv{
+		Config:  config,
+		Context: core.NewQRVMPendingBlockContext(config, head, blockHeaders{chain}),
+		State:   statedb.Copy(),
+	}
+}
+
+// BlockReader retrieves the blocks of the chain, used to serve block hashes to
+// the validation simulations.
+type BlockReader interface {
+	GetBlock(hash common.Hash, number uint64) *types.Block
+}
+
+// blockHeaders adapts a BlockReader to the chain context needed by the QRVM to
+// retrieve block hashes.
+type blockHeaders struct {
+	chain BlockReader
+}
+
+// Engine is never called, since the block author is always given explicitly.
+func (c blockHeaders) Engine() consensus.Engine { return nil }
+
+func (c blockHeaders) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if block := c.chain.GetBlock(hash, number); block != nil {
+		return block.Header()
+	}
+	return nil
+}
+
 // ValidationOptionsWithState define certain differences between stateful transaction
 // validation across the different pools without having to duplicate those checks.
 type ValidationOptionsWithState struct {
 	State *state.StateDB // State database to check nonces and balances against
 
+	Validation *ValidationEnv // Environment to simulate account abstraction validation in
+
 	// FirstNonceGap is an optional callback to retrieve the first nonce gap in
 	// the list of pooled transactions of a specific account. If this method is
 	// set, nonce gaps will be checked and forbidden. If this method is not set,
@@ -131,11 +204,23 @@ type ValidationOptionsWithState struct {
 // rules without duplicating code and running the risk of missed updates.
 func ValidateTransactionWithState(tx *types.Transaction, signer types.Signer, opts *ValidationOptionsWithState) error {
 	// Ensure the transaction adheres to nonce ordering
-	from, err := signer.Sender(tx) // already validated (and cached), but cleaner to check
+	from, err := types.Sender(signer, tx) // already validated (and cached), but cleaner to check
 	if err != nil {
 		log.Error("Transaction sender recovery failed", "err", err)
 		return err
 	}
+	// Ensure the sender of account abstraction transactions is able to validate them
+	if tx.Type() == types.AccountAbstractionTxType && opts.State.GetCodeSize(from) == 0 {
+		return fmt.Errorf("%w: address %v", core.ErrSenderNoContract, from)
+	}
+	// Ensure the sender contract approves account abstraction transactions, so
+	// they can't be used to fill up the pool without paying for any of them
+	if tx.Type() == types.AccountAbstractionTxType {
+		env := opts.Validation
+		if err := core.ValidateAccountAbstraction(env.Config, env.Context, env.State, tx, signer, params.MaxValidationGas); err != nil {
+			return err
+		}
+	}
 	next := opts.State.GetNonce(from)
 	if next > tx.Nonce() {
 		return fmt.Errorf("%w: next nonce %v, tx nonce %v", core.ErrNonceTooLow, next, tx.Nonce())
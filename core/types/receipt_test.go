@@ -377,3 +377,38 @@ func clearComputedFieldsOnLogs(logs []*Log) []*Log {
 	}
 	return l
 }
+
+// TestReceiptForStorageValidationGas tests that the validation gas used by
+// account abstraction transactions survives the storage encoding, and that
+// receipts of other transactions keep their encoding.
+func TestReceiptForStorageValidationGas(t *testing.T) {
+	for _, validationGasUsed := range []uint64{0, 21000} {
+		receipt := &Receipt{
+			Status:            ReceiptStatusSuccessful,
+			CumulativeGasUsed: 42000,
+			Logs:              []*Log{},
+			ValidationGasUsed: validationGasUsed,
+		}
+		enc, err := rlp.EncodeToBytes((*ReceiptForStorage)(receipt))
+		if err != nil {
+			t.Fatalf("failed to encode receipt: %v", err)
+		}
+		var dec ReceiptForStorage
+		if err := rlp.DecodeBytes(enc, &dec); err != nil {
+			t.Fatalf("failed to decode receipt: %v", err)
+		}
+		if dec.ValidationGasUsed != validationGasUsed {
+			t.Errorf("validation gas mismatch: have %d, want %d", dec.ValidationGasUsed, validationGasUsed)
+		}
+		if validationGasUsed == 0 {
+			var stored struct {
+				PostStateOrStatus []byte
+				CumulativeGasUsed uint64
+				Logs              []*Log
+			}
+			if err := rlp.DecodeBytes(enc, &stored); err != nil {
+				t.Errorf("encoding of regular receipt changed: %v", err)
+			}
+		}
+	}
+}
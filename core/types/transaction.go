@@ -31,8 +31,8 @@ import (
 
 var (
 	// NOTE(rgeraldes24): unused for now
-	// ErrInvalidSig           = errors.New("invalid transaction v, r, s values")
 	// ErrUnexpectedProtection = errors.New("transaction type does not supported EIP-155 protected signatures")
+	ErrInvalidSig         = errors.New("invalid transaction signature")
 	ErrTxTypeNotSupported = errors.New("transaction type not supported")
 	ErrGasFeeCapTooLow    = errors.New("fee cap less than base fee")
 	errShortTypedTx       = errors.New("typed transaction too short")
@@ -40,7 +40,8 @@ var (
 
 // Transaction types.
 const (
-	DynamicFeeTxType = 0x02
+	DynamicFeeTxType         = 0x02
+	AccountAbstractionTxType = 0x03
 )
 
 // Transaction is a QRL transaction.
@@ -63,7 +64,7 @@ func NewTx(inner TxData) *Transaction {
 
 // TxData is the underlying data of a transaction.
 //
-// This is implemented by DynamicFeeTx.
+// This is implemented by DynamicFeeTx and AccountAbstractionTx.
 type TxData interface {
 	txType() byte // returns the type ID
 	copy() TxData // creates a deep copy and initializes all fields
@@ -171,6 +172,8 @@ func (tx *Transaction) decodeTyped(b []byte) (TxData, error) {
 	switch b[0] {
 	case DynamicFeeTxType:
 		inner = new(DynamicFeeTx)
+	case AccountAbstractionTxType:
+		inner = new(AccountAbstractionTx)
 	default:
 		return nil, ErrTxTypeNotSupported
 	}
@@ -291,6 +294,25 @@ func (tx *Transaction) RawDescriptorValue() (descriptor []byte) {
 	return tx.inner.rawDescriptorValue()
 }
 
+// ValidationGas returns the gas limit of the validation phase of an account
+// abstraction transaction. It returns 0 for all other transaction types.
+func (tx *Transaction) ValidationGas() uint64 {
+	if aatx, ok := tx.inner.(*AccountAbstractionTx); ok {
+		return aatx.ValidationGas
+	}
+	return 0
+}
+
+// AccountAbstractionSender returns the contract account of an account abstraction
+// transaction, or nil for all other transaction types.
+func (tx *Transaction) AccountAbstractionSender() *common.Address {
+	if aatx, ok := tx.inner.(*AccountAbstractionTx); ok {
+		sender := aatx.Sender
+		return &sender
+	}
+	return nil
+}
+
 // GasFeeCapCmp compares the fee cap of two transactions.
 func (tx *Transaction) GasFeeCapCmp(other *Transaction) int {
 	return tx.inner.gasFeeCap().Cmp(other.inner.gasFeeCap())
@@ -400,6 +422,16 @@ func (tx *Transaction) WithSignaturePublicKeyAndDescriptor(signer Signer, sig, p
 	return &Transaction{inner: cpy, time: tx.time}, nil
 }
 
+// WithPublicKey returns a new transaction with the given public key, leaving the
+// signature and descriptor untouched. Unlike WithSignaturePublicKeyAndDescriptor
+// the key is not validated, which allows the database to strip and restore the
+// public keys of stored transactions.
+func (tx *Transaction) WithPublicKey(pk []byte) *Transaction {
+	cpy := tx.inner.copy()
+	cpy.setSignaturePublicKeyAndDescriptorValues(cpy.chainID(), cpy.rawSignatureValue(), common.CopyBytes(pk), cpy.rawDescriptorValue())
+	return &Transaction{inner: cpy, time: tx.time}
+}
+
 // Transactions implements DerivableList for transactions.
 type Transactions []*Transaction
 
@@ -38,9 +38,13 @@ type txJSON struct {
 	Value                *hexutil.Big    `json:"value"`
 	Input                *hexutil.Bytes  `json:"input"`
 	AccessList           *AccessList     `json:"accessList,omitempty"`
-	PublicKey            *hexutil.Bytes  `json:"publicKey"`
+	PublicKey            *hexutil.Bytes  `json:"publicKey,omitempty"`
 	Signature            *hexutil.Bytes  `json:"signature"`
-	Descriptor           *hexutil.Bytes  `json:"descriptor"`
+	Descriptor           *hexutil.Bytes  `json:"descriptor,omitempty"`
+
+	// Account abstraction transaction fields:
+	Sender        *common.Address `json:"sender,omitempty"`
+	ValidationGas *hexutil.Uint64 `json:"validationGas,omitempty"`
 
 	// Only used for encoding:
 	Hash common.Hash `json:"hash"`
@@ -68,6 +72,20 @@ func (tx *Transaction) MarshalJSON() ([]byte, error) {
 		enc.PublicKey = (*hexutil.Bytes)(&itx.PublicKey)
 		enc.Signature = (*hexutil.Bytes)(&itx.Signature)
 		enc.Descriptor = (*hexutil.Bytes)(&itx.Descriptor)
+
+	case *AccountAbstractionTx:
+		enc.ChainID = (*hexutil.Big)(itx.ChainID)
+		enc.Nonce = (*hexutil.Uint64)(&itx.Nonce)
+		enc.To = tx.To()
+		enc.Gas = (*hexutil.Uint64)(&itx.Gas)
+		enc.ValidationGas = (*hexutil.Uint64)(&itx.ValidationGas)
+		enc.MaxFeePerGas = (*hexutil.Big)(itx.GasFeeCap)
+		enc.MaxPriorityFeePerGas = (*hexutil.Big)(itx.GasTipCap)
+		enc.Value = (*hexutil.Big)(itx.Value)
+		enc.Input = (*hexutil.Bytes)(&itx.Data)
+		enc.AccessList = &itx.AccessList
+		enc.Sender = &itx.Sender
+		enc.Signature = (*hexutil.Bytes)(&itx.Signature)
 	}
 	return json.Marshal(&enc)
 }
@@ -140,6 +158,56 @@ func (tx *Transaction) UnmarshalJSON(input []byte) error {
 		//	}
 		//}
 
+	case AccountAbstractionTxType:
+		var itx AccountAbstractionTx
+		inner = &itx
+		if dec.ChainID == nil {
+			return errors.New("missing required field 'chainId' in transaction")
+		}
+		itx.ChainID = (*big.Int)(dec.ChainID)
+		if dec.Nonce == nil {
+			return errors.New("missing required field 'nonce' in transaction")
+		}
+		itx.Nonce = uint64(*dec.Nonce)
+		if dec.To != nil {
+			itx.To = dec.To
+		}
+		if dec.Gas == nil {
+			return errors.New("missing required field 'gas' for txdata")
+		}
+		itx.Gas = uint64(*dec.Gas)
+		if dec.ValidationGas == nil {
+			return errors.New("missing required field 'validationGas' for txdata")
+		}
+		itx.ValidationGas = uint64(*dec.ValidationGas)
+		if dec.MaxPriorityFeePerGas == nil {
+			return errors.New("missing required field 'maxPriorityFeePerGas' for txdata")
+		}
+		itx.GasTipCap = (*big.Int)(dec.MaxPriorityFeePerGas)
+		if dec.MaxFeePerGas == nil {
+			return errors.New("missing required field 'maxFeePerGas' for txdata")
+		}
+		itx.GasFeeCap = (*big.Int)(dec.MaxFeePerGas)
+		if dec.Value == nil {
+			return errors.New("missing required field 'value' in transaction")
+		}
+		itx.Value = (*big.Int)(dec.Value)
+		if dec.Input == nil {
+			return errors.New("missing required field 'input' in transaction")
+		}
+		itx.Data = *dec.Input
+		if dec.AccessList != nil {
+			itx.AccessList = *dec.AccessList
+		}
+		if dec.Sender == nil {
+			return errors.New("missing required field 'sender' in transaction")
+		}
+		itx.Sender = *dec.Sender
+		if dec.Signature == nil {
+			return errors.New("missing required field 'signature' in transaction")
+		}
+		itx.Signature = *dec.Signature
+
 	default:
 		return ErrTxTypeNotSupported
 	}
@@ -22,23 +22,30 @@ import (
 	"math/big"
 
 	"github.com/theQRL/go-qrllib/wallet/common/descriptor"
-	walletmldsa87 "github.com/theQRL/go-qrllib/wallet/ml_dsa_87"
+	"github.com/theQRL/go-qrllib/wallet/common/wallettype"
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/crypto/pqcrypto"
 	"github.com/theQRL/go-zond/params"
 )
 
-var ErrInvalidChainId = errors.New("invalid chain id for signer")
+var (
+	ErrInvalidChainId     = errors.New("invalid chain id for signer")
+	ErrSchemeNotSupported = errors.New("signature scheme not supported")
+)
 
-// sigCache is used to cache the derived sender and contains
-// the signer used to derive it.
+// sigCache is used to cache the derived sender, or the error rejecting the
+// signature, and contains the signer used to derive it.
 type sigCache struct {
 	signer Signer
 	from   common.Address
+	err    error
 }
 
-// MakeSigner returns a Signer based on the given chain config and block number.
-func MakeSigner(config *params.ChainConfig) Signer {
+// MakeSigner returns a Signer based on the given chain config and block time.
+func MakeSigner(config *params.ChainConfig, blockTime uint64) Signer {
+	if config.IsCancun(blockTime) {
+		return NewCancunSigner(config.ChainID)
+	}
 	return NewShanghaiSigner(config.ChainID)
 }
 
@@ -50,6 +57,9 @@ func MakeSigner(config *params.ChainConfig) Signer {
 // Use this in transaction-handling code where the current block number is unknown. If you
 // have the current block number available, use MakeSigner instead.
 func LatestSigner(config *params.ChainConfig) Signer {
+	if config.CancunTime != nil {
+		return NewCancunSigner(config.ChainID)
+	}
 	return NewShanghaiSigner(config.ChainID)
 }
 
@@ -61,11 +71,12 @@ func LatestSigner(config *params.ChainConfig) Signer {
 // configuration are unknown. If you have a ChainConfig, use LatestSigner instead.
 // If you have a ChainConfig and know the current block number, use MakeSigner instead.
 func LatestSignerForChainID(chainID *big.Int) Signer {
-	return NewShanghaiSigner(chainID)
+	return NewCancunSigner(chainID)
 }
 
-// SignTx signs the transaction using the given ML-DSA-87 signer and wallet.
-func SignTx(tx *Transaction, s Signer, w *walletmldsa87.Wallet) (*Transaction, error) {
+// SignTx signs the transaction using the given signer and wallet. The signature
+// scheme is selected by the wallet's descriptor.
+func SignTx(tx *Transaction, s Signer, w pqcrypto.Wallet) (*Transaction, error) {
 	// Check that chain ID of tx matches the signer. We also accept ID zero here,
 	// because it indicates that the chain ID was not specified in the tx.
 	// NOTE(rgeraldes24): chain ID is filled in in the WithSignatureAndPublicKey method
@@ -79,25 +90,23 @@ func SignTx(tx *Transaction, s Signer, w *walletmldsa87.Wallet) (*Transaction, e
 	if err != nil {
 		return nil, err
 	}
-	pk := w.GetPK()
-	return tx.WithSignaturePublicKeyAndDescriptor(s, sig[:], pk[:], w.GetDescriptor().ToDescriptor().ToBytes())
+	return tx.WithSignaturePublicKeyAndDescriptor(s, sig, w.GetPK(), w.GetDescriptor().ToBytes())
 }
 
 // SignNewTx creates a transaction and signs it.
-func SignNewTx(w *walletmldsa87.Wallet, s Signer, txdata TxData) (*Transaction, error) {
+func SignNewTx(w pqcrypto.Wallet, s Signer, txdata TxData) (*Transaction, error) {
 	tx := NewTx(txdata)
 	h := s.Hash(tx)
 	sig, err := pqcrypto.Sign(h[:], w)
 	if err != nil {
 		return nil, err
 	}
-	pk := w.GetPK()
-	return tx.WithSignaturePublicKeyAndDescriptor(s, sig, pk[:], w.GetDescriptor().ToDescriptor().ToBytes())
+	return tx.WithSignaturePublicKeyAndDescriptor(s, sig, w.GetPK(), w.GetDescriptor().ToBytes())
 }
 
 // MustSignNewTx creates a transaction and signs it.
 // This panics if the transaction cannot be signed.
-func MustSignNewTx(d *walletmldsa87.Wallet, s Signer, txdata TxData) *Transaction {
+func MustSignNewTx(d pqcrypto.Wallet, s Signer, txdata TxData) *Transaction {
 	tx, err := SignNewTx(d, s, txdata)
 	if err != nil {
 		panic(err)
@@ -110,7 +119,9 @@ func MustSignNewTx(d *walletmldsa87.Wallet, s Signer, txdata TxData) *Transactio
 //
 // Sender may cache the address, allowing it to be used regardless of
 // signing method. The cache is invalidated if the cached signer does
-// not match the signer used in the current call.
+// not match the signer used in the current call. Signature failures are
+// cached as well, so an invalid transaction is only ever verified once
+// per signer.
 func Sender(signer Signer, tx *Transaction) (common.Address, error) {
 	if sc := tx.from.Load(); sc != nil {
 		sigCache := sc.(sigCache)
@@ -118,18 +129,29 @@ func Sender(signer Signer, tx *Transaction) (common.Address, error) {
 		// call is not the same as used current, invalidate
 		// the cache.
 		if sigCache.signer.Equal(signer) {
-			return sigCache.from, nil
+			return sigCache.from, sigCache.err
 		}
 	}
 
 	addr, err := signer.Sender(tx)
 	if err != nil {
+		// Cache the failure too, so an invalid signature is not verified again
+		tx.from.Store(sigCache{signer: signer, err: err})
 		return common.Address{}, err
 	}
 	tx.from.Store(sigCache{signer: signer, from: addr})
 	return addr, nil
 }
 
+// SenderCached reports whether the signature of tx has already been verified,
+// successfully or not, by a previous call to Sender with an equal signer.
+func SenderCached(signer Signer, tx *Transaction) bool {
+	if sc := tx.from.Load(); sc != nil {
+		return sc.(sigCache).signer.Equal(signer)
+	}
+	return false
+}
+
 // Signer encapsulates transaction signature handling. The name of this type is slightly
 // misleading because Signers don't actually sign, they're just for validating and
 // processing of signatures.
@@ -158,9 +180,12 @@ type ShanghaiSigner struct {
 }
 
 // NewShangaiSigner returns a signer that accepts
+// - account abstraction transactions
 // - EIP-1559 dynamic fee transactions
 // - EIP-2930 access list transactions,
 // - EIP-155 replay protected transactions
+//
+// signed with ML-DSA-87 keys.
 func NewShanghaiSigner(chainId *big.Int) Signer {
 	return ShanghaiSigner{chainId}
 }
@@ -169,16 +194,45 @@ func (s ShanghaiSigner) ChainID() *big.Int {
 	return s.ChainId
 }
 
+// Sender derives the sender address from the public key and descriptor of the
+// transaction and verifies the signature against the signing hash.
+//
+// Account abstraction transactions name their sender explicitly. Their
+// authorization data is checked by the sender contract during execution, not
+// by the signer.
 func (s ShanghaiSigner) Sender(tx *Transaction) (common.Address, error) {
+	return s.sender(tx, s.supported)
+}
+
+// supported reports whether the signer accepts signatures of the given scheme.
+func (s ShanghaiSigner) supported(d descriptor.Descriptor) bool {
+	return wallettype.WalletType(d.Type()) == wallettype.ML_DSA_87
+}
+
+func (s ShanghaiSigner) sender(tx *Transaction, supported func(descriptor.Descriptor) bool) (common.Address, error) {
 	if tx.ChainId().Cmp(s.ChainId) != 0 {
 		return common.Address{}, fmt.Errorf("%w: have %d want %d", ErrInvalidChainId, tx.ChainId(), s.ChainId)
 	}
+	if aatx, ok := tx.inner.(*AccountAbstractionTx); ok {
+		return aatx.Sender, nil
+	}
 
 	d, err := descriptor.FromBytes(tx.RawDescriptorValue())
 	if err != nil {
 		return common.Address{}, err
 	}
-	return pqcrypto.PKToAddress(tx.RawPublicKeyValue(), d)
+	if !supported(d) {
+		return common.Address{}, fmt.Errorf("%w: wallet type %d", ErrSchemeNotSupported, d.Type())
+	}
+	addr, err := pqcrypto.PKToAddress(tx.RawPublicKeyValue(), d)
+	if err != nil {
+		return common.Address{}, err
+	}
+	h := s.Hash(tx)
+	if !pqcrypto.Verify(h[:], tx.RawSignatureValue(), tx.RawPublicKeyValue(), d) {
+		return common.Address{}, ErrInvalidSig
+	}
+	return addr, nil
 }
 
 func (s ShanghaiSigner) Equal(s2 Signer) bool {
@@ -187,18 +241,68 @@ func (s ShanghaiSigner) Equal(s2 Signer) bool {
 }
 
 func (s ShanghaiSigner) SignaturePublicKeyAndDescriptorValues(tx *Transaction, sig, pk, desc []byte) (Signature, PublicKey, Descriptor []byte, err error) {
+	return s.signaturePublicKeyAndDescriptorValues(tx, sig, pk, desc, s.supported)
+}
+
+func (s ShanghaiSigner) signaturePublicKeyAndDescriptorValues(tx *Transaction, sig, pk, desc []byte, supported func(descriptor.Descriptor) bool) (Signature, PublicKey, Descriptor []byte, err error) {
 	// Check that chain ID of tx matches the signer. We also accept ID zero here,
 	// because it indicates that the chain ID was not specified in the tx.
 	chainID := tx.inner.chainID()
 	if chainID.Sign() != 0 && chainID.Cmp(s.ChainId) != 0 {
 		return nil, nil, nil, fmt.Errorf("%w: have %d want %d", ErrInvalidChainId, chainID, s.ChainId)
 	}
-	Signature = decodeSignature(sig)
-	PublicKey = decodePublicKey(pk)
+	if tx.Type() == AccountAbstractionTxType {
+		// The authorization data is opaque to the protocol.
+		return common.CopyBytes(sig), nil, nil, nil
+	}
 	Descriptor = decodeDescriptor(desc)
+	d, err := descriptor.FromBytes(Descriptor)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !supported(d) {
+		return nil, nil, nil, fmt.Errorf("%w: wallet type %d", ErrSchemeNotSupported, d.Type())
+	}
+	Signature = decodeSignature(sig, d)
+	PublicKey = decodePublicKey(pk, d)
 	return Signature, PublicKey, Descriptor, nil
 }
 
+// CancunSigner accepts the same transactions as the ShanghaiSigner, signed with
+// any scheme supported by the protocol, including SPHINCS+-256s.
+type CancunSigner struct {
+	ShanghaiSigner
+}
+
+// NewCancunSigner returns a signer that accepts the transactions the Shanghai
+// signer does, signed with either ML-DSA-87 or SPHINCS+-256s keys.
+func NewCancunSigner(chainId *big.Int) Signer {
+	return CancunSigner{ShanghaiSigner{chainId}}
+}
+
+func (s CancunSigner) Sender(tx *Transaction) (common.Address, error) {
+	return s.sender(tx, s.supported)
+}
+
+// supported reports whether the signer accepts signatures of the given scheme.
+func (s CancunSigner) supported(d descriptor.Descriptor) bool {
+	switch wallettype.WalletType(d.Type()) {
+	case wallettype.ML_DSA_87, wallettype.SPHINCSPLUS_256S:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s CancunSigner) Equal(s2 Signer) bool {
+	x, ok := s2.(CancunSigner)
+	return ok && x.ChainId.Cmp(s.ChainId) == 0
+}
+
+func (s CancunSigner) SignaturePublicKeyAndDescriptorValues(tx *Transaction, sig, pk, desc []byte) (Signature, PublicKey, Descriptor []byte, err error) {
+	return s.signaturePublicKeyAndDescriptorValues(tx, sig, pk, desc, s.supported)
+}
+
 // Hash returns the hash to be signed by the sender.
 // It does not uniquely identify the transaction.
 // Hash returns the hash to be signed by the sender.
@@ -219,6 +323,23 @@ func (s ShanghaiSigner) Hash(tx *Transaction) common.Hash {
 				tx.Data(),
 				tx.AccessList(),
 			})
+	case AccountAbstractionTxType:
+		aatx := tx.inner.(*AccountAbstractionTx)
+		return prefixedRlpHash(
+			tx.Type(),
+			[]interface{}{
+				s.ChainId,
+				aatx.Nonce,
+				aatx.GasTipCap,
+				aatx.GasFeeCap,
+				aatx.Gas,
+				aatx.ValidationGas,
+				aatx.Sender,
+				aatx.To,
+				aatx.Value,
+				aatx.Data,
+				aatx.AccessList,
+			})
 	default:
 		// This _should_ not happen, but in case someone sends in a bad
 		// json struct via RPC, it's probably more prudent to return an
@@ -228,22 +349,18 @@ func (s ShanghaiSigner) Hash(tx *Transaction) common.Hash {
 	}
 }
 
-func decodeSignature(sig []byte) (signature []byte) {
-	if len(sig) != pqcrypto.MLDSA87SignatureLength {
-		panic(fmt.Sprintf("wrong size for ml-dsa-87 signature: got %d, want %d", len(sig), pqcrypto.MLDSA87SignatureLength))
+func decodeSignature(sig []byte, d descriptor.Descriptor) (signature []byte) {
+	if want := pqcrypto.SignatureLength(d); len(sig) != want {
+		panic(fmt.Sprintf("wrong size for signature of wallet type %d: got %d, want %d", d.Type(), len(sig), want))
 	}
-	signature = make([]byte, pqcrypto.MLDSA87SignatureLength)
-	copy(signature, sig)
-	return signature
+	return common.CopyBytes(sig)
 }
 
-func decodePublicKey(pk []byte) (publicKey []byte) {
-	if len(pk) != pqcrypto.MLDSA87PublicKeyLength {
-		panic(fmt.Sprintf("wrong size for ml-dsa-87 publickey: got %d, want %d", len(pk), pqcrypto.MLDSA87PublicKeyLength))
+func decodePublicKey(pk []byte, d descriptor.Descriptor) (publicKey []byte) {
+	if want := pqcrypto.PublicKeyLength(d); len(pk) != want {
+		panic(fmt.Sprintf("wrong size for publickey of wallet type %d: got %d, want %d", d.Type(), len(pk), want))
 	}
-	publicKey = make([]byte, pqcrypto.MLDSA87PublicKeyLength)
-	copy(publicKey, pk)
-	return publicKey
+	return common.CopyBytes(pk)
 }
 
 func decodeDescriptor(d []byte) (descriptor []byte) {
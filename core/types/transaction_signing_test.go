@@ -21,8 +21,11 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/theQRL/go-qrllib/wallet/common/wallettype"
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/crypto"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
+	"github.com/theQRL/go-zond/params"
 	"github.com/theQRL/go-zond/rlp"
 )
 
@@ -45,6 +48,63 @@ func TestShaghaiSigning(t *testing.T) {
 	}
 }
 
+func TestCancunSigningSphincsPlus(t *testing.T) {
+	key, _ := pqcrypto.GenerateWallet(wallettype.SPHINCSPLUS_256S)
+	addr := key.GetAddress()
+
+	signer := NewCancunSigner(big.NewInt(18))
+	tx, err := SignTx(NewTx(&DynamicFeeTx{Nonce: 0, To: &addr, Value: new(big.Int), Gas: 0, GasFeeCap: new(big.Int), Data: nil}), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := len(tx.RawSignatureValue()), pqcrypto.SphincsPlus256sSignatureLength; have != want {
+		t.Fatalf("wrong signature length: have %d want %d", have, want)
+	}
+
+	// Round trip through the encoding to make sure the scheme specific
+	// lengths are preserved.
+	enc, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dec Transaction
+	if err := dec.UnmarshalBinary(enc); err != nil {
+		t.Fatal(err)
+	}
+	from, err := Sender(signer, &dec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != addr {
+		t.Errorf("exected from and address to be equal. Got %x want %x", from, addr)
+	}
+}
+
+// Tests that SPHINCS+-256s signatures are rejected by signers of forks before
+// Cancun, both when signing and when deriving the sender.
+func TestShanghaiSignerRejectsSphincsPlus(t *testing.T) {
+	key, _ := pqcrypto.GenerateWallet(wallettype.SPHINCSPLUS_256S)
+	addr := key.GetAddress()
+
+	cancunTime := uint64(10)
+	config := *params.TestChainConfig
+	config.CancunTime = &cancunTime
+
+	tx, err := SignNewTx(key, NewCancunSigner(config.ChainID), &DynamicFeeTx{Nonce: 0, To: &addr, Value: new(big.Int), Gas: 0, GasFeeCap: new(big.Int), Data: nil})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.WithSignaturePublicKeyAndDescriptor(NewShanghaiSigner(config.ChainID), tx.RawSignatureValue(), tx.RawPublicKeyValue(), tx.RawDescriptorValue()); !errors.Is(err, ErrSchemeNotSupported) {
+		t.Fatalf("signing error mismatch: have %v, want %v", err, ErrSchemeNotSupported)
+	}
+	if _, err := Sender(MakeSigner(&config, cancunTime-1), tx); !errors.Is(err, ErrSchemeNotSupported) {
+		t.Fatalf("sender error before Cancun mismatch: have %v, want %v", err, ErrSchemeNotSupported)
+	}
+	if from, err := Sender(MakeSigner(&config, cancunTime), tx); err != nil || from != addr {
+		t.Fatalf("sender after Cancun mismatch: have %x (%v), want %x", from, err, addr)
+	}
+}
+
 func TestEIP155ChainId(t *testing.T) {
 	key, _ := crypto.GenerateMLDSA87Key()
 	addr := common.Address(key.GetAddress())
@@ -111,3 +171,35 @@ func TestChainId(t *testing.T) {
 		t.Error("expected no error")
 	}
 }
+
+func TestSenderForgedSignature(t *testing.T) {
+	key, _ := defaultTestKey()
+	signer := NewShanghaiSigner(big.NewInt(1))
+
+	tx, err := SignTx(NewTx(&DynamicFeeTx{Nonce: 0, To: &common.Address{}, Value: new(big.Int), Gas: 0, GasFeeCap: new(big.Int), Data: nil}), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Keep the valid public key and descriptor, but tamper with the signature.
+	sig := common.CopyBytes(tx.RawSignatureValue())
+	sig[0] ^= 0xff
+	forged, err := tx.WithSignaturePublicKeyAndDescriptor(signer, sig, tx.RawPublicKeyValue(), tx.RawDescriptorValue())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Sender(signer, forged); !errors.Is(err, ErrInvalidSig) {
+		t.Errorf("expected error: %v, got %v", ErrInvalidSig, err)
+	}
+	// A signature over a different transaction must be rejected as well.
+	other, err := SignTx(NewTx(&DynamicFeeTx{Nonce: 1, To: &common.Address{}, Value: new(big.Int), Gas: 0, GasFeeCap: new(big.Int), Data: nil}), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed, err := tx.WithSignaturePublicKeyAndDescriptor(signer, other.RawSignatureValue(), tx.RawPublicKeyValue(), tx.RawDescriptorValue())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Sender(signer, replayed); !errors.Is(err, ErrInvalidSig) {
+		t.Errorf("expected error: %v, got %v", ErrInvalidSig, err)
+	}
+}
@@ -248,7 +248,7 @@ func decodeTx(data []byte) (*Transaction, error) {
 	return t, err
 }
 
-func defaultTestKey() (*walletmldsa87.Wallet, common.Address) {
+func defaultTestKey() (pqcrypto.Wallet, common.Address) {
 	key, _ := pqcrypto.HexToWallet("a7b1a3005d9e110009c48d45deb43f0a0e31846ed2c5aaefb6d4238040ad4c08794ffe65585c13eb6948c2faf6db90c2")
 	addr := key.GetAddress()
 	return key, addr
@@ -470,3 +470,62 @@ func TestTransactionSizes(t *testing.T) {
 		}
 	}
 }
+
+// TestAccountAbstractionTxCoding tests that account abstraction transactions
+// round trip through RLP and JSON, and that their sender is taken from the
+// transaction instead of being recovered from a signature.
+func TestAccountAbstractionTxCoding(t *testing.T) {
+	var (
+		signer       = NewShanghaiSigner(common.Big1)
+		sender, _    = common.NewAddressFromString("Q00000000000000000000000000000000000000aa")
+		recipient, _ = common.NewAddressFromString("Q095e7baea6a6c7c4c2dfeb977efac326af552d87")
+	)
+	unsigned := NewTx(&AccountAbstractionTx{
+		ChainID:       big.NewInt(1),
+		Nonce:         1,
+		GasTipCap:     big.NewInt(1),
+		GasFeeCap:     big.NewInt(10),
+		Gas:           100000,
+		ValidationGas: 50000,
+		Sender:        sender,
+		To:            &recipient,
+		Value:         big.NewInt(5),
+		Data:          []byte("abcdef"),
+		AccessList:    AccessList{{Address: recipient, StorageKeys: []common.Hash{{0}}}},
+	})
+	tx, err := unsigned.WithSignaturePublicKeyAndDescriptor(signer, []byte("authorization"), nil, nil)
+	if err != nil {
+		t.Fatalf("could not attach authorization: %v", err)
+	}
+	if signer.Hash(tx) != signer.Hash(unsigned) {
+		t.Fatal("signing hash depends on the authorization data")
+	}
+	for name, coder := range map[string]func(*Transaction) (*Transaction, error){
+		"rlp":  encodeDecodeBinary,
+		"json": encodeDecodeJSON,
+	} {
+		parsed, err := coder(tx)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if err := assertEqual(parsed, tx); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if have := parsed.ValidationGas(); have != 50000 {
+			t.Errorf("%s: validation gas mismatch: have %d, want %d", name, have, 50000)
+		}
+		if have := parsed.RawSignatureValue(); !bytes.Equal(have, []byte("authorization")) {
+			t.Errorf("%s: authorization mismatch: have %x", name, have)
+		}
+		from, err := Sender(signer, parsed)
+		if err != nil {
+			t.Fatalf("%s: sender derivation failed: %v", name, err)
+		}
+		if from != sender {
+			t.Errorf("%s: sender mismatch: have %v, want %v", name, from, sender)
+		}
+	}
+	if _, err := Sender(NewShanghaiSigner(big.NewInt(2)), tx); !errors.Is(err, ErrInvalidChainId) {
+		t.Errorf("expected chain id error, got %v", err)
+	}
+}
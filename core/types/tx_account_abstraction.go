@@ -0,0 +1,126 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/rlp"
+)
+
+// AccountAbstractionTx represents a transaction whose sender is a contract
+// account. Instead of carrying a public key and a signature that is checked by
+// the protocol, the transaction carries opaque authorization data that is handed
+// to the sender contract, which decides on its own whether the transaction is
+// valid. This allows for multisig wallets, key rotation and signature schemes
+// that are not natively supported by the protocol.
+type AccountAbstractionTx struct {
+	ChainID       *big.Int
+	Nonce         uint64
+	GasTipCap     *big.Int // a.k.a. maxPriorityFeePerGas
+	GasFeeCap     *big.Int // a.k.a. maxFeePerGas
+	Gas           uint64
+	ValidationGas uint64          // gas available to the validation phase, part of Gas
+	Sender        common.Address  // contract account validating and paying for the tx
+	To            *common.Address `rlp:"nil"` // nil means contract creation
+	Value         *big.Int
+	Data          []byte
+	AccessList    AccessList
+
+	// Authorization data passed to the sender contract during validation
+	Signature []byte
+}
+
+// copy creates a deep copy of the transaction data and initializes all fields.
+func (tx *AccountAbstractionTx) copy() TxData {
+	cpy := &AccountAbstractionTx{
+		Nonce:         tx.Nonce,
+		Gas:           tx.Gas,
+		ValidationGas: tx.ValidationGas,
+		Sender:        tx.Sender,
+		To:            copyAddressPtr(tx.To),
+		Data:          common.CopyBytes(tx.Data),
+		Signature:     common.CopyBytes(tx.Signature),
+		// These are copied below.
+		AccessList: make(AccessList, len(tx.AccessList)),
+		Value:      new(big.Int),
+		ChainID:    new(big.Int),
+		GasTipCap:  new(big.Int),
+		GasFeeCap:  new(big.Int),
+	}
+	copy(cpy.AccessList, tx.AccessList)
+	if tx.Value != nil {
+		cpy.Value.Set(tx.Value)
+	}
+	if tx.ChainID != nil {
+		cpy.ChainID.Set(tx.ChainID)
+	}
+	if tx.GasTipCap != nil {
+		cpy.GasTipCap.Set(tx.GasTipCap)
+	}
+	if tx.GasFeeCap != nil {
+		cpy.GasFeeCap.Set(tx.GasFeeCap)
+	}
+	return cpy
+}
+
+// accessors for innerTx.
+func (tx *AccountAbstractionTx) txType() byte           { return AccountAbstractionTxType }
+func (tx *AccountAbstractionTx) chainID() *big.Int      { return tx.ChainID }
+func (tx *AccountAbstractionTx) accessList() AccessList { return tx.AccessList }
+func (tx *AccountAbstractionTx) data() []byte           { return tx.Data }
+func (tx *AccountAbstractionTx) gas() uint64            { return tx.Gas }
+func (tx *AccountAbstractionTx) gasFeeCap() *big.Int    { return tx.GasFeeCap }
+func (tx *AccountAbstractionTx) gasTipCap() *big.Int    { return tx.GasTipCap }
+func (tx *AccountAbstractionTx) gasPrice() *big.Int     { return tx.GasFeeCap }
+func (tx *AccountAbstractionTx) value() *big.Int        { return tx.Value }
+func (tx *AccountAbstractionTx) nonce() uint64          { return tx.Nonce }
+func (tx *AccountAbstractionTx) to() *common.Address    { return tx.To }
+
+func (tx *AccountAbstractionTx) effectiveGasPrice(dst *big.Int, baseFee *big.Int) *big.Int {
+	if baseFee == nil {
+		return dst.Set(tx.GasFeeCap)
+	}
+	tip := dst.Sub(tx.GasFeeCap, baseFee)
+	if tip.Cmp(tx.GasTipCap) > 0 {
+		tip.Set(tx.GasTipCap)
+	}
+	return tip.Add(tip, baseFee)
+}
+
+func (tx *AccountAbstractionTx) rawSignatureValue() (signature []byte) {
+	return tx.Signature
+}
+
+// The sender of an account abstraction transaction is identified by its address,
+// there is no public key or descriptor involved.
+func (tx *AccountAbstractionTx) rawPublicKeyValue() (publicKey []byte)   { return nil }
+func (tx *AccountAbstractionTx) rawDescriptorValue() (descriptor []byte) { return nil }
+
+func (tx *AccountAbstractionTx) setSignaturePublicKeyAndDescriptorValues(chainID *big.Int, signature, publicKey, descriptor []byte) {
+	tx.ChainID, tx.Signature = chainID, signature
+}
+
+func (tx *AccountAbstractionTx) encode(b *bytes.Buffer) error {
+	return rlp.Encode(b, tx)
+}
+
+func (tx *AccountAbstractionTx) decode(input []byte) error {
+	return rlp.DecodeBytes(input, tx)
+}
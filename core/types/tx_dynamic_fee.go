@@ -21,7 +21,6 @@ import (
 	"math/big"
 
 	"github.com/theQRL/go-zond/common"
-	"github.com/theQRL/go-zond/crypto/pqcrypto"
 	"github.com/theQRL/go-zond/rlp"
 )
 
@@ -70,15 +69,17 @@ func (tx *DynamicFeeTx) copy() TxData {
 		To:    copyAddressPtr(tx.To),
 		Data:  common.CopyBytes(tx.Data),
 		Gas:   tx.Gas,
+		// The lengths of the public key and signature depend on the
+		// signature scheme selected by the descriptor.
+		PublicKey:  common.CopyBytes(tx.PublicKey),
+		Signature:  common.CopyBytes(tx.Signature),
+		Descriptor: common.CopyBytes(tx.Descriptor),
 		// These are copied below.
 		AccessList: make(AccessList, len(tx.AccessList)),
 		Value:      new(big.Int),
 		ChainID:    new(big.Int),
 		GasTipCap:  new(big.Int),
 		GasFeeCap:  new(big.Int),
-		PublicKey:  make([]byte, pqcrypto.MLDSA87PublicKeyLength),
-		Signature:  make([]byte, pqcrypto.MLDSA87SignatureLength),
-		Descriptor: make([]byte, pqcrypto.DescriptorSize),
 	}
 	copy(cpy.AccessList, tx.AccessList)
 	if tx.Value != nil {
@@ -93,15 +94,6 @@ func (tx *DynamicFeeTx) copy() TxData {
 	if tx.GasFeeCap != nil {
 		cpy.GasFeeCap.Set(tx.GasFeeCap)
 	}
-	if tx.PublicKey != nil {
-		copy(cpy.PublicKey[:pqcrypto.MLDSA87PublicKeyLength], tx.PublicKey)
-	}
-	if tx.Signature != nil {
-		copy(cpy.Signature[:pqcrypto.MLDSA87SignatureLength], tx.Signature)
-	}
-	if tx.Descriptor != nil {
-		copy(cpy.Descriptor[:pqcrypto.DescriptorSize], tx.Descriptor)
-	}
 	return cpy
 }
 
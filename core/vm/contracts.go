@@ -25,9 +25,11 @@ import (
 
 	pkgerrors "github.com/pkg/errors"
 	ssz "github.com/prysmaticlabs/fastssz"
+	"github.com/theQRL/go-qrllib/wallet/common/descriptor"
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/common/math"
 	"github.com/theQRL/go-zond/crypto/bn256"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
 	"github.com/theQRL/go-zond/params"
 )
 
@@ -51,7 +53,21 @@ var PrecompiledContractsShanghai = map[common.Address]PrecompiledContract{
 	common.BytesToAddress([]byte{8}): &bn256PairingIstanbul{},
 }
 
+// PrecompiledContractsCancun contains the default set of pre-compiled QRL
+// contracts used in the Cancun release.
+var PrecompiledContractsCancun = map[common.Address]PrecompiledContract{
+	common.BytesToAddress([]byte{1}): &depositroot{},
+	common.BytesToAddress([]byte{2}): &sha256hash{},
+	common.BytesToAddress([]byte{3}): &mldsa87Verify{},
+	common.BytesToAddress([]byte{4}): &dataCopy{},
+	common.BytesToAddress([]byte{5}): &bigModExp{eip2565: true},
+	common.BytesToAddress([]byte{6}): &bn256AddIstanbul{},
+	common.BytesToAddress([]byte{7}): &bn256ScalarMulIstanbul{},
+	common.BytesToAddress([]byte{8}): &bn256PairingIstanbul{},
+}
+
 var (
+	PrecompiledAddressesCancun   []common.Address
 	PrecompiledAddressesShanghai []common.Address
 )
 
@@ -59,11 +75,19 @@ func init() {
 	for k := range PrecompiledContractsShanghai {
 		PrecompiledAddressesShanghai = append(PrecompiledAddressesShanghai, k)
 	}
+	for k := range PrecompiledContractsCancun {
+		PrecompiledAddressesCancun = append(PrecompiledAddressesCancun, k)
+	}
 }
 
 // ActivePrecompiles returns the precompiles enabled with the current configuration.
 func ActivePrecompiles(rules params.Rules) []common.Address {
-	return PrecompiledAddressesShanghai
+	switch {
+	case rules.IsCancun:
+		return PrecompiledAddressesCancun
+	default:
+		return PrecompiledAddressesShanghai
+	}
 }
 
 // RunPrecompiledContract runs and evaluates the output of a precompiled contract.
@@ -184,6 +208,37 @@ func (c *sha256hash) Run(input []byte) ([]byte, error) {
 	return h[:], nil
 }
 
+// ML-DSA-87 signature verification implemented as a native contract.
+//
+// The input is the 32 byte message digest, followed by the public key, the
+// descriptor and the signature. The output is a 32 byte word set to 1 if the
+// signature is valid, and to 0 otherwise.
+type mldsa87Verify struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *mldsa87Verify) RequiredGas(input []byte) uint64 {
+	return uint64(len(input)+31)/32*params.MLDSA87VerifyPerWordGas + params.MLDSA87VerifyBaseGas
+}
+
+func (c *mldsa87Verify) Run(input []byte) ([]byte, error) {
+	const (
+		pkOffset   = pqcrypto.DigestLength
+		descOffset = pkOffset + pqcrypto.MLDSA87PublicKeyLength
+		sigOffset  = descOffset + pqcrypto.DescriptorSize
+	)
+	var (
+		digest = getData(input, 0, pqcrypto.DigestLength)
+		pk     = getData(input, pkOffset, pqcrypto.MLDSA87PublicKeyLength)
+		desc   = getData(input, descOffset, pqcrypto.DescriptorSize)
+		sig    = getData(input, sigOffset, pqcrypto.MLDSA87SignatureLength)
+	)
+	d, err := descriptor.FromBytes(desc)
+	if err != nil || !pqcrypto.Verify(digest, sig, pk, d) {
+		return common.LeftPadBytes(nil, 32), nil
+	}
+	return common.LeftPadBytes([]byte{1}, 32), nil
+}
+
 // data copy implemented as a native contract.
 type dataCopy struct{}
 
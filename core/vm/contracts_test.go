@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/params"
 )
 
 // precompiledTest defines the input/output pairs for precompiled contract tests.
@@ -51,6 +52,7 @@ type precompiledFailureTest struct {
 var allPrecompiles = map[common.Address]PrecompiledContract{
 	common.BytesToAddress([]byte{1}):    &depositroot{},
 	common.BytesToAddress([]byte{2}):    &sha256hash{},
+	common.BytesToAddress([]byte{3}):    &mldsa87Verify{},
 	common.BytesToAddress([]byte{4}):    &dataCopy{},
 	common.BytesToAddress([]byte{5}):    &bigModExp{eip2565: false},
 	common.BytesToAddress([]byte{0xf5}): &bigModExp{eip2565: true},
@@ -195,6 +197,54 @@ func BenchmarkPrecompiledIdentity(bench *testing.B) {
 	benchmarkPrecompiled("04", t, bench)
 }
 
+// Tests the sample inputs from the ML-DSA-87 signature verification precompile.
+func TestPrecompiledMLDSA87Verify(t *testing.T) {
+	testJson("mldsa87Verify", "Q0000000000000000000000000000000000000003", t)
+}
+func BenchmarkPrecompiledMLDSA87Verify(b *testing.B) {
+	benchJson("mldsa87Verify", "Q0000000000000000000000000000000000000003", b)
+}
+
+func TestPrecompiledMLDSA87VerifyOOG(t *testing.T) {
+	tests, err := loadJson("mldsa87Verify")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		testPrecompiledOOG("Q0000000000000000000000000000000000000003", test, t)
+	}
+}
+
+// Tests that the ML-DSA-87 verification precompile is only activated with the
+// Cancun fork, leaving the Shanghai precompile set untouched.
+func TestMLDSA87VerifyActivation(t *testing.T) {
+	var (
+		addr   = common.BytesToAddress([]byte{3})
+		cancun = uint64(10)
+		config = &params.ChainConfig{ChainID: common.Big1, CancunTime: &cancun}
+	)
+	for _, tt := range []struct {
+		time   uint64
+		active bool
+	}{
+		{time: 0, active: false},
+		{time: cancun - 1, active: false},
+		{time: cancun, active: true},
+	} {
+		qrvm := NewQRVM(BlockContext{BlockNumber: common.Big1, Time: tt.time}, TxContext{}, nil, config, Config{})
+		if _, ok := qrvm.precompile(addr); ok != tt.active {
+			t.Errorf("time %d: precompile activation mismatch: have %v, want %v", tt.time, ok, tt.active)
+		}
+		active := false
+		for _, precompile := range ActivePrecompiles(config.Rules(common.Big1, tt.time)) {
+			active = active || precompile == addr
+		}
+		if active != tt.active {
+			t.Errorf("time %d: active precompiles mismatch: have %v, want %v", tt.time, active, tt.active)
+		}
+	}
+}
+
 // Tests the sample inputs from the ModExp EIP 198.
 func TestPrecompiledModExp(t *testing.T) {
 	testJson("modexp", "Q0000000000000000000000000000000000000005", t)
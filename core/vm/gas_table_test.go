@@ -19,6 +19,7 @@ package vm
 import (
 	"bytes"
 	"math/big"
+	"reflect"
 	"sort"
 	"testing"
 
@@ -111,3 +112,39 @@ func TestCreateGas(t *testing.T) {
 		}
 	}
 }
+
+func TestTransientStorageGas(t *testing.T) {
+	// tstore(1, 0x2a) mstore(0, tload(1)) return(0, 32)
+	code := hexutil.MustDecode("0x602a60015d60015c60005260206000f3")
+
+	for i, tt := range []struct {
+		config  *params.ChainConfig
+		gasUsed uint64
+		err     error
+	}{
+		{params.AllBeaconProtocolChanges, 224, nil},
+		{params.NonActivatedConfig, 100_000, &ErrInvalidOpCode{opcode: TSTORE}},
+	} {
+		address := common.BytesToAddress([]byte("contract"))
+		statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		statedb.CreateAccount(address)
+		statedb.SetCode(address, code)
+		statedb.Finalise(true)
+		vmctx := BlockContext{
+			CanTransfer: func(StateDB, common.Address, *big.Int) bool { return true },
+			Transfer:    func(StateDB, common.Address, common.Address, *big.Int) {},
+			BlockNumber: big.NewInt(0),
+		}
+		vmenv := NewQRVM(vmctx, TxContext{}, statedb, tt.config, Config{})
+		ret, gas, err := vmenv.Call(AccountRef(common.Address{}), address, nil, 100_000, new(big.Int))
+		if !reflect.DeepEqual(err, tt.err) {
+			t.Fatalf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+		if gasUsed := 100_000 - gas; gasUsed != tt.gasUsed {
+			t.Errorf("test %d: gas used mismatch: have %v, want %v", i, gasUsed, tt.gasUsed)
+		}
+		if err == nil && new(big.Int).SetBytes(ret).Uint64() != 0x2a {
+			t.Errorf("test %d: transient value mismatch: have %x, want 0x2a", i, ret)
+		}
+	}
+}
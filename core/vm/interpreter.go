@@ -19,16 +19,18 @@ package vm
 import (
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/common/math"
+	"github.com/theQRL/go-zond/core/tracing"
 	"github.com/theQRL/go-zond/crypto"
 	"github.com/theQRL/go-zond/log"
 )
 
 // Config are the configuration options for the Interpreter
 type Config struct {
-	Tracer                  QRVMLogger // Opcode logger
-	NoBaseFee               bool       // Forces the EIP-1559 baseFee to 0 (needed for 0 price calls)
-	EnablePreimageRecording bool       // Enables recording of SHA3/keccak preimages
-	ExtraQips               []int      // Additional QIPS that are to be enabled
+	Tracer                  QRVMLogger     // Opcode logger
+	LiveTracer              *tracing.Hooks // Live tracer notified while importing blocks
+	NoBaseFee               bool           // Forces the EIP-1559 baseFee to 0 (needed for 0 price calls)
+	EnablePreimageRecording bool           // Enables recording of SHA3/keccak preimages
+	ExtraQips               []int          // Additional QIPS that are to be enabled
 }
 
 // ScopeContext contains the things that are per-call, such as stack and memory,
@@ -54,7 +56,13 @@ type QRVMInterpreter struct {
 // NewQRVMInterpreter returns a new instance of the Interpreter.
 func NewQRVMInterpreter(qrvm *QRVM) *QRVMInterpreter {
 	// If jump table was not initialised we set the default one.
-	table := &shanghaiInstructionSet
+	var table *JumpTable
+	switch {
+	case qrvm.chainRules.IsCancun:
+		table = &cancunInstructionSet
+	default:
+		table = &shanghaiInstructionSet
+	}
 	var extraQips []int
 	if len(qrvm.Config.ExtraQips) > 0 {
 		// Deep-copy jumptable to prevent modification of opcodes in other tables
@@ -44,7 +44,10 @@ type operation struct {
 	memorySize memorySizeFunc
 }
 
-var shanghaiInstructionSet = newShanghaiInstructionSet()
+var (
+	shanghaiInstructionSet = newShanghaiInstructionSet()
+	cancunInstructionSet   = newCancunInstructionSet()
+)
 
 // JumpTable contains the QRVM opcodes supported at a given fork.
 type JumpTable [256]*operation
@@ -67,6 +70,15 @@ func validate(jt JumpTable) JumpTable {
 	return jt
 }
 
+// newCancunInstructionSet returns the instructions that can be executed
+// after the Cancun fork.
+func newCancunInstructionSet() JumpTable {
+	instructionSet := newShanghaiInstructionSet()
+	enable1153(&instructionSet) // Transient storage opcodes
+	enable5656(&instructionSet) // MCOPY opcode
+	return validate(instructionSet)
+}
+
 // newFrontierInstructionSet returns the frontier instructions
 // that can be executed during the frontier phase.
 func newShanghaiInstructionSet() JumpTable {
@@ -38,7 +38,13 @@ type (
 )
 
 func (qrvm *QRVM) precompile(addr common.Address) (PrecompiledContract, bool) {
-	precompiles := PrecompiledContractsShanghai
+	var precompiles map[common.Address]PrecompiledContract
+	switch {
+	case qrvm.chainRules.IsCancun:
+		precompiles = PrecompiledContractsCancun
+	default:
+		precompiles = PrecompiledContractsShanghai
+	}
 	p, ok := precompiles[addr]
 	return p, ok
 }
@@ -53,7 +53,8 @@ type Config struct {
 func setDefaults(cfg *Config) {
 	if cfg.ChainConfig == nil {
 		cfg.ChainConfig = &params.ChainConfig{
-			ChainID: big.NewInt(1),
+			ChainID:    big.NewInt(1),
+			CancunTime: new(uint64),
 		}
 	}
 
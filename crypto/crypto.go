@@ -29,9 +29,9 @@ import (
 	"math/big"
 	"os"
 
-	walletmldsa87 "github.com/theQRL/go-qrllib/wallet/ml_dsa_87"
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/common/math"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
 	"github.com/theQRL/go-zond/rlp"
 	"golang.org/x/crypto/sha3"
 )
@@ -268,8 +268,8 @@ func GenerateKey() (*ecdsa.PrivateKey, error) {
 }
 
 // GenerateMLDSA87Key generates a new private key.
-func GenerateMLDSA87Key() (*walletmldsa87.Wallet, error) {
-	return walletmldsa87.NewWallet()
+func GenerateMLDSA87Key() (pqcrypto.Wallet, error) {
+	return pqcrypto.GenerateWalletKey()
 }
 
 // ValidateSignatureValues verifies whether the signature values are valid with
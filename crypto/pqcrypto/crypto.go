@@ -9,10 +9,11 @@ import (
 	"os"
 
 	cryptomldsa87 "github.com/theQRL/go-qrllib/crypto/ml_dsa_87"
+	cryptosphincsplus256s "github.com/theQRL/go-qrllib/crypto/sphincsplus_256s"
 	"github.com/theQRL/go-qrllib/wallet"
 	walletcommon "github.com/theQRL/go-qrllib/wallet/common"
 	"github.com/theQRL/go-qrllib/wallet/common/descriptor"
-	walletmldsa87 "github.com/theQRL/go-qrllib/wallet/ml_dsa_87"
+	"github.com/theQRL/go-qrllib/wallet/common/wallettype"
 	"github.com/theQRL/go-zond/common"
 )
 
@@ -20,13 +21,18 @@ const MLDSA87SignatureLength = cryptomldsa87.CryptoBytes
 
 const MLDSA87PublicKeyLength = cryptomldsa87.CryptoPublicKeyBytes
 
+const SphincsPlus256sSignatureLength = cryptosphincsplus256s.CRYPTO_BYTES
+
+const SphincsPlus256sPublicKeyLength = cryptosphincsplus256s.CRYPTO_PUBLICKEYBYTES
+
 const DescriptorSize = descriptor.DescriptorSize
 
 // DigestLength sets the signature digest exact length
 const DigestLength = 32
 
-// LoadWallet loads ML-DSA-87 or SPHINCS+-256s Wallet from the given file having hex seed (not extended hex seed).
-func LoadWallet(file string) (*walletmldsa87.Wallet, error) {
+// LoadWallet loads ML-DSA-87 or SPHINCS+-256s Wallet from the given file having
+// either a hex seed, which is loaded as ML-DSA-87, or a hex extended seed.
+func LoadWallet(file string) (Wallet, error) {
 	fd, err := os.Open(file)
 	if err != nil {
 		return nil, err
@@ -34,22 +40,27 @@ func LoadWallet(file string) (*walletmldsa87.Wallet, error) {
 	defer fd.Close()
 
 	r := bufio.NewReader(fd)
-	buf := make([]byte, walletcommon.SeedSize*2)
+	buf := make([]byte, walletcommon.ExtendedSeedSize*2)
 	n, err := readASCII(buf, r)
-	if err != nil {
+	switch {
+	case err != nil:
 		return nil, err
-	} else if n != len(buf) {
+	case n == walletcommon.SeedSize*2, n == walletcommon.ExtendedSeedSize*2:
+	case n > walletcommon.SeedSize*2:
+		return nil, fmt.Errorf("invalid character %q at end of key file", buf[walletcommon.SeedSize*2])
+	default:
 		return nil, fmt.Errorf("key file too short, want %v hex characters", walletcommon.SeedSize*2)
 	}
 	if err := checkKeyFileEnd(r); err != nil {
 		return nil, err
 	}
 
-	return HexToWallet(string(buf))
+	return HexToWallet(string(buf[:n]))
 }
 
-func GenerateWalletKey() (*walletmldsa87.Wallet, error) {
-	return walletmldsa87.NewWallet()
+// GenerateWalletKey creates a new random ML-DSA-87 wallet.
+func GenerateWalletKey() (Wallet, error) {
+	return GenerateWallet(wallettype.ML_DSA_87)
 }
 
 // readASCII reads into 'buf', stopping when the buffer is full or
@@ -86,19 +97,33 @@ func checkKeyFileEnd(r *bufio.Reader) error {
 
 // ToWalletUnsafe blindly converts a binary blob to a private key. It should almost
 // never be used unless you are sure the input is valid and want to avoid hitting
-// errors due to bad origin encoding (0 prefixes cut off).
-func ToWalletUnsafe(seed []byte) *walletmldsa87.Wallet {
-	var sizedSeed [walletcommon.SeedSize]uint8
+// errors due to bad origin encoding (0 prefixes cut off). Blobs of extended seed
+// size are loaded with the scheme of their descriptor, anything else as an
+// ML-DSA-87 seed.
+func ToWalletUnsafe(seed []byte) Wallet {
+	if len(seed) == walletcommon.ExtendedSeedSize {
+		extendedSeed, err := walletcommon.NewExtendedSeedFromBytes(seed)
+		if err != nil {
+			return nil
+		}
+		w, err := WalletFromExtendedSeed(extendedSeed)
+		if err != nil {
+			return nil
+		}
+		return w
+	}
+	var sizedSeed walletcommon.Seed
 	copy(sizedSeed[:], seed)
-	d, err := walletmldsa87.NewWalletFromSeed(sizedSeed)
+	w, err := WalletFromSeed(wallettype.ML_DSA_87, sizedSeed)
 	if err != nil {
 		return nil
 	}
-	return d
+	return w
 }
 
-// HexToWallet parses a hex seed (not extended hex seed).
-func HexToWallet(hexSeedStr string) (*walletmldsa87.Wallet, error) {
+// HexToWallet parses a hex seed, which is loaded as ML-DSA-87, or a hex
+// extended seed, which is loaded with the scheme of its descriptor.
+func HexToWallet(hexSeedStr string) (Wallet, error) {
 	b, err := hex.DecodeString(hexSeedStr)
 	if byteErr, ok := err.(hex.InvalidByteError); ok {
 		return nil, fmt.Errorf("invalid hex character %q in seed", byte(byteErr))
@@ -106,10 +131,17 @@ func HexToWallet(hexSeedStr string) (*walletmldsa87.Wallet, error) {
 		return nil, errors.New("invalid hex data for seed")
 	}
 
-	var hexSeed [walletcommon.SeedSize]uint8
+	if len(b) == walletcommon.ExtendedSeedSize {
+		extendedSeed, err := walletcommon.NewExtendedSeedFromBytes(b)
+		if err != nil {
+			return nil, err
+		}
+		return WalletFromExtendedSeed(extendedSeed)
+	}
+	var hexSeed walletcommon.Seed
 	copy(hexSeed[:], b)
 
-	return walletmldsa87.NewWalletFromSeed(hexSeed)
+	return WalletFromSeed(wallettype.ML_DSA_87, hexSeed)
 }
 
 func PKToAddress(pk []byte, d descriptor.Descriptor) (common.Address, error) {
@@ -3,16 +3,47 @@ package pqcrypto
 import (
 	"fmt"
 
+	"github.com/theQRL/go-qrllib/wallet/common/descriptor"
+	"github.com/theQRL/go-qrllib/wallet/common/wallettype"
 	walletmldsa87 "github.com/theQRL/go-qrllib/wallet/ml_dsa_87"
+	walletsphincsplus256s "github.com/theQRL/go-qrllib/wallet/sphincsplus_256s"
 )
 
-func Sign(digestHash []byte, w *walletmldsa87.Wallet) ([]byte, error) {
+// Sign signs the given digest with the wallet, using the wallet's scheme.
+func Sign(digestHash []byte, w Wallet) ([]byte, error) {
 	if len(digestHash) != DigestLength {
 		return nil, fmt.Errorf("hash is required to be exactly %d bytes (%d)", DigestLength, len(digestHash))
 	}
-	signature, err := w.Sign(digestHash)
-	if err != nil {
-		return nil, err
+	return w.Sign(digestHash)
+}
+
+// Verify checks that the given signature over digestHash was produced by the
+// owner of the given public key, using the scheme selected by the descriptor.
+// Malformed inputs are reported as invalid signatures instead of panicking.
+func Verify(digestHash, signature, publicKey []byte, desc descriptor.Descriptor) bool {
+	if len(digestHash) != DigestLength {
+		return false
+	}
+	switch wallettype.WalletType(desc.Type()) {
+	case wallettype.ML_DSA_87:
+		if len(signature) != MLDSA87SignatureLength || len(publicKey) != MLDSA87PublicKeyLength {
+			return false
+		}
+		pk, err := walletmldsa87.BytesToPK(publicKey)
+		if err != nil {
+			return false
+		}
+		return walletmldsa87.Verify(digestHash, signature, &pk, desc)
+	case wallettype.SPHINCSPLUS_256S:
+		if len(signature) != SphincsPlus256sSignatureLength || len(publicKey) != SphincsPlus256sPublicKeyLength {
+			return false
+		}
+		pk, err := walletsphincsplus256s.BytesToPK(publicKey)
+		if err != nil {
+			return false
+		}
+		return walletsphincsplus256s.Verify(digestHash, signature, &pk, desc)
+	default:
+		return false
 	}
-	return signature[:], nil
 }
@@ -0,0 +1,177 @@
+package pqcrypto
+
+import (
+	"fmt"
+
+	walletcommon "github.com/theQRL/go-qrllib/wallet/common"
+	"github.com/theQRL/go-qrllib/wallet/common/descriptor"
+	"github.com/theQRL/go-qrllib/wallet/common/wallettype"
+	walletmldsa87 "github.com/theQRL/go-qrllib/wallet/ml_dsa_87"
+	walletsphincsplus256s "github.com/theQRL/go-qrllib/wallet/sphincsplus_256s"
+	"github.com/theQRL/go-zond/common"
+)
+
+// Wallet is a signing key of any of the signature schemes supported by
+// go-qrllib. The scheme in use is identified by the wallet's descriptor.
+type Wallet interface {
+	// Sign signs the given message, returning a signature whose length
+	// depends on the wallet's scheme.
+	Sign(message []byte) ([]byte, error)
+
+	// GetPK returns the public key of the wallet.
+	GetPK() []byte
+
+	// GetDescriptor returns the descriptor identifying the signature scheme.
+	GetDescriptor() descriptor.Descriptor
+
+	// GetAddress returns the address derived from the public key and descriptor.
+	GetAddress() common.Address
+
+	// GetSeed returns the seed the wallet was derived from.
+	GetSeed() walletcommon.Seed
+
+	// GetExtendedSeed returns the descriptor prefixed seed of the wallet.
+	GetExtendedSeed() walletcommon.ExtendedSeed
+
+	// GetMnemonic returns the mnemonic encoding of the extended seed.
+	GetMnemonic() string
+}
+
+// mldsa87Wallet adapts an ML-DSA-87 wallet to the Wallet interface.
+type mldsa87Wallet struct {
+	w *walletmldsa87.Wallet
+}
+
+func (w *mldsa87Wallet) Sign(message []byte) ([]byte, error) {
+	sig, err := w.w.Sign(message)
+	if err != nil {
+		return nil, err
+	}
+	return sig[:], nil
+}
+
+func (w *mldsa87Wallet) GetPK() []byte {
+	pk := w.w.GetPK()
+	return pk[:]
+}
+
+func (w *mldsa87Wallet) GetDescriptor() descriptor.Descriptor {
+	return w.w.GetDescriptor().ToDescriptor()
+}
+func (w *mldsa87Wallet) GetAddress() common.Address { return w.w.GetAddress() }
+func (w *mldsa87Wallet) GetSeed() walletcommon.Seed { return w.w.GetSeed() }
+func (w *mldsa87Wallet) GetExtendedSeed() walletcommon.ExtendedSeed {
+	return w.w.GetExtendedSeed()
+}
+func (w *mldsa87Wallet) GetMnemonic() string { return w.w.GetMnemonic() }
+
+// sphincsPlus256sWallet adapts a SPHINCS+-256s wallet to the Wallet interface.
+type sphincsPlus256sWallet struct {
+	w *walletsphincsplus256s.Wallet
+}
+
+func (w *sphincsPlus256sWallet) Sign(message []byte) ([]byte, error) {
+	sig, err := w.w.Sign(message)
+	if err != nil {
+		return nil, err
+	}
+	return sig[:], nil
+}
+
+func (w *sphincsPlus256sWallet) GetPK() []byte {
+	pk := w.w.GetPK()
+	return pk[:]
+}
+
+func (w *sphincsPlus256sWallet) GetDescriptor() descriptor.Descriptor {
+	return w.w.GetDescriptor().ToDescriptor()
+}
+func (w *sphincsPlus256sWallet) GetAddress() common.Address { return w.w.GetAddress() }
+func (w *sphincsPlus256sWallet) GetSeed() walletcommon.Seed { return w.w.GetSeed() }
+func (w *sphincsPlus256sWallet) GetExtendedSeed() walletcommon.ExtendedSeed {
+	return w.w.GetExtendedSeed()
+}
+func (w *sphincsPlus256sWallet) GetMnemonic() string { return w.w.GetMnemonic() }
+
+// NewMLDSA87Wallet wraps an ML-DSA-87 wallet into a scheme agnostic Wallet.
+func NewMLDSA87Wallet(w *walletmldsa87.Wallet) Wallet {
+	return &mldsa87Wallet{w}
+}
+
+// NewSphincsPlus256sWallet wraps a SPHINCS+-256s wallet into a scheme agnostic Wallet.
+func NewSphincsPlus256sWallet(w *walletsphincsplus256s.Wallet) Wallet {
+	return &sphincsPlus256sWallet{w}
+}
+
+// GenerateWallet creates a new random wallet of the given scheme.
+func GenerateWallet(walletType wallettype.WalletType) (Wallet, error) {
+	switch walletType {
+	case wallettype.ML_DSA_87:
+		w, err := walletmldsa87.NewWallet()
+		if err != nil {
+			return nil, err
+		}
+		return NewMLDSA87Wallet(w), nil
+	case wallettype.SPHINCSPLUS_256S:
+		w, err := walletsphincsplus256s.NewWallet()
+		if err != nil {
+			return nil, err
+		}
+		return NewSphincsPlus256sWallet(w), nil
+	default:
+		return nil, fmt.Errorf("unsupported wallet type %d", walletType)
+	}
+}
+
+// WalletFromSeed creates a wallet of the given scheme from a seed.
+func WalletFromSeed(walletType wallettype.WalletType, seed walletcommon.Seed) (Wallet, error) {
+	switch walletType {
+	case wallettype.ML_DSA_87:
+		w, err := walletmldsa87.NewWalletFromSeed(seed)
+		if err != nil {
+			return nil, err
+		}
+		return NewMLDSA87Wallet(w), nil
+	case wallettype.SPHINCSPLUS_256S:
+		w, err := walletsphincsplus256s.NewWalletFromSeed(seed)
+		if err != nil {
+			return nil, err
+		}
+		return NewSphincsPlus256sWallet(w), nil
+	default:
+		return nil, fmt.Errorf("unsupported wallet type %d", walletType)
+	}
+}
+
+// WalletFromExtendedSeed creates a wallet from an extended seed, selecting the
+// scheme from the descriptor it is prefixed with.
+func WalletFromExtendedSeed(extendedSeed walletcommon.ExtendedSeed) (Wallet, error) {
+	d := extendedSeed.GetDescriptorBytes()
+	return WalletFromSeed(wallettype.WalletType(d[0]), extendedSeed.GetSeed())
+}
+
+// SignatureLength returns the length of the signatures of the scheme selected
+// by the given descriptor, or zero if the scheme is not supported.
+func SignatureLength(desc descriptor.Descriptor) int {
+	switch wallettype.WalletType(desc.Type()) {
+	case wallettype.ML_DSA_87:
+		return MLDSA87SignatureLength
+	case wallettype.SPHINCSPLUS_256S:
+		return SphincsPlus256sSignatureLength
+	default:
+		return 0
+	}
+}
+
+// PublicKeyLength returns the length of the public keys of the scheme selected
+// by the given descriptor, or zero if the scheme is not supported.
+func PublicKeyLength(desc descriptor.Descriptor) int {
+	switch wallettype.WalletType(desc.Type()) {
+	case wallettype.ML_DSA_87:
+		return MLDSA87PublicKeyLength
+	case wallettype.SPHINCSPLUS_256S:
+		return SphincsPlus256sPublicKeyLength
+	default:
+		return 0
+	}
+}
@@ -19,6 +19,7 @@ package graphql
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -32,10 +33,12 @@ import (
 	"github.com/theQRL/go-zond/common/hexutil"
 	"github.com/theQRL/go-zond/common/math"
 	"github.com/theQRL/go-zond/consensus/misc/eip1559"
+	"github.com/theQRL/go-zond/core"
 	"github.com/theQRL/go-zond/core/state"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/internal/qrlapi"
 	"github.com/theQRL/go-zond/qrl/filters"
+	"github.com/theQRL/go-zond/qrl/tracers"
 	"github.com/theQRL/go-zond/rlp"
 	"github.com/theQRL/go-zond/rpc"
 )
@@ -44,6 +47,10 @@ var (
 	errBlockInvariant = errors.New("block objects must be instantiated with at least one of num or hash")
 )
 
+// chainEventChanSize is the size of the channels listening to chain events
+// on behalf of subscriptions.
+const chainEventChanSize = 10
+
 type Long int64
 
 // ImplementsGraphQLType returns true if Long implements the provided GraphQL type.
@@ -138,6 +145,99 @@ func (a *Account) Storage(ctx context.Context, args struct{ Slot common.Hash })
 	return state.GetState(a.address, args.Slot), nil
 }
 
+func (a *Account) Proof(ctx context.Context, args struct{ Slots *[]common.Hash }) (*AccountProof, error) {
+	var keys []string
+	if args.Slots != nil {
+		keys = make([]string, 0, len(*args.Slots))
+		for _, slot := range *args.Slots {
+			keys = append(keys, slot.Hex())
+		}
+	}
+	result, err := qrlapi.NewBlockChainAPI(a.r.backend).GetProof(ctx, a.address, keys, a.blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	accountProof, err := decodeProof(result.AccountProof)
+	if err != nil {
+		return nil, err
+	}
+	storageProof := make([]*StorageProof, 0, len(result.StorageProof))
+	for _, res := range result.StorageProof {
+		proof, err := decodeProof(res.Proof)
+		if err != nil {
+			return nil, err
+		}
+		storageProof = append(storageProof, &StorageProof{
+			key:   common.HexToHash(res.Key),
+			value: res.Value,
+			proof: proof,
+		})
+	}
+	return &AccountProof{
+		accountProof: accountProof,
+		codeHash:     result.CodeHash,
+		storageHash:  result.StorageHash,
+		storageProof: storageProof,
+	}, nil
+}
+
+// decodeProof converts a list of hex encoded trie nodes into their binary form.
+func decodeProof(nodes []string) ([]hexutil.Bytes, error) {
+	proof := make([]hexutil.Bytes, 0, len(nodes))
+	for _, node := range nodes {
+		blob, err := hexutil.Decode(node)
+		if err != nil {
+			return nil, err
+		}
+		proof = append(proof, blob)
+	}
+	return proof, nil
+}
+
+// AccountProof represents the Merkle proof of an account and some of its
+// storage slots.
+type AccountProof struct {
+	accountProof []hexutil.Bytes
+	codeHash     common.Hash
+	storageHash  common.Hash
+	storageProof []*StorageProof
+}
+
+func (p *AccountProof) AccountProof() []hexutil.Bytes {
+	return p.accountProof
+}
+
+func (p *AccountProof) CodeHash() common.Hash {
+	return p.codeHash
+}
+
+func (p *AccountProof) StorageHash() common.Hash {
+	return p.storageHash
+}
+
+func (p *AccountProof) StorageProof() []*StorageProof {
+	return p.storageProof
+}
+
+// StorageProof represents the Merkle proof of a single storage slot.
+type StorageProof struct {
+	key   common.Hash
+	value *hexutil.Big
+	proof []hexutil.Bytes
+}
+
+func (p *StorageProof) Key() common.Hash {
+	return p.key
+}
+
+func (p *StorageProof) Value() hexutil.Big {
+	return *p.value
+}
+
+func (p *StorageProof) Proof() []hexutil.Bytes {
+	return p.proof
+}
+
 // Log represents an individual log message. All arguments are mandatory.
 type Log struct {
 	r           *Resolver
@@ -307,7 +407,7 @@ func (t *Transaction) MaxFeePerGas(ctx context.Context) *hexutil.Big {
 		return nil
 	}
 	switch tx.Type() {
-	case types.DynamicFeeTxType:
+	case types.DynamicFeeTxType, types.AccountAbstractionTxType:
 		return (*hexutil.Big)(tx.GasFeeCap())
 	default:
 		return nil
@@ -320,7 +420,7 @@ func (t *Transaction) MaxPriorityFeePerGas(ctx context.Context) *hexutil.Big {
 		return nil
 	}
 	switch tx.Type() {
-	case types.DynamicFeeTxType:
+	case types.DynamicFeeTxType, types.AccountAbstractionTxType:
 		return (*hexutil.Big)(tx.GasTipCap())
 	default:
 		return nil
@@ -569,6 +669,122 @@ func (t *Transaction) RawReceipt(ctx context.Context) (hexutil.Bytes, error) {
 	return receipt.MarshalBinary()
 }
 
+func (t *Transaction) Trace(ctx context.Context) (*CallFrame, error) {
+	// Only mined transactions can be traced
+	tx, block := t.resolve(ctx)
+	if block == nil {
+		return nil, nil
+	}
+	if t.r.tracer == nil {
+		return nil, errors.New("tracing not supported by backend")
+	}
+	// Apply the same limits as to calls, as traces are requested over the
+	// open GraphQL endpoint: DoS protection
+	if gasCap := t.r.backend.RPCGasCap(); gasCap != 0 && tx.Gas() > gasCap {
+		return nil, fmt.Errorf("transaction gas %d exceeds the RPC gas cap %d", tx.Gas(), gasCap)
+	}
+	tracer := "callTracer"
+	config := &tracers.TraceConfig{Tracer: &tracer}
+	if timeout := t.r.backend.RPCQRVMTimeout(); timeout > 0 {
+		limit := timeout.String()
+		config.Timeout = &limit
+	}
+	result, err := t.r.tracer.TraceTransaction(ctx, t.hash, config)
+	if err != nil {
+		return nil, err
+	}
+	blob, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	frame := new(callTrace)
+	if err := json.Unmarshal(blob, frame); err != nil {
+		return nil, err
+	}
+	return &CallFrame{frame}, nil
+}
+
+// callTrace is the JSON representation of a call frame reported by the native
+// call tracer.
+type callTrace struct {
+	Type         string          `json:"type"`
+	From         common.Address  `json:"from"`
+	To           *common.Address `json:"to"`
+	Value        *hexutil.Big    `json:"value"`
+	Gas          hexutil.Uint64  `json:"gas"`
+	GasUsed      hexutil.Uint64  `json:"gasUsed"`
+	Input        hexutil.Bytes   `json:"input"`
+	Output       hexutil.Bytes   `json:"output"`
+	Error        string          `json:"error"`
+	RevertReason string          `json:"revertReason"`
+	Calls        []*callTrace    `json:"calls"`
+}
+
+// CallFrame represents a single call made while executing a transaction.
+type CallFrame struct {
+	frame *callTrace
+}
+
+func (c *CallFrame) Type() string {
+	return c.frame.Type
+}
+
+func (c *CallFrame) From() common.Address {
+	return c.frame.From
+}
+
+func (c *CallFrame) To() *common.Address {
+	return c.frame.To
+}
+
+func (c *CallFrame) Value() *hexutil.Big {
+	return c.frame.Value
+}
+
+func (c *CallFrame) Gas() hexutil.Uint64 {
+	return c.frame.Gas
+}
+
+func (c *CallFrame) GasUsed() hexutil.Uint64 {
+	return c.frame.GasUsed
+}
+
+func (c *CallFrame) Input() hexutil.Bytes {
+	return c.frame.Input
+}
+
+func (c *CallFrame) Output() *hexutil.Bytes {
+	if len(c.frame.Output) == 0 {
+		return nil
+	}
+	return &c.frame.Output
+}
+
+func (c *CallFrame) Error() *string {
+	if c.frame.Error == "" {
+		return nil
+	}
+	return &c.frame.Error
+}
+
+func (c *CallFrame) RevertReason() *string {
+	if c.frame.RevertReason == "" {
+		return nil
+	}
+	return &c.frame.RevertReason
+}
+
+func (c *CallFrame) Calls() *[]*CallFrame {
+	if len(c.frame.Calls) == 0 {
+		return nil
+	}
+	calls := make([]*CallFrame, 0, len(c.frame.Calls))
+	for _, frame := range c.frame.Calls {
+		calls = append(calls, &CallFrame{frame})
+	}
+	return &calls
+}
+
 type BlockType int
 
 // Block represents a QRL block.
@@ -987,6 +1203,65 @@ type CallData struct {
 	Data                 *hexutil.Bytes  // Any data sent with the call.
 }
 
+// StateOverride encapsulates the fields of an account to override before
+// executing a `call` or `estimateGas`.
+type StateOverride struct {
+	Address   common.Address // The QRL address of the account to override.
+	Nonce     *Long          // The nonce to set for the account.
+	Code      *hexutil.Bytes // The code to set for the account.
+	Balance   *hexutil.Big   // The balance to set for the account, in planck.
+	State     *[]StorageSlot // The storage to replace the entire storage of the account with.
+	StateDiff *[]StorageSlot // The storage slots to replace in the account.
+}
+
+// StorageSlot is the value of a single storage slot.
+type StorageSlot struct {
+	Slot  common.Hash
+	Value common.Hash
+}
+
+// toStateOverride converts the overrides of a `call` or `estimateGas` into
+// their internal representation.
+func toStateOverride(overrides *[]StateOverride) (*qrlapi.StateOverride, error) {
+	if overrides == nil {
+		return nil, nil
+	}
+	diff := make(qrlapi.StateOverride, len(*overrides))
+	for _, override := range *overrides {
+		if _, ok := diff[override.Address]; ok {
+			return nil, fmt.Errorf("account %v overridden multiple times", override.Address)
+		}
+		var account qrlapi.OverrideAccount
+		if override.Nonce != nil {
+			nonce := hexutil.Uint64(*override.Nonce)
+			account.Nonce = &nonce
+		}
+		account.Code = override.Code
+		if override.Balance != nil {
+			account.Balance = &override.Balance
+		}
+		if override.State != nil {
+			state := toStorage(*override.State)
+			account.State = &state
+		}
+		if override.StateDiff != nil {
+			state := toStorage(*override.StateDiff)
+			account.StateDiff = &state
+		}
+		diff[override.Address] = account
+	}
+	return &diff, nil
+}
+
+// toStorage converts a list of storage slots into a storage map.
+func toStorage(slots []StorageSlot) map[common.Hash]common.Hash {
+	storage := make(map[common.Hash]common.Hash, len(slots))
+	for _, slot := range slots {
+		storage[slot.Slot] = slot.Value
+	}
+	return storage
+}
+
 // CallResult encapsulates the result of an invocation of the `call` accessor.
 type CallResult struct {
 	data    hexutil.Bytes  // The return data from the call
@@ -1007,9 +1282,14 @@ func (c *CallResult) Status() hexutil.Uint64 {
 }
 
 func (b *Block) Call(ctx context.Context, args struct {
-	Data qrlapi.TransactionArgs
+	Data      qrlapi.TransactionArgs
+	Overrides *[]StateOverride
 }) (*CallResult, error) {
-	result, err := qrlapi.DoCall(ctx, b.r.backend, args.Data, *b.numberOrHash, nil, nil, b.r.backend.RPCQRVMTimeout(), b.r.backend.RPCGasCap())
+	overrides, err := toStateOverride(args.Overrides)
+	if err != nil {
+		return nil, err
+	}
+	result, err := qrlapi.DoCall(ctx, b.r.backend, args.Data, *b.numberOrHash, overrides, nil, b.r.backend.RPCQRVMTimeout(), b.r.backend.RPCGasCap())
 	if err != nil {
 		return nil, err
 	}
@@ -1026,9 +1306,14 @@ func (b *Block) Call(ctx context.Context, args struct {
 }
 
 func (b *Block) EstimateGas(ctx context.Context, args struct {
-	Data qrlapi.TransactionArgs
+	Data      qrlapi.TransactionArgs
+	Overrides *[]StateOverride
 }) (hexutil.Uint64, error) {
-	return qrlapi.DoEstimateGas(ctx, b.r.backend, args.Data, *b.numberOrHash, nil, b.r.backend.RPCGasCap())
+	overrides, err := toStateOverride(args.Overrides)
+	if err != nil {
+		return 0, err
+	}
+	return qrlapi.DoEstimateGas(ctx, b.r.backend, args.Data, *b.numberOrHash, overrides, b.r.backend.RPCGasCap())
 }
 
 type Pending struct {
@@ -1069,10 +1354,15 @@ func (p *Pending) Account(ctx context.Context, args struct {
 }
 
 func (p *Pending) Call(ctx context.Context, args struct {
-	Data qrlapi.TransactionArgs
+	Data      qrlapi.TransactionArgs
+	Overrides *[]StateOverride
 }) (*CallResult, error) {
+	overrides, err := toStateOverride(args.Overrides)
+	if err != nil {
+		return nil, err
+	}
 	pendingBlockNr := rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber)
-	result, err := qrlapi.DoCall(ctx, p.r.backend, args.Data, pendingBlockNr, nil, nil, p.r.backend.RPCQRVMTimeout(), p.r.backend.RPCGasCap())
+	result, err := qrlapi.DoCall(ctx, p.r.backend, args.Data, pendingBlockNr, overrides, nil, p.r.backend.RPCQRVMTimeout(), p.r.backend.RPCGasCap())
 	if err != nil {
 		return nil, err
 	}
@@ -1089,16 +1379,53 @@ func (p *Pending) Call(ctx context.Context, args struct {
 }
 
 func (p *Pending) EstimateGas(ctx context.Context, args struct {
-	Data qrlapi.TransactionArgs
+	Data      qrlapi.TransactionArgs
+	Overrides *[]StateOverride
 }) (hexutil.Uint64, error) {
+	overrides, err := toStateOverride(args.Overrides)
+	if err != nil {
+		return 0, err
+	}
 	latestBlockNr := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
-	return qrlapi.DoEstimateGas(ctx, p.r.backend, args.Data, latestBlockNr, nil, p.r.backend.RPCGasCap())
+	return qrlapi.DoEstimateGas(ctx, p.r.backend, args.Data, latestBlockNr, overrides, p.r.backend.RPCGasCap())
+}
+
+// TxPoolContent represents the transactions of a single account in the
+// transaction pool.
+type TxPoolContent struct {
+	r       *Resolver
+	pending []*types.Transaction
+	queued  []*types.Transaction
+}
+
+func (c *TxPoolContent) Pending(ctx context.Context) []*Transaction {
+	return c.wrap(c.pending)
+}
+
+func (c *TxPoolContent) Queued(ctx context.Context) []*Transaction {
+	return c.wrap(c.queued)
+}
+
+func (c *TxPoolContent) wrap(txs []*types.Transaction) []*Transaction {
+	ret := make([]*Transaction, 0, len(txs))
+	for _, tx := range txs {
+		ret = append(ret, &Transaction{
+			r:    c.r,
+			hash: tx.Hash(),
+			tx:   tx,
+		})
+	}
+	return ret
 }
 
 // Resolver is the top-level object in the GraphQL hierarchy.
 type Resolver struct {
 	backend      qrlapi.Backend
 	filterSystem *filters.FilterSystem
+	tracer       *tracers.API // Call tracer, nil if the backend doesn't support tracing
+
+	events     *filters.EventSystem // Log event system, created on the first log subscription
+	eventsOnce sync.Once
 }
 
 func (r *Resolver) Block(ctx context.Context, args struct {
@@ -1245,6 +1572,83 @@ func (r *Resolver) Logs(ctx context.Context, args struct{ Filter FilterCriteria
 	return runFilter(ctx, r, filter)
 }
 
+func (r *Resolver) NewHeads(ctx context.Context) (<-chan *Block, error) {
+	var (
+		events = make(chan core.ChainEvent, chainEventChanSize)
+		sub    = r.backend.SubscribeChainEvent(events)
+		blocks = make(chan *Block)
+	)
+	go func() {
+		defer close(blocks)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-events:
+				numberOrHash := rpc.BlockNumberOrHashWithHash(ev.Hash, false)
+				block := &Block{
+					r:            r,
+					numberOrHash: &numberOrHash,
+					hash:         ev.Hash,
+					header:       ev.Block.Header(),
+					block:        ev.Block,
+				}
+				select {
+				case blocks <- block:
+				case <-ctx.Done():
+					return
+				}
+			case <-sub.Err():
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return blocks, nil
+}
+
+func (r *Resolver) NewLogs(ctx context.Context, args struct{ Filter BlockFilterCriteria }) (<-chan *Log, error) {
+	var crit qrl.FilterQuery
+	if args.Filter.Addresses != nil {
+		crit.Addresses = *args.Filter.Addresses
+	}
+	if args.Filter.Topics != nil {
+		crit.Topics = *args.Filter.Topics
+	}
+	r.eventsOnce.Do(func() {
+		r.events = filters.NewEventSystem(r.filterSystem)
+	})
+	matches := make(chan []*types.Log, chainEventChanSize)
+	sub, err := r.events.SubscribeLogs(crit, matches)
+	if err != nil {
+		return nil, err
+	}
+	logs := make(chan *Log)
+	go func() {
+		defer close(logs)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case batch := <-matches:
+				for _, log := range batch {
+					select {
+					case logs <- &Log{r: r, transaction: &Transaction{r: r, hash: log.TxHash}, log: log}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			case <-sub.Err():
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return logs, nil
+}
+
 func (r *Resolver) GasPrice(ctx context.Context) (hexutil.Big, error) {
 	tipcap, err := r.backend.SuggestGasTipCap(ctx)
 	if err != nil {
@@ -1268,6 +1672,15 @@ func (r *Resolver) ChainID(ctx context.Context) (hexutil.Big, error) {
 	return hexutil.Big(*r.backend.ChainConfig().ChainID), nil
 }
 
+func (r *Resolver) TxPoolContent(ctx context.Context, args struct{ Address common.Address }) *TxPoolContent {
+	pending, queued := r.backend.TxPoolContentFrom(args.Address)
+	return &TxPoolContent{
+		r:       r,
+		pending: pending,
+		queued:  queued,
+	}
+}
+
 // SyncState represents the synchronisation status returned from the `syncing` accessor.
 type SyncState struct {
 	progress qrl.SyncProgress
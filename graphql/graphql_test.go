@@ -40,7 +40,10 @@ import (
 	"github.com/theQRL/go-zond/qrl"
 	"github.com/theQRL/go-zond/qrl/filters"
 	"github.com/theQRL/go-zond/qrl/qrlconfig"
+	"github.com/theQRL/go-zond/qrl/tracers"
 
+	"github.com/gorilla/websocket"
+	"github.com/graph-gophers/graphql-go"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -291,7 +294,7 @@ func TestGraphQLConcurrentResolvers(t *testing.T) {
 	defer stack.Close()
 
 	var tx *types.Transaction
-	handler, chain := newGQLService(t, stack, genesis, 1, func(i int, gen *core.BlockGen) {
+	handler, chain, _ := newGQLService(t, stack, genesis, 1, func(i int, gen *core.BlockGen) {
 		tx, _ = types.SignNewTx(key, signer, &types.DynamicFeeTx{To: &dad, Gas: 100000, GasFeeCap: big.NewInt(params.InitialBaseFee)})
 		gen.AddTx(tx)
 		tx, _ = types.SignNewTx(key, signer, &types.DynamicFeeTx{To: &dad, Nonce: 1, Gas: 100000, GasFeeCap: big.NewInt(params.InitialBaseFee)})
@@ -376,7 +379,7 @@ func TestWithdrawals(t *testing.T) {
 	)
 	defer stack.Close()
 
-	handler, _ := newGQLService(t, stack, genesis, 1, func(i int, gen *core.BlockGen) {
+	handler, _, _ := newGQLService(t, stack, genesis, 1, func(i int, gen *core.BlockGen) {
 		tx, _ := types.SignNewTx(key, signer, &types.DynamicFeeTx{To: &common.Address{}, Gas: 100000, GasFeeCap: big.NewInt(params.InitialBaseFee)})
 		gen.AddTx(tx)
 		gen.AddWithdrawal(&types.Withdrawal{
@@ -417,6 +420,175 @@ func TestWithdrawals(t *testing.T) {
 	}
 }
 
+func TestGraphQLTracesOverridesAndProofs(t *testing.T) {
+	var (
+		key, _  = crypto.GenerateMLDSA87Key()
+		addr    = key.GetAddress()
+		dadStr  = "Q0000000000000000000000000000000000000dad"
+		dad, _  = common.NewAddressFromString(dadStr)
+		beefStr = "Q000000000000000000000000000000000000beef"
+		codeStr = "Q000000000000000000000000000000000000c0de"
+		genesis = &core.Genesis{
+			Config:   params.AllBeaconProtocolChanges,
+			GasLimit: 11500000,
+			Alloc: core.GenesisAlloc{
+				addr: {Balance: big.NewInt(params.Quanta)},
+				dad: {
+					// CALL(0xffff, 0xbeef, 0, 0, 0, 0, 0), STOP
+					Code:    common.Hex2Bytes("6000600060006000600073000000000000000000000000000000000000beef61fffff100"),
+					Storage: map[common.Hash]common.Hash{{}: common.HexToHash("0x2a")},
+				},
+			},
+		}
+		signer = types.LatestSigner(genesis.Config)
+		stack  = createNode(t)
+	)
+	defer stack.Close()
+
+	var tx *types.Transaction
+	handler, _, backend := newGQLService(t, stack, genesis, 1, func(i int, gen *core.BlockGen) {
+		tx, _ = types.SignNewTx(key, signer, &types.DynamicFeeTx{To: &dad, Gas: 100000, GasFeeCap: big.NewInt(params.InitialBaseFee)})
+		gen.AddTx(tx)
+	})
+	queued, _ := types.SignNewTx(key, signer, &types.DynamicFeeTx{To: &dad, Nonce: 5, Gas: 100000, GasFeeCap: big.NewInt(params.InitialBaseFee)})
+	raw, _ := queued.MarshalBinary()
+
+	for i, tt := range []struct {
+		body string
+		want string
+	}{
+		// Call traces of mined transactions
+		{
+			body: fmt.Sprintf(`{ transaction(hash: "%s") { trace { type to calls { type from to calls { type } } } } }`, tx.Hash()),
+			want: fmt.Sprintf(`{"transaction":{"trace":{"type":"CALL","to":"%s","calls":[{"type":"CALL","from":"%s","to":"%s","calls":null}]}}}`, dadStr, dadStr, beefStr),
+		},
+		// Calls against overridden accounts: SLOAD(0), MSTORE(0), RETURN(0, 32)
+		{
+			body: fmt.Sprintf(`{ block { call(data: {to: "%s"}, overrides: [{address: "%s", code: "0x60005460005260206000f3", stateDiff: [{slot: "0x0000000000000000000000000000000000000000000000000000000000000000", value: "0x0000000000000000000000000000000000000000000000000000000000000007"}]}]) { data status } } }`, codeStr, codeStr),
+			want: `{"block":{"call":{"data":"0x0000000000000000000000000000000000000000000000000000000000000007","status":"0x1"}}}`,
+		},
+		{
+			body: fmt.Sprintf(`{ pending { call(data: {to: "%s"}, overrides: [{address: "%s", code: "0x60005460005260206000f3"}]) { data } } }`, codeStr, codeStr),
+			want: `{"pending":{"call":{"data":"0x0000000000000000000000000000000000000000000000000000000000000000"}}}`,
+		},
+		// Merkle proofs of accounts and their storage
+		{
+			body: fmt.Sprintf(`{ block { account(address: "%s") { proof(slots: ["0x0000000000000000000000000000000000000000000000000000000000000000"]) { codeHash storageProof { key value } } } } }`, dadStr),
+			want: fmt.Sprintf(`{"block":{"account":{"proof":{"codeHash":"%s","storageProof":[{"key":"0x0000000000000000000000000000000000000000000000000000000000000000","value":"0x2a"}]}}}}`, crypto.Keccak256Hash(genesis.Alloc[dad].Code)),
+		},
+		// Transaction pool content of an account
+		{
+			body: fmt.Sprintf(`mutation { sendRawTransaction(data: "%#x") }`, raw),
+			want: fmt.Sprintf(`{"sendRawTransaction":"%s"}`, queued.Hash()),
+		},
+		{
+			body: fmt.Sprintf(`{ txPoolContent(address: "%s") { pending { hash } queued { hash nonce } } }`, addr),
+			want: fmt.Sprintf(`{"txPoolContent":{"pending":[],"queued":[{"hash":"%s","nonce":"0x5"}]}}`, queued.Hash()),
+		},
+	} {
+		res := handler.Schema.Exec(context.Background(), tt.body, "", map[string]interface{}{})
+		if res.Errors != nil {
+			t.Fatalf("failed to execute query for testcase #%d: %v", i, res.Errors)
+		}
+		have, err := json.Marshal(res.Data)
+		if err != nil {
+			t.Fatalf("failed to encode graphql response for testcase #%d: %s", i, err)
+		}
+		if string(have) != tt.want {
+			t.Errorf("response unmatch for testcase #%d.\nhave:\n%s\nwant:\n%s", i, have, tt.want)
+		}
+	}
+	// Duplicate overrides of an account are rejected
+	res := handler.Schema.Exec(context.Background(), fmt.Sprintf(`{ block { estimateGas(data: {to: "%s"}, overrides: [{address: "%s"}, {address: "%s"}]) } }`, codeStr, codeStr, codeStr), "", map[string]interface{}{})
+	if res.Errors == nil {
+		t.Fatalf("duplicate overrides accepted")
+	}
+	// Transactions above the RPC gas cap are not traced
+	capped := &gasCapBackend{QRLAPIBackend: backend.APIBackend, gasCap: tx.Gas() - 1}
+	cappedSchema, err := graphql.ParseSchema(schema, &Resolver{backend: capped, tracer: tracers.NewAPI(capped)})
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	res = cappedSchema.Exec(context.Background(), fmt.Sprintf(`{ transaction(hash: "%s") { trace { type } } }`, tx.Hash()), "", map[string]interface{}{})
+	if res.Errors == nil {
+		t.Fatalf("transaction above the gas cap traced")
+	}
+}
+
+// gasCapBackend overrides the RPC gas cap of the backend it wraps.
+type gasCapBackend struct {
+	*qrl.QRLAPIBackend
+	gasCap uint64
+}
+
+func (b *gasCapBackend) RPCGasCap() uint64 { return b.gasCap }
+
+// Tests that new heads are delivered to subscribers over websockets.
+func TestGraphQLSubscriptions(t *testing.T) {
+	stack := createNode(t)
+	defer stack.Close()
+
+	genesis := &core.Genesis{
+		Config:   params.AllBeaconProtocolChanges,
+		GasLimit: 11500000,
+	}
+	_, chain, backend := newGQLService(t, stack, genesis, 1, func(i int, gen *core.BlockGen) {})
+	if err := stack.Start(); err != nil {
+		t.Fatalf("could not start node: %v", err)
+	}
+	url := "ws" + strings.TrimPrefix(stack.HTTPEndpoint(), "http") + "/graphql"
+	dialer := websocket.Dialer{Subprotocols: []string{wsSubprotocol}}
+	conn, _, err := dialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("could not dial %s: %v", url, err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+
+	expect := func(typ string) *wsMessage {
+		t.Helper()
+		var msg wsMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Fatalf("failed to read %s message: %v", typ, err)
+		}
+		if msg.Type != typ {
+			t.Fatalf("message type mismatch: have %s, want %s (payload %s)", msg.Type, typ, msg.Payload)
+		}
+		return &msg
+	}
+	conn.WriteJSON(&wsMessage{Type: "connection_init"})
+	expect("connection_ack")
+
+	conn.WriteJSON(&wsMessage{ID: "1", Type: "subscribe", Payload: json.RawMessage(`{"query": "subscription { newHeads { number hash } }"}`)})
+	conn.WriteJSON(&wsMessage{Type: "ping"})
+	expect("pong")
+
+	blocks, _ := core.GenerateChain(params.AllBeaconProtocolChanges, chain[len(chain)-1], beacon.NewFaker(), backend.ChainDb(), 1, func(i int, gen *core.BlockGen) {})
+	if _, err := backend.BlockChain().InsertChain(blocks); err != nil {
+		t.Fatalf("could not import blocks: %v", err)
+	}
+	msg := expect("next")
+	if msg.ID != "1" {
+		t.Fatalf("subscription id mismatch: have %s, want 1", msg.ID)
+	}
+	want := fmt.Sprintf(`{"data":{"newHeads":{"number":"0x2","hash":"%s"}}}`, blocks[0].Hash())
+	if string(msg.Payload) != want {
+		t.Fatalf("payload mismatch:\nhave: %s\nwant: %s", msg.Payload, want)
+	}
+	// Queries are answered over the same connection
+	conn.WriteJSON(&wsMessage{ID: "2", Type: "subscribe", Payload: json.RawMessage(`{"query": "{ block { number } }"}`)})
+	if msg := expect("next"); string(msg.Payload) != `{"data":{"block":{"number":"0x2"}}}` {
+		t.Fatalf("query payload mismatch: %s", msg.Payload)
+	}
+	expect("complete")
+
+	// Reusing a running subscription id terminates the connection
+	conn.WriteJSON(&wsMessage{ID: "1", Type: "subscribe", Payload: json.RawMessage(`{"query": "subscription { newHeads { number } }"}`)})
+	if _, _, err := conn.ReadMessage(); !websocket.IsCloseError(err, wsCloseDuplicateID) {
+		t.Fatalf("unexpected close error: %v", err)
+	}
+}
+
 func createNode(t *testing.T) *node.Node {
 	stack, err := node.New(&node.Config{
 		HTTPHost:     "127.0.0.1",
@@ -431,7 +603,7 @@ func createNode(t *testing.T) *node.Node {
 	return stack
 }
 
-func newGQLService(t *testing.T, stack *node.Node, gspec *core.Genesis, genBlocks int, genfunc func(i int, gen *core.BlockGen)) (*handler, []*types.Block) {
+func newGQLService(t *testing.T, stack *node.Node, gspec *core.Genesis, genBlocks int, genfunc func(i int, gen *core.BlockGen)) (*handler, []*types.Block, *qrl.QRL) {
 	qrlConf := &qrlconfig.Config{
 		Genesis:        gspec,
 		NetworkId:      1337,
@@ -458,5 +630,5 @@ func newGQLService(t *testing.T, stack *node.Node, gspec *core.Genesis, genBlock
 	if err != nil {
 		t.Fatalf("could not create graphql service: %v", err)
 	}
-	return handler, chain
+	return handler, chain, qrlBackend
 }
@@ -36,6 +36,7 @@ const schema string = `
     schema {
         query: Query
         mutation: Mutation
+        subscription: Subscription
     }
 
     # Account is a QRL account at a particular block.
@@ -54,6 +55,33 @@ const schema string = `
         # Storage provides access to the storage of a contract account, indexed
         # by its 32 byte slot identifier.
         storage(slot: Bytes32!): Bytes32!
+        # Proof returns the Merkle proof of the account and of the given storage
+        # slots of the account.
+        proof(slots: [Bytes32!]): AccountProof!
+    }
+
+    # AccountProof is the Merkle proof of an account and some of its storage slots.
+    type AccountProof {
+        # AccountProof is the list of RLP encoded trie nodes on the path from the
+        # state root to the account.
+        accountProof: [Bytes!]!
+        # CodeHash is the hash of the code of the account.
+        codeHash: Bytes32!
+        # StorageHash is the root hash of the storage trie of the account.
+        storageHash: Bytes32!
+        # StorageProof is the list of Merkle proofs of the requested storage slots.
+        storageProof: [StorageProof!]!
+    }
+
+    # StorageProof is the Merkle proof of a single storage slot.
+    type StorageProof {
+        # Key is the storage slot the proof is for.
+        key: Bytes32!
+        # Value is the value held in the storage slot.
+        value: BigInt!
+        # Proof is the list of RLP encoded trie nodes on the path from the
+        # storage root to the slot.
+        proof: [Bytes!]!
     }
 
     # Log is a QRL event log.
@@ -152,6 +180,37 @@ const schema string = `
         # RawReceipt is the canonical encoding of the receipt: this is equivalent to 
         # TxType || ReceiptEncoding.
         rawReceipt: Bytes!
+        # Trace is the tree of calls made while executing this transaction, as
+        # reported by the call tracer. If the transaction has not yet been
+        # mined, this field will be null.
+        trace: CallFrame
+    }
+
+    # CallFrame is a single call made while executing a transaction, along with
+    # all the calls it made in turn.
+    type CallFrame {
+        # Type is the kind of the call, e.g. CALL, DELEGATECALL or CREATE.
+        type: String!
+        # From is the address making the call.
+        from: Address!
+        # To is the address the call is sent to.
+        to: Address
+        # Value is the value, in planck, sent along with the call.
+        value: BigInt
+        # Gas is the amount of gas provided for the call.
+        gas: Long!
+        # GasUsed is the amount of gas used by the call.
+        gasUsed: Long!
+        # Input is the data sent to the callee.
+        input: Bytes!
+        # Output is the data returned by the callee.
+        output: Bytes
+        # Error is the reason of the failure if the call failed.
+        error: String
+        # RevertReason is the decoded revert reason if the call reverted.
+        revertReason: String
+        # Calls is the list of calls made by this call.
+        calls: [CallFrame!]
     }
 
     # BlockFilterCriteria encapsulates log filter criteria for a filter applied
@@ -221,11 +280,13 @@ const schema string = `
         logs(filter: BlockFilterCriteria!): [Log!]!
         # Account fetches a QRL account at the current block's state.
         account(address: Address!): Account!
-        # Call executes a local call operation at the current block's state.
-        call(data: CallData!): CallResult
+        # Call executes a local call operation at the current block's state,
+        # with the given accounts overridden.
+        call(data: CallData!, overrides: [StateOverride!]): CallResult
         # EstimateGas estimates the amount of gas that will be required for
-        # successful execution of a transaction at the current block's state.
-        estimateGas(data: CallData!): Long!
+        # successful execution of a transaction at the current block's state,
+        # with the given accounts overridden.
+        estimateGas(data: CallData!, overrides: [StateOverride!]): Long!
         # RawHeader is the RLP encoding of the block's header.
         rawHeader: Bytes!
         # Raw is the RLP encoding of the block.
@@ -257,6 +318,30 @@ const schema string = `
         data: Bytes
     }
 
+    # StateOverride replaces some fields of an account before executing a call.
+    input StateOverride {
+        # Address is the account to override.
+        address: Address!
+        # Nonce overrides the nonce of the account.
+        nonce: Long
+        # Code overrides the code of the account.
+        code: Bytes
+        # Balance overrides the balance of the account, in planck.
+        balance: BigInt
+        # State replaces the entire storage of the account.
+        state: [StorageSlot!]
+        # StateDiff replaces individual storage slots of the account.
+        stateDiff: [StorageSlot!]
+    }
+
+    # StorageSlot is the value of a single storage slot.
+    input StorageSlot {
+        # Slot is the 32 byte identifier of the storage slot.
+        slot: Bytes32!
+        # Value is the value to place in the storage slot.
+        value: Bytes32!
+    }
+
     # CallResult is the result of a local call operation.
     type CallResult {
         # Data is the return data of the called contract.
@@ -310,11 +395,21 @@ const schema string = `
         transactions: [Transaction!]
         # Account fetches a QRL account for the pending state.
         account(address: Address!): Account!
-        # Call executes a local call operation for the pending state.
-        call(data: CallData!): CallResult
+        # Call executes a local call operation for the pending state, with
+        # the given accounts overridden.
+        call(data: CallData!, overrides: [StateOverride!]): CallResult
         # EstimateGas estimates the amount of gas that will be required for
-        # successful execution of a transaction for the pending state.
-        estimateGas(data: CallData!): Long!
+        # successful execution of a transaction for the pending state, with
+        # the given accounts overridden.
+        estimateGas(data: CallData!, overrides: [StateOverride!]): Long!
+    }
+
+    # TxPoolContent is the content of the transaction pool for a single account.
+    type TxPoolContent {
+        # Pending is the list of executable transactions, sorted by nonce.
+        pending: [Transaction!]!
+        # Queued is the list of non-executable transactions, sorted by nonce.
+        queued: [Transaction!]!
     }
     
     type Query {
@@ -340,10 +435,21 @@ const schema string = `
         syncing: SyncState
         # ChainID returns the current chain ID for transaction replay protection.
         chainID: BigInt!
+        # TxPoolContent returns the transactions of an account in the
+        # transaction pool.
+        txPoolContent(address: Address!): TxPoolContent!
     }
 
     type Mutation {
         # SendRawTransaction sends an RLP-encoded transaction to the network.
         sendRawTransaction(data: Bytes!): Bytes32!
     }
+
+    type Subscription {
+        # NewHeads delivers every block appended to the canonical chain.
+        newHeads: Block!
+        # NewLogs delivers every log matching the filter from the blocks
+        # appended to the canonical chain.
+        newLogs(filter: BlockFilterCriteria!): Log!
+    }
 `
@@ -29,6 +29,8 @@ import (
 	"github.com/theQRL/go-zond/internal/qrlapi"
 	"github.com/theQRL/go-zond/node"
 	"github.com/theQRL/go-zond/qrl/filters"
+	"github.com/theQRL/go-zond/qrl/tracers"
+	_ "github.com/theQRL/go-zond/qrl/tracers/native" // register the call tracer
 	"github.com/theQRL/go-zond/rpc"
 )
 
@@ -113,15 +115,31 @@ func New(stack *node.Node, backend qrlapi.Backend, filterSystem *filters.FilterS
 
 // newHandler returns a new `http.Handler` that will answer GraphQL queries.
 // It additionally exports an interactive query browser on the / endpoint.
+// Websocket upgrade requests are served by the subscription transport.
 func newHandler(stack *node.Node, backend qrlapi.Backend, filterSystem *filters.FilterSystem, cors, vhosts []string) (*handler, error) {
-	q := Resolver{backend, filterSystem}
-
+	q := Resolver{
+		backend:      backend,
+		filterSystem: filterSystem,
+	}
+	if backend, ok := backend.(tracers.Backend); ok {
+		q.tracer = tracers.NewAPI(backend)
+	}
 	s, err := graphql.ParseSchema(schema, &q)
 	if err != nil {
 		return nil, err
 	}
 	h := handler{Schema: s}
-	handler := node.NewHTTPHandlerStack(h, cors, vhosts, nil)
+	var (
+		httpHandler = node.NewHTTPHandlerStack(h, cors, vhosts, nil)
+		wsHandler   = node.NewWSHandlerStack(newWSHandler(s, cors), nil)
+	)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isWebsocket(r) {
+			wsHandler.ServeHTTP(w, r)
+			return
+		}
+		httpHandler.ServeHTTP(w, r)
+	})
 
 	stack.RegisterHandler("GraphQL UI", "/graphql/ui", GraphiQL{})
 	stack.RegisterHandler("GraphQL UI", "/graphql/ui/", GraphiQL{})
@@ -0,0 +1,323 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/theQRL/go-zond/log"
+)
+
+// wsSubprotocol is the websocket subprotocol spoken by the subscription
+// transport, see https://github.com/enisdenjo/graphql-ws/blob/master/PROTOCOL.md.
+const wsSubprotocol = "graphql-transport-ws"
+
+const (
+	wsInitTimeout    = 10 * time.Second // Time allowed for the client to initialise the connection
+	wsWriteTimeout   = 10 * time.Second // Time allowed to write a message to the client
+	wsMessageSizeMax = 1024 * 1024      // Maximum size of a message sent by the client
+)
+
+// Close codes defined by the graphql-transport-ws protocol.
+const (
+	wsCloseInvalidMessage = 4400
+	wsCloseUnauthorized   = 4401
+	wsCloseInitTimeout    = 4408
+	wsCloseDuplicateID    = 4409
+	wsCloseTooManyInits   = 4429
+)
+
+// wsMessage is a single message of the graphql-transport-ws protocol.
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// wsSubscribePayload is the payload of a subscribe message.
+type wsSubscribePayload struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// wsHandler serves GraphQL operations, most notably subscriptions, over
+// websocket connections.
+type wsHandler struct {
+	schema   *graphql.Schema
+	upgrader websocket.Upgrader
+}
+
+// newWSHandler creates a websocket handler executing operations against the
+// given schema, accepting connections from the given origins.
+func newWSHandler(schema *graphql.Schema, origins []string) *wsHandler {
+	return &wsHandler{
+		schema: schema,
+		upgrader: websocket.Upgrader{
+			Subprotocols: []string{wsSubprotocol},
+			CheckOrigin:  wsOriginValidator(origins),
+		},
+	}
+}
+
+// wsOriginValidator returns a function that checks the Origin header of the
+// websocket handshakes against the allowed origins. If no origins are allowed
+// explicitly, only same-origin connections are accepted.
+func wsOriginValidator(allowed []string) func(*http.Request) bool {
+	origins := make(map[string]struct{})
+	for _, origin := range allowed {
+		if origin == "*" {
+			return func(*http.Request) bool { return true }
+		}
+		if origin != "" {
+			origins[strings.ToLower(origin)] = struct{}{}
+		}
+	}
+	return func(r *http.Request) bool {
+		// Non-browser clients don't set the origin, nothing to protect against
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		if _, ok := origins[strings.ToLower(origin)]; ok {
+			return true
+		}
+		if len(origins) == 0 {
+			if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+				return true
+			}
+		}
+		log.Warn("Rejected GraphQL websocket connection", "origin", origin)
+		return false
+	}
+}
+
+// isWebsocket checks whether the request asks for a websocket upgrade.
+func isWebsocket(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
+		strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade")
+}
+
+func (h *wsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Debug("Failed to upgrade GraphQL websocket connection", "err", err)
+		return
+	}
+	c := &wsConn{
+		schema: h.schema,
+		conn:   conn,
+		subs:   make(map[string]*wsSubscription),
+	}
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+	c.run()
+}
+
+// wsSubscription is a running operation of a websocket connection.
+type wsSubscription struct {
+	cancel context.CancelFunc
+}
+
+// wsConn is a single websocket connection speaking graphql-transport-ws.
+type wsConn struct {
+	schema *graphql.Schema
+	conn   *websocket.Conn
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	acked bool                       // Whether the connection was initialised
+	subs  map[string]*wsSubscription // Running operations, keyed by client id
+	lock  sync.Mutex                 // Protects the running operations
+	wg    sync.WaitGroup             // Tracks the running operations
+
+	writeLock sync.Mutex // Serialises writes to the connection
+}
+
+// run reads and handles the messages of the client until the connection is
+// closed, then tears down all running operations.
+func (c *wsConn) run() {
+	defer func() {
+		c.cancel()
+		c.wg.Wait()
+		c.conn.Close()
+	}()
+	if c.conn.Subprotocol() != wsSubprotocol {
+		c.close(websocket.CloseProtocolError, "unsupported subprotocol")
+		return
+	}
+	c.conn.SetReadLimit(wsMessageSizeMax)
+	c.conn.SetReadDeadline(time.Now().Add(wsInitTimeout))
+
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			var netErr interface{ Timeout() bool }
+			if errors.As(err, &netErr) && netErr.Timeout() && !c.acked {
+				c.close(wsCloseInitTimeout, "Connection initialisation timeout")
+			}
+			return
+		}
+		var msg wsMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			c.close(wsCloseInvalidMessage, "Invalid message received")
+			return
+		}
+		if !c.handle(&msg) {
+			return
+		}
+	}
+}
+
+// handle processes a single message of the client, returning whether the
+// connection should be kept open.
+func (c *wsConn) handle(msg *wsMessage) bool {
+	switch msg.Type {
+	case "connection_init":
+		if c.acked {
+			c.close(wsCloseTooManyInits, "Too many initialisation requests")
+			return false
+		}
+		c.acked = true
+		c.conn.SetReadDeadline(time.Time{})
+		return c.send("", "connection_ack", nil) == nil
+
+	case "ping":
+		return c.send("", "pong", nil) == nil
+
+	case "pong":
+		return true
+
+	case "subscribe":
+		if !c.acked {
+			c.close(wsCloseUnauthorized, "Unauthorized")
+			return false
+		}
+		var payload wsSubscribePayload
+		if msg.ID == "" || json.Unmarshal(msg.Payload, &payload) != nil {
+			c.close(wsCloseInvalidMessage, "Invalid message received")
+			return false
+		}
+		return c.subscribe(msg.ID, &payload)
+
+	case "complete":
+		c.lock.Lock()
+		if sub, ok := c.subs[msg.ID]; ok {
+			sub.cancel()
+			delete(c.subs, msg.ID)
+		}
+		c.lock.Unlock()
+		return true
+
+	default:
+		c.close(wsCloseInvalidMessage, "Invalid message received")
+		return false
+	}
+}
+
+// subscribe starts executing an operation of the client, streaming back its
+// results until it completes or is cancelled.
+func (c *wsConn) subscribe(id string, payload *wsSubscribePayload) bool {
+	c.lock.Lock()
+	if _, ok := c.subs[id]; ok {
+		c.lock.Unlock()
+		c.close(wsCloseDuplicateID, fmt.Sprintf("Subscriber for %s already exists", id))
+		return false
+	}
+	ctx, cancel := context.WithCancel(c.ctx)
+	sub := &wsSubscription{cancel: cancel}
+	c.subs[id] = sub
+	c.lock.Unlock()
+
+	responses, err := c.schema.Subscribe(ctx, payload.Query, payload.OperationName, payload.Variables)
+	if err != nil {
+		c.finish(id, sub)
+		return c.send(id, "error", []map[string]string{{"message": err.Error()}}) == nil
+	}
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		failed := false
+		for res := range responses {
+			// Drain the responses after cancellation to release the executor
+			if ctx.Err() != nil || failed {
+				continue
+			}
+			response := res.(*graphql.Response)
+			if response.Data == nil && len(response.Errors) > 0 {
+				failed = true
+				c.send(id, "error", response.Errors)
+				continue
+			}
+			c.send(id, "next", response)
+		}
+		// Only report completion if the client hasn't cancelled the operation
+		if c.finish(id, sub) && !failed {
+			c.send(id, "complete", nil)
+		}
+	}()
+	return true
+}
+
+// finish removes a terminated operation, reporting whether it was still
+// running (i.e. not cancelled by the client).
+func (c *wsConn) finish(id string, sub *wsSubscription) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	sub.cancel()
+	if c.subs[id] != sub {
+		return false
+	}
+	delete(c.subs, id)
+	return c.ctx.Err() == nil
+}
+
+// send writes a message to the client.
+func (c *wsConn) send(id string, typ string, payload interface{}) error {
+	msg := &wsMessage{ID: id, Type: typ}
+	if payload != nil {
+		blob, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		msg.Payload = blob
+	}
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	c.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	return c.conn.WriteJSON(msg)
+}
+
+// close terminates the connection with the given close code.
+func (c *wsConn) close(code int, reason string) {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(wsWriteTimeout))
+}
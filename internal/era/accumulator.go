@@ -0,0 +1,72 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package era
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/theQRL/go-zond/common"
+)
+
+// accumulatorDepth is the depth of the merkle tree over the block hashes of an
+// epoch, which can hold up to MaxSize leaves.
+const accumulatorDepth = 13
+
+// ComputeAccumulator calculates the accumulator root of the block hashes of an
+// epoch. It is the SSZ hash tree root of a List[Bytes32, MaxSize], i.e. the
+// root of the zero padded merkle tree of the hashes mixed in with their count.
+// Contrary to the era1 format no total difficulties are accumulated, since the
+// chain has no notion of them.
+func ComputeAccumulator(hashes []common.Hash) (common.Hash, error) {
+	if len(hashes) > MaxSize {
+		return common.Hash{}, fmt.Errorf("too many block hashes: have %d, max %d", len(hashes), MaxSize)
+	}
+	var (
+		layer = make([][32]byte, len(hashes))
+		zero  [32]byte // root of an empty subtree at the current depth
+		pair  [64]byte
+	)
+	for i, hash := range hashes {
+		layer[i] = hash
+	}
+	for depth := 0; depth < accumulatorDepth; depth++ {
+		if len(layer)%2 == 1 {
+			layer = append(layer, zero)
+		}
+		for i := 0; i < len(layer)/2; i++ {
+			copy(pair[:32], layer[2*i][:])
+			copy(pair[32:], layer[2*i+1][:])
+			layer[i] = sha256.Sum256(pair[:])
+		}
+		layer = layer[:len(layer)/2]
+
+		copy(pair[:32], zero[:])
+		copy(pair[32:], zero[:])
+		zero = sha256.Sum256(pair[:])
+	}
+	root := zero
+	if len(layer) > 0 {
+		root = layer[0]
+	}
+	// Mix in the length of the list
+	copy(pair[:32], root[:])
+	clear(pair[32:])
+	binary.LittleEndian.PutUint64(pair[32:], uint64(len(hashes)))
+	return sha256.Sum256(pair[:]), nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package e2store
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	entries := []Entry{
+		{Type: 0xffff, Value: nil},
+		{Type: 42, Value: []byte{0xde, 0xad, 0xbe, 0xef}},
+		{Type: 0x3265, Value: bytes.Repeat([]byte{0x01}, 1024)},
+	}
+	var (
+		buf = bytes.NewBuffer(nil)
+		w   = NewWriter(buf)
+	)
+	for _, e := range entries {
+		if _, err := w.Write(e.Type, e.Value); err != nil {
+			t.Fatalf("failed to write entry: %v", err)
+		}
+	}
+	r := NewReader(bytes.NewReader(buf.Bytes()))
+	for i, want := range entries {
+		have, err := r.Read()
+		if err != nil {
+			t.Fatalf("entry %d: failed to read: %v", i, err)
+		}
+		if have.Type != want.Type || !bytes.Equal(have.Value, want.Value) {
+			t.Fatalf("entry %d: mismatch: have %x/%x, want %x/%x", i, have.Type, have.Value, want.Type, want.Value)
+		}
+	}
+	if _, err := r.Read(); err != io.EOF {
+		t.Fatalf("expected EOF, have %v", err)
+	}
+	if e, err := NewReader(bytes.NewReader(buf.Bytes())).Find(42); err != nil || !bytes.Equal(e.Value, entries[1].Value) {
+		t.Fatalf("failed to find entry: %v", err)
+	}
+	// Non-zero reserved bytes are rejected
+	blob := buf.Bytes()
+	blob[7] = 1
+	if _, err := NewReader(bytes.NewReader(blob)).Read(); err == nil {
+		t.Fatalf("entry with reserved bytes set accepted")
+	}
+}
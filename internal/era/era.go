@@ -0,0 +1,349 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package era
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/internal/era/e2store"
+	"github.com/theQRL/go-zond/rlp"
+	"github.com/theQRL/go-zond/trie"
+)
+
+// Type constants for the e2store entries of an archive.
+const (
+	TypeVersion            uint16 = 0x3265
+	TypeCompressedHeader   uint16 = 0x03
+	TypeCompressedBody     uint16 = 0x04
+	TypeCompressedReceipts uint16 = 0x05
+	TypeAccumulator        uint16 = 0x07
+	TypeBlockIndex         uint16 = 0x3266
+
+	MaxSize = 8192 // Number of blocks in an epoch
+)
+
+// Extension is the file extension of the chain history archives.
+const Extension = ".era"
+
+var (
+	// ErrNotFound is returned if the requested block is not in the archive.
+	ErrNotFound = errors.New("block not found in archive")
+
+	// ErrAccumulatorMismatch is returned if the blocks of an archive don't
+	// match its accumulator.
+	ErrAccumulatorMismatch = errors.New("accumulator mismatch")
+)
+
+// Filename returns a recognizable archive filename for the given epoch, made
+// up of the network name, the epoch number and the prefix of the accumulator.
+func Filename(network string, epoch int, root common.Hash) string {
+	return fmt.Sprintf("%s-%05d-%s%s", network, epoch, root.Hex()[2:10], Extension)
+}
+
+// ReadDir reads the archive files of the given network from dir, sorted by
+// epoch. An error is returned if the epochs are not contiguous.
+func ReadDir(dir, network string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading directory %s: %w", dir, err)
+	}
+	var (
+		next  = uint64(0)
+		files []string
+	)
+	for _, entry := range entries {
+		if path.Ext(entry.Name()) != Extension {
+			continue
+		}
+		parts := strings.Split(entry.Name(), "-")
+		if len(parts) != 3 || parts[0] != network {
+			// Invalid archive filename, skip
+			continue
+		}
+		epoch, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed archive filename: %s", entry.Name())
+		}
+		if len(files) > 0 && epoch != next {
+			return nil, fmt.Errorf("missing epoch %d", next)
+		}
+		next = epoch + 1
+		files = append(files, entry.Name())
+	}
+	return files, nil
+}
+
+// ReadAtSeekCloser is the interface of the backing store of an archive.
+type ReadAtSeekCloser interface {
+	io.ReaderAt
+	io.Seeker
+	io.Closer
+}
+
+// Era reads an archive of the chain history, giving random access to its
+// blocks by number.
+type Era struct {
+	f   ReadAtSeekCloser // backing era file
+	s   *e2store.Reader  // e2store reader over f
+	m   metadata         // start, count, length info
+	mu  *sync.Mutex      // lock for buf
+	buf [8]byte          // buffer reading entry offsets
+}
+
+// From returns an archive backed by f.
+func From(f ReadAtSeekCloser) (*Era, error) {
+	m, err := readMetadata(f)
+	if err != nil {
+		return nil, err
+	}
+	return &Era{
+		f:  f,
+		s:  e2store.NewReader(f),
+		m:  m,
+		mu: new(sync.Mutex),
+	}, nil
+}
+
+// Open returns an archive read from the given file.
+func Open(filename string) (*Era, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	e, err := From(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return e, nil
+}
+
+// Close closes the backing file of the archive.
+func (e *Era) Close() error {
+	return e.f.Close()
+}
+
+// Start returns the number of the first block in the archive.
+func (e *Era) Start() uint64 {
+	return e.m.start
+}
+
+// Count returns the number of blocks in the archive.
+func (e *Era) Count() uint64 {
+	return e.m.count
+}
+
+// GetBlockByNumber returns the block with the given number from the archive.
+func (e *Era) GetBlockByNumber(num uint64) (*types.Block, error) {
+	off, err := e.readOffset(num)
+	if err != nil {
+		return nil, err
+	}
+	r, n, err := newSnappyReader(e.s, TypeCompressedHeader, off)
+	if err != nil {
+		return nil, err
+	}
+	var header types.Header
+	if err := rlp.Decode(r, &header); err != nil {
+		return nil, err
+	}
+	off += n
+	r, _, err = newSnappyReader(e.s, TypeCompressedBody, off)
+	if err != nil {
+		return nil, err
+	}
+	var body types.Body
+	if err := rlp.Decode(r, &body); err != nil {
+		return nil, err
+	}
+	return types.NewBlockWithHeader(&header).WithBody(body), nil
+}
+
+// GetReceiptsByNumber returns the receipts of the block with the given number
+// from the archive, in consensus encoding.
+func (e *Era) GetReceiptsByNumber(num uint64) (types.Receipts, error) {
+	off, err := e.readOffset(num)
+	if err != nil {
+		return nil, err
+	}
+	// Skip over the header and the body
+	for i := 0; i < 2; i++ {
+		n, err := e.s.LengthAt(off)
+		if err != nil {
+			return nil, err
+		}
+		off += n
+	}
+	r, _, err := newSnappyReader(e.s, TypeCompressedReceipts, off)
+	if err != nil {
+		return nil, err
+	}
+	var receipts types.Receipts
+	if err := rlp.Decode(r, &receipts); err != nil {
+		return nil, err
+	}
+	return receipts, nil
+}
+
+// Accumulator reads the accumulator root stored in the archive.
+func (e *Era) Accumulator() (common.Hash, error) {
+	entry, err := e.s.Find(TypeAccumulator)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(entry.Value), nil
+}
+
+// Verify checks that the blocks in the archive form a chain, that their bodies
+// and receipts match the headers and that the hashes of the blocks match the
+// accumulator of the archive. The accumulator root is returned.
+func (e *Era) Verify() (common.Hash, error) {
+	want, err := e.Accumulator()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	var (
+		hashes = make([]common.Hash, 0, e.m.count)
+		parent common.Hash
+	)
+	for num := e.m.start; num < e.m.start+e.m.count; num++ {
+		block, err := e.GetBlockByNumber(num)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("error reading block %d: %w", num, err)
+		}
+		if block.NumberU64() != num {
+			return common.Hash{}, fmt.Errorf("block number mismatch: have %d, want %d", block.NumberU64(), num)
+		}
+		if num > e.m.start && block.ParentHash() != parent {
+			return common.Hash{}, fmt.Errorf("block %d is not linked to its parent", num)
+		}
+		receipts, err := e.GetReceiptsByNumber(num)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("error reading receipts %d: %w", num, err)
+		}
+		if err := verifyBlock(block, receipts); err != nil {
+			return common.Hash{}, fmt.Errorf("invalid block %d: %w", num, err)
+		}
+		parent = block.Hash()
+		hashes = append(hashes, parent)
+	}
+	have, err := ComputeAccumulator(hashes)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if have != want {
+		return common.Hash{}, fmt.Errorf("%w: have %x, want %x", ErrAccumulatorMismatch, have, want)
+	}
+	return want, nil
+}
+
+// readOffset reads a specific block's offset from the block index. The value n
+// is the absolute block number desired.
+func (e *Era) readOffset(n uint64) (int64, error) {
+	if n < e.m.start || n >= e.m.start+e.m.count {
+		return 0, ErrNotFound
+	}
+	var (
+		blockIndexRecordOffset = e.m.length - 24 - int64(e.m.count)*8 // skips start, count, and header
+		firstIndex             = blockIndexRecordOffset + 16          // first index after header / start-num
+		indexOffset            = int64(n-e.m.start) * 8               // desired index * size of indexes
+		offOffset              = firstIndex + indexOffset             // offset of block offset
+	)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	clear(e.buf[:])
+	if _, err := e.f.ReadAt(e.buf[:], offOffset); err != nil {
+		return 0, err
+	}
+	// Since the block offset is relative from the start of the block index record
+	// we need to add the record offset to it's offset to get the block's absolute
+	// offset.
+	return blockIndexRecordOffset + int64(binary.LittleEndian.Uint64(e.buf[:])), nil
+}
+
+// metadata wraps the metadata in the block index.
+type metadata struct {
+	start  uint64
+	count  uint64
+	length int64
+}
+
+// readMetadata reads the metadata stored in an archive's block index.
+func readMetadata(f ReadAtSeekCloser) (m metadata, err error) {
+	// Determine length of reader.
+	if m.length, err = f.Seek(0, io.SeekEnd); err != nil {
+		return
+	}
+	b := make([]byte, 16)
+	// Read count. It's the last 8 bytes of the file.
+	if _, err = f.ReadAt(b[:8], m.length-8); err != nil {
+		return
+	}
+	m.count = binary.LittleEndian.Uint64(b)
+	if m.count > MaxSize || int64(m.count)*8+32 > m.length {
+		return m, fmt.Errorf("invalid block count %d", m.count)
+	}
+	// Read start. It's at the offset -sizeof(m.count) -
+	// count*sizeof(indexEntry) - sizeof(m.start)
+	if _, err = f.ReadAt(b[8:], m.length-16-int64(m.count*8)); err != nil {
+		return
+	}
+	m.start = binary.LittleEndian.Uint64(b[8:])
+	if m.start > math.MaxUint64-m.count {
+		return m, fmt.Errorf("invalid block range %d+%d", m.start, m.count)
+	}
+	return
+}
+
+// newSnappyReader returns a snappy.Reader for the e2store entry value at off.
+func newSnappyReader(e *e2store.Reader, expectedType uint16, off int64) (io.Reader, int64, error) {
+	r, n, err := e.ReaderAt(expectedType, off)
+	if err != nil {
+		return nil, 0, err
+	}
+	return snappy.NewReader(r), int64(n), err
+}
+
+// verifyBlock checks that the body and the receipts of a block match the roots
+// committed to by its header.
+func verifyBlock(block *types.Block, receipts types.Receipts) error {
+	hasher := trie.NewStackTrie(nil)
+	if root := types.DeriveSha(block.Transactions(), hasher); root != block.TxHash() {
+		return fmt.Errorf("transaction root mismatch: have %x, want %x", root, block.TxHash())
+	}
+	if withdrawals := block.Withdrawals(); block.Header().WithdrawalsHash != nil {
+		if root := types.DeriveSha(withdrawals, hasher); root != *block.Header().WithdrawalsHash {
+			return fmt.Errorf("withdrawal root mismatch: have %x, want %x", root, *block.Header().WithdrawalsHash)
+		}
+	}
+	if root := types.DeriveSha(receipts, hasher); root != block.ReceiptHash() {
+		return fmt.Errorf("receipt root mismatch: have %x, want %x", root, block.ReceiptHash())
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package era
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/rlp"
+	"github.com/theQRL/go-zond/trie"
+)
+
+// makeTestChain creates a linked chain of blocks with a transaction and the
+// corresponding receipt each.
+func makeTestChain(start, count uint64) ([]*types.Block, []types.Receipts) {
+	var (
+		to       = common.Address{0x11}
+		parent   common.Hash
+		blocks   []*types.Block
+		receipts []types.Receipts
+	)
+	for n := start; n < start+count; n++ {
+		tx := types.NewTx(&types.DynamicFeeTx{Nonce: n, GasFeeCap: big.NewInt(1), Gas: 21000, To: &to})
+		rs := types.Receipts{{
+			Type:              types.DynamicFeeTxType,
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: 21000,
+			Logs:              []*types.Log{{Address: to, Data: []byte{byte(n)}}},
+		}}
+		rs[0].Bloom = types.CreateBloom(rs)
+
+		header := &types.Header{ParentHash: parent, Number: new(big.Int).SetUint64(n), Extra: []byte("test"), BaseFee: big.NewInt(1)}
+		body := &types.Body{Transactions: types.Transactions{tx}, Withdrawals: []*types.Withdrawal{}}
+		block := types.NewBlock(header, body, rs, trie.NewStackTrie(nil))
+		parent = block.Hash()
+
+		blocks = append(blocks, block)
+		receipts = append(receipts, rs)
+	}
+	return blocks, receipts
+}
+
+func TestEraRoundTrip(t *testing.T) {
+	blocks, receipts := makeTestChain(MaxSize, 128)
+
+	var (
+		buf     = bytes.NewBuffer(nil)
+		builder = NewBuilder(buf)
+		hashes  []common.Hash
+	)
+	for i, block := range blocks {
+		if err := builder.Add(block, receipts[i]); err != nil {
+			t.Fatalf("failed to add block %d: %v", i, err)
+		}
+		hashes = append(hashes, block.Hash())
+	}
+	if err := builder.Add(blocks[0], receipts[0]); err == nil {
+		t.Fatalf("non-contiguous block accepted")
+	}
+	root, err := builder.Finalize()
+	if err != nil {
+		t.Fatalf("failed to finalize archive: %v", err)
+	}
+	if want, _ := ComputeAccumulator(hashes); root != want {
+		t.Fatalf("accumulator mismatch: have %x, want %x", root, want)
+	}
+	path := filepath.Join(t.TempDir(), Filename("test", 1, root))
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("failed to write archive: %v", err)
+	}
+	e, err := Open(path)
+	if err != nil {
+		t.Fatalf("failed to open archive: %v", err)
+	}
+	defer e.Close()
+
+	if e.Start() != MaxSize || e.Count() != 128 {
+		t.Fatalf("range mismatch: have %d+%d, want %d+128", e.Start(), e.Count(), MaxSize)
+	}
+	if have, err := e.Verify(); err != nil || have != root {
+		t.Fatalf("failed to verify archive: root %x, err %v", have, err)
+	}
+	// Read the blocks out of order to exercise the index
+	for _, i := range []int{127, 0, 64, 1} {
+		num := blocks[i].NumberU64()
+		block, err := e.GetBlockByNumber(num)
+		if err != nil {
+			t.Fatalf("failed to read block %d: %v", num, err)
+		}
+		if block.Hash() != blocks[i].Hash() || block.Transactions()[0].Hash() != blocks[i].Transactions()[0].Hash() {
+			t.Fatalf("block %d mismatch", num)
+		}
+		rs, err := e.GetReceiptsByNumber(num)
+		if err != nil {
+			t.Fatalf("failed to read receipts %d: %v", num, err)
+		}
+		if len(rs) != 1 || !bytes.Equal(rs[0].Logs[0].Data, receipts[i][0].Logs[0].Data) {
+			t.Fatalf("receipts %d mismatch", num)
+		}
+	}
+	for _, num := range []uint64{MaxSize - 1, MaxSize + 128} {
+		if _, err := e.GetBlockByNumber(num); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("block %d outside of the archive: have %v, want %v", num, err, ErrNotFound)
+		}
+	}
+	// Verify the archive names are parsed back
+	files, err := ReadDir(filepath.Dir(path), "test")
+	if err != nil || len(files) != 1 || files[0] != filepath.Base(path) {
+		t.Fatalf("failed to list archives: %v %v", files, err)
+	}
+}
+
+// Tests that archives with blocks not matching their accumulator are rejected.
+func TestEraAccumulatorMismatch(t *testing.T) {
+	blocks, receipts := makeTestChain(0, 4)
+
+	buf := bytes.NewBuffer(nil)
+	builder := NewBuilder(buf)
+	for i, block := range blocks[:3] {
+		builder.Add(block, receipts[i])
+	}
+	// Swap in a block not linked to its parent
+	forged, forgedReceipts := makeTestChain(3, 1)
+	builder.AddRLP(mustEncode(t, forged[0].Header()), mustEncode(t, forged[0].Body()), mustEncode(t, forgedReceipts[0]), 3, blocks[3].Hash())
+	if _, err := builder.Finalize(); err != nil {
+		t.Fatalf("failed to finalize archive: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "test.era")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("failed to write archive: %v", err)
+	}
+	e, err := Open(path)
+	if err != nil {
+		t.Fatalf("failed to open archive: %v", err)
+	}
+	defer e.Close()
+	if _, err := e.Verify(); err == nil {
+		t.Fatalf("forged archive verified")
+	}
+}
+
+func mustEncode(t *testing.T, val interface{}) []byte {
+	t.Helper()
+	blob, err := rlp.EncodeToBytes(val)
+	if err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+	return blob
+}
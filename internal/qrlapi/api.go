@@ -34,7 +34,9 @@ import (
 	"github.com/theQRL/go-zond/consensus"
 	"github.com/theQRL/go-zond/consensus/misc/eip1559"
 	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/rawdb"
 	"github.com/theQRL/go-zond/core/state"
+	"github.com/theQRL/go-zond/core/txpool"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/core/vm"
 	"github.com/theQRL/go-zond/crypto"
@@ -251,6 +253,83 @@ func (s *TxPoolAPI) Inspect() map[string]map[string]map[string]string {
 	return content
 }
 
+// RPCTxPoolEvent is the RPC representation of a transaction lifecycle change
+// within the transaction pool.
+type RPCTxPoolEvent struct {
+	Kind        string          `json:"kind"`
+	Hash        common.Hash     `json:"hash"`
+	From        common.Address  `json:"from"`
+	Nonce       hexutil.Uint64  `json:"nonce"`
+	Reason      string          `json:"reason,omitempty"`
+	ReplacedBy  *common.Hash    `json:"replacedBy,omitempty"`
+	BlockHash   *common.Hash    `json:"blockHash,omitempty"`
+	BlockNumber *hexutil.Uint64 `json:"blockNumber,omitempty"`
+}
+
+// newRPCTxPoolEvent converts a transaction pool event into its RPC representation.
+func newRPCTxPoolEvent(ev *txpool.TxEvent) *RPCTxPoolEvent {
+	result := &RPCTxPoolEvent{
+		Kind:  ev.Kind.String(),
+		Hash:  ev.Hash,
+		From:  ev.From,
+		Nonce: hexutil.Uint64(ev.Nonce),
+	}
+	switch ev.Kind {
+	case txpool.TxEventDropped:
+		result.Reason = string(ev.Reason)
+	case txpool.TxEventReplaced:
+		result.ReplacedBy = &ev.ReplacedBy
+	case txpool.TxEventIncluded:
+		number := hexutil.Uint64(ev.BlockNumber)
+		result.BlockHash, result.BlockNumber = &ev.BlockHash, &number
+	}
+	return result
+}
+
+// TransactionEvents creates a subscription that is triggered each time a transaction
+// enters the pool, gets replaced, dropped, included in a block or moved back into
+// the queue. If a list of accounts is given, only events of transactions sent by
+// them are delivered.
+func (s *TxPoolAPI) TransactionEvents(ctx context.Context, accounts *[]common.Address) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	var filter map[common.Address]struct{}
+	if accounts != nil {
+		filter = make(map[common.Address]struct{}, len(*accounts))
+		for _, addr := range *accounts {
+			filter[addr] = struct{}{}
+		}
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		events := make(chan []*txpool.TxEvent, 128)
+		sub := s.b.SubscribeTxPoolEvents(events)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case batch := <-events:
+				for _, ev := range batch {
+					if filter != nil {
+						if _, ok := filter[ev.From]; !ok {
+							continue
+						}
+					}
+					notifier.Notify(rpcSub.ID, newRPCTxPoolEvent(ev))
+				}
+			case <-sub.Err():
+				return
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
 // QRLAccountAPI provides an API to access accounts managed by this node.
 // It offers only methods that can retrieve accounts.
 type QRLAccountAPI struct {
@@ -430,6 +509,110 @@ func decodeHash(s string) (h common.Hash, inputLength int, err error) {
 	return common.BytesToHash(b), len(b), nil
 }
 
+// AccountStateResult is the state of an account at a given block, as returned
+// by qrl_getAccount and qrl_getAccounts.
+type AccountStateResult struct {
+	Address     common.Address              `json:"address"`
+	Nonce       hexutil.Uint64              `json:"nonce"`
+	Balance     *hexutil.Big                `json:"balance"`
+	CodeHash    common.Hash                 `json:"codeHash"`
+	StorageRoot common.Hash                 `json:"storageRoot"`
+	Storage     map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+// AccountQuery selects an account and some of its storage slots to retrieve
+// through qrl_getAccounts.
+type AccountQuery struct {
+	Address     common.Address `json:"address"`
+	StorageKeys []string       `json:"storageKeys"`
+}
+
+const (
+	// maxAccountQueries is the maximum number of accounts which can be
+	// retrieved in a single qrl_getAccounts request.
+	maxAccountQueries = 1024
+
+	// maxStorageQueries is the maximum number of storage slots which can be
+	// retrieved in a single qrl_getAccounts request, across all accounts.
+	maxStorageQueries = 8192
+)
+
+// accountState retrieves the state of an account from the given state, along
+// with the requested storage slots. Accounts not present in the state are
+// reported as empty ones.
+func accountState(statedb *state.StateDB, address common.Address, keys []common.Hash) *AccountStateResult {
+	result := &AccountStateResult{
+		Address:     address,
+		Nonce:       hexutil.Uint64(statedb.GetNonce(address)),
+		Balance:     (*hexutil.Big)(statedb.GetBalance(address)),
+		CodeHash:    statedb.GetCodeHash(address),
+		StorageRoot: statedb.GetStorageRoot(address),
+	}
+	if result.CodeHash == (common.Hash{}) {
+		result.CodeHash = types.EmptyCodeHash
+	}
+	if result.StorageRoot == (common.Hash{}) {
+		result.StorageRoot = types.EmptyRootHash
+	}
+	if len(keys) > 0 {
+		result.Storage = make(map[common.Hash]common.Hash, len(keys))
+		for _, key := range keys {
+			result.Storage[key] = statedb.GetState(address, key)
+		}
+	}
+	return result
+}
+
+// GetAccount returns the nonce, balance, code hash and storage root of the
+// given account at the given block.
+func (s *BlockChainAPI) GetAccount(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*AccountStateResult, error) {
+	statedb, _, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if statedb == nil || err != nil {
+		return nil, err
+	}
+	result := accountState(statedb, address, nil)
+	return result, statedb.Error()
+}
+
+// GetAccounts returns the state of many accounts, along with the requested
+// storage slots of each, at the given block. The state is served from the
+// snapshot if it's available for the block, and from the tries otherwise.
+func (s *BlockChainAPI) GetAccounts(ctx context.Context, queries []AccountQuery, blockNrOrHash rpc.BlockNumberOrHash) ([]*AccountStateResult, error) {
+	if len(queries) > maxAccountQueries {
+		return nil, fmt.Errorf("too many accounts requested: %d, limit %d", len(queries), maxAccountQueries)
+	}
+	// Deserialize all keys. This prevents state access on invalid input.
+	var (
+		keys  = make([][]common.Hash, len(queries))
+		count int
+	)
+	for i, query := range queries {
+		if count += len(query.StorageKeys); count > maxStorageQueries {
+			return nil, fmt.Errorf("too many storage slots requested, limit %d", maxStorageQueries)
+		}
+		keys[i] = make([]common.Hash, len(query.StorageKeys))
+		for j, hexKey := range query.StorageKeys {
+			key, _, err := decodeHash(hexKey)
+			if err != nil {
+				return nil, fmt.Errorf("unable to decode storage key of account %d: %s", i, err)
+			}
+			keys[i][j] = key
+		}
+	}
+	statedb, _, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if statedb == nil || err != nil {
+		return nil, err
+	}
+	results := make([]*AccountStateResult, len(queries))
+	for i, query := range queries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		results[i] = accountState(statedb, query.Address, keys[i])
+	}
+	return results, statedb.Error()
+}
+
 // GetHeaderByNumber returns the requested canonical block header.
 //   - When blockNr is -1 the chain pending header is returned.
 //   - When blockNr is -2 the chain latest header is returned.
@@ -478,6 +661,12 @@ func (s *BlockChainAPI) GetBlockByNumber(ctx context.Context, number rpc.BlockNu
 		}
 		return response, err
 	}
+	// If the header is known but the block isn't, it might have been pruned
+	if err == nil {
+		if header, _ := s.b.HeaderByNumber(ctx, number); header != nil {
+			return nil, checkPrunedHistory(s.b, header.Number.Uint64())
+		}
+	}
 	return nil, err
 }
 
@@ -488,6 +677,12 @@ func (s *BlockChainAPI) GetBlockByHash(ctx context.Context, hash common.Hash, fu
 	if block != nil {
 		return s.rpcMarshalBlock(block, true, fullTx)
 	}
+	// If the header is known but the block isn't, it might have been pruned
+	if err == nil {
+		if header, _ := s.b.HeaderByHash(ctx, hash); header != nil {
+			return nil, checkPrunedHistory(s.b, header.Number.Uint64())
+		}
+	}
 	return nil, err
 }
 
@@ -521,6 +716,10 @@ func (s *BlockChainAPI) GetStorageAt(ctx context.Context, address common.Address
 func (s *BlockChainAPI) GetBlockReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]map[string]interface{}, error) {
 	block, err := s.b.BlockByNumberOrHash(ctx, blockNrOrHash)
 	if block == nil || err != nil {
+		// If the header is known but the block isn't, it might have been pruned
+		if header, _ := s.b.HeaderByNumberOrHash(ctx, blockNrOrHash); header != nil {
+			return nil, checkPrunedHistory(s.b, header.Number.Uint64())
+		}
 		// When the block doesn't exist, the RPC method should return JSON null
 		// as per specification.
 		return nil, nil
@@ -535,7 +734,7 @@ func (s *BlockChainAPI) GetBlockReceipts(ctx context.Context, blockNrOrHash rpc.
 	}
 
 	// Derive the sender.
-	signer := types.MakeSigner(s.b.ChainConfig())
+	signer := types.MakeSigner(s.b.ChainConfig(), block.Time())
 
 	result := make([]map[string]interface{}, len(receipts))
 	for i, receipt := range receipts {
@@ -636,6 +835,33 @@ func (diff *BlockOverrides) Apply(blockCtx *vm.BlockContext) {
 	}
 }
 
+// MakeHeader returns a new header object with the overridden fields.
+func (diff *BlockOverrides) MakeHeader(header *types.Header) *types.Header {
+	if diff == nil {
+		return header
+	}
+	h := types.CopyHeader(header)
+	if diff.Number != nil {
+		h.Number = diff.Number.ToInt()
+	}
+	if diff.Time != nil {
+		h.Time = uint64(*diff.Time)
+	}
+	if diff.GasLimit != nil {
+		h.GasLimit = uint64(*diff.GasLimit)
+	}
+	if diff.Coinbase != nil {
+		h.Coinbase = *diff.Coinbase
+	}
+	if diff.Random != nil {
+		h.Random = *diff.Random
+	}
+	if diff.BaseFee != nil {
+		h.BaseFee = diff.BaseFee.ToInt()
+	}
+	return h
+}
+
 // ChainContextBackend provides methods required to implement ChainContext.
 type ChainContextBackend interface {
 	Engine() consensus.Engine
@@ -778,6 +1004,44 @@ func (s *BlockChainAPI) Call(ctx context.Context, args TransactionArgs, blockNrO
 	return result.Return(), result.Err
 }
 
+// SimulateV1 executes series of transactions on top of a base state.
+// The transactions are packed into blocks. For each block, block header
+// fields can be overridden. The state can also be overridden prior to
+// execution of each block.
+//
+// Note, this function doesn't make any changes in the state/blockchain and is
+// useful to execute and retrieve values.
+func (s *BlockChainAPI) SimulateV1(ctx context.Context, opts simOpts, blockNrOrHash *rpc.BlockNumberOrHash) ([]map[string]interface{}, error) {
+	if len(opts.BlockStateCalls) == 0 {
+		return nil, &invalidParamsError{message: "empty input"}
+	} else if len(opts.BlockStateCalls) > maxSimulateBlocks {
+		return nil, &clientLimitExceededError{message: "too many blocks"}
+	}
+	if blockNrOrHash == nil {
+		n := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		blockNrOrHash = &n
+	}
+	state, base, err := s.b.StateAndHeaderByNumberOrHash(ctx, *blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	gasCap := s.b.RPCGasCap()
+	if gasCap == 0 {
+		gasCap = math.MaxUint64
+	}
+	sim := &simulator{
+		b:           s.b,
+		state:       state,
+		base:        base,
+		chainConfig: s.b.ChainConfig(),
+		// Each tx and all the series of txes shouldn't consume more gas than cap
+		gp:       new(core.GasPool).AddGas(gasCap),
+		validate: opts.Validation,
+		fullTx:   opts.ReturnFullTransactions,
+	}
+	return sim.execute(ctx, opts.BlockStateCalls)
+}
+
 // executeEstimate is a helper that executes the transaction under a given gas limit and returns
 // true if the transaction fails for a reason that might be related to not enough gas. A non-nil
 // error means execution failed due to reasons unrelated to the gas limit.
@@ -785,7 +1049,7 @@ func executeEstimate(ctx context.Context, b Backend, args TransactionArgs, state
 	args.Gas = (*hexutil.Uint64)(&gasLimit)
 	result, err := doCall(ctx, b, args, state, header, nil, nil, 0, gasCap)
 	if err != nil {
-		if errors.Is(err, core.ErrIntrinsicGas) {
+		if errors.Is(err, core.ErrIntrinsicGas) || errors.Is(err, core.ErrValidationGasTooHigh) {
 			return true, nil, nil // Special case, raise gas limit
 		}
 		return true, nil, err // Bail out
@@ -948,6 +1212,9 @@ func RPCMarshalHeader(head *types.Header) map[string]interface{} {
 	if head.WithdrawalsHash != nil {
 		result["withdrawalsRoot"] = head.WithdrawalsHash
 	}
+	if head.ParentBeaconRoot != nil {
+		result["parentBeaconBlockRoot"] = head.ParentBeaconRoot
+	}
 	return result
 }
 
@@ -1012,15 +1279,16 @@ type RPCTransaction struct {
 	Type             hexutil.Uint64    `json:"type"`
 	Accesses         *types.AccessList `json:"accessList,omitempty"`
 	ChainID          *hexutil.Big      `json:"chainId,omitempty"`
-	PublicKey        hexutil.Bytes     `json:"publicKey"`
+	PublicKey        hexutil.Bytes     `json:"publicKey,omitempty"`
 	Signature        hexutil.Bytes     `json:"signature"`
-	Descriptor       hexutil.Bytes     `json:"descriptor"`
+	Descriptor       hexutil.Bytes     `json:"descriptor,omitempty"`
+	ValidationGas    *hexutil.Uint64   `json:"validationGas,omitempty"`
 }
 
 // newRPCTransaction returns a transaction that will serialize to the RPC
 // representation, with the given location metadata set (if available).
 func newRPCTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber uint64, index uint64, baseFee *big.Int, config *params.ChainConfig) *RPCTransaction {
-	signer := types.MakeSigner(config)
+	signer := types.LatestSigner(config)
 	from, _ := types.Sender(signer, tx)
 	publicKey := tx.RawPublicKeyValue()
 	signature := tx.RawSignatureValue()
@@ -1046,7 +1314,7 @@ func newRPCTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber
 	}
 
 	switch tx.Type() {
-	case types.DynamicFeeTxType:
+	case types.DynamicFeeTxType, types.AccountAbstractionTxType:
 		al := tx.AccessList()
 		result.Accesses = &al
 		result.ChainID = (*hexutil.Big)(tx.ChainId())
@@ -1060,6 +1328,10 @@ func newRPCTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber
 			result.GasPrice = (*hexutil.Big)(tx.GasFeeCap())
 		}
 	}
+	if tx.Type() == types.AccountAbstractionTxType {
+		validationGas := hexutil.Uint64(tx.ValidationGas())
+		result.ValidationGas = &validationGas
+	}
 	return result
 }
 
@@ -1294,6 +1566,12 @@ func (s *TransactionAPI) GetTransactionByHash(ctx context.Context, hash common.H
 		}
 		return newRPCTransaction(tx, blockHash, blockNumber, index, header.BaseFee, s.b.ChainConfig()), nil
 	}
+	// The transaction might still be indexed while its block was pruned
+	if number := rawdb.ReadTxLookupEntry(s.b.ChainDb(), hash); number != nil {
+		if err := checkPrunedHistory(s.b, *number); err != nil {
+			return nil, err
+		}
+	}
 	// No finalized transaction, try to retrieve it from the pool
 	if tx := s.b.GetPoolTransaction(hash); tx != nil {
 		return NewRPCPendingTransaction(tx, s.b.CurrentHeader(), s.b.ChainConfig()), nil
@@ -1324,6 +1602,12 @@ func (s *TransactionAPI) GetRawTransactionByHash(ctx context.Context, hash commo
 func (s *TransactionAPI) GetTransactionReceipt(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
 	tx, blockHash, blockNumber, index, err := s.b.GetTransaction(ctx, hash)
 	if tx == nil || err != nil {
+		// The transaction might still be indexed while its block was pruned
+		if number := rawdb.ReadTxLookupEntry(s.b.ChainDb(), hash); number != nil {
+			if err := checkPrunedHistory(s.b, *number); err != nil {
+				return nil, err
+			}
+		}
 		// When the transaction doesn't exist, the RPC method should return JSON null
 		// as per specification.
 		return nil, nil
@@ -1338,7 +1622,11 @@ func (s *TransactionAPI) GetTransactionReceipt(ctx context.Context, hash common.
 	receipt := receipts[index]
 
 	// Derive the sender.
-	signer := types.MakeSigner(s.b.ChainConfig())
+	header, err := s.b.HeaderByHash(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	signer := types.MakeSigner(s.b.ChainConfig(), header.Time)
 	return marshalReceipt(receipt, blockHash, blockNumber, signer, tx, int(index)), nil
 }
 
@@ -1376,6 +1664,9 @@ func marshalReceipt(receipt *types.Receipt, blockHash common.Hash, blockNumber u
 	if receipt.ContractAddress != (common.Address{}) {
 		fields["contractAddress"] = receipt.ContractAddress
 	}
+	if tx.Type() == types.AccountAbstractionTxType {
+		fields["validationGasUsed"] = hexutil.Uint64(receipt.ValidationGasUsed)
+	}
 	return fields
 }
 
@@ -1403,7 +1694,8 @@ func SubmitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (c
 		return common.Hash{}, err
 	}
 	// Print a log with full tx details for manual investigations and interventions
-	signer := types.MakeSigner(b.ChainConfig())
+	head := b.CurrentBlock()
+	signer := types.MakeSigner(b.ChainConfig(), head.Time)
 	from, err := types.Sender(signer, tx)
 	if err != nil {
 		return common.Hash{}, err
@@ -1420,13 +1712,24 @@ func SubmitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (c
 
 // SendTransaction creates a transaction for the given argument, sign it and submit it to the
 // transaction pool.
+//
+// Account abstraction transactions are authorized by their sender contract instead
+// of a local key, so they are submitted with the authorization data given in the
+// arguments.
 func (s *TransactionAPI) SendTransaction(ctx context.Context, args TransactionArgs) (common.Hash, error) {
-	// Look up the wallet containing the requested signer
-	account := accounts.Account{Address: args.from()}
+	var (
+		account accounts.Account
+		wallet  accounts.Wallet
+		err     error
+	)
+	if !args.isAccountAbstraction() {
+		// Look up the wallet containing the requested signer
+		account = accounts.Account{Address: args.from()}
 
-	wallet, err := s.b.AccountManager().Find(account)
-	if err != nil {
-		return common.Hash{}, err
+		wallet, err = s.b.AccountManager().Find(account)
+		if err != nil {
+			return common.Hash{}, err
+		}
 	}
 
 	if args.Nonce == nil {
@@ -1442,7 +1745,9 @@ func (s *TransactionAPI) SendTransaction(ctx context.Context, args TransactionAr
 	}
 	// Assemble the transaction and sign with the wallet
 	tx := args.toTransaction()
-
+	if args.isAccountAbstraction() {
+		return SubmitTransaction(ctx, s.b, tx)
+	}
 	signed, err := wallet.SignTx(account, tx, s.b.ChainConfig().ChainID)
 	if err != nil {
 		return common.Hash{}, err
@@ -1477,6 +1782,46 @@ func (s *TransactionAPI) SendRawTransaction(ctx context.Context, input hexutil.B
 	return SubmitTransaction(ctx, s.b, tx)
 }
 
+// defaultPrivateTxBlocks is the number of blocks a private transaction is kept
+// private for, if no expiry is requested explicitly.
+const defaultPrivateTxBlocks = 25
+
+// PrivateTransactionArgs represents the arguments to submit a private transaction.
+type PrivateTransactionArgs struct {
+	Tx             hexutil.Bytes   `json:"tx"`
+	MaxBlockNumber *hexutil.Uint64 `json:"maxBlockNumber"`
+}
+
+// SendPrivateTransaction submits a signed transaction to be included only in the
+// blocks built by this node. The transaction is not propagated to the network
+// until the chain reaches maxBlockNumber without including it, after which it
+// is added to the transaction pool and broadcast as usual. The miner caps how
+// far in the future maxBlockNumber may be.
+func (s *TransactionAPI) SendPrivateTransaction(ctx context.Context, args PrivateTransactionArgs) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(args.Tx); err != nil {
+		return common.Hash{}, err
+	}
+	if err := checkTxFee(tx.GasPrice(), tx.Gas(), s.b.RPCTxFeeCap()); err != nil {
+		return common.Hash{}, err
+	}
+	expiry := s.b.CurrentHeader().Number.Uint64() + defaultPrivateTxBlocks
+	if args.MaxBlockNumber != nil {
+		expiry = uint64(*args.MaxBlockNumber)
+	}
+	if err := s.b.SendPrivateTx(ctx, tx, expiry); err != nil {
+		return common.Hash{}, err
+	}
+	log.Info("Submitted private transaction", "hash", tx.Hash().Hex(), "nonce", tx.Nonce())
+	return tx.Hash(), nil
+}
+
+// CancelPrivateTransaction drops a private transaction which was not included
+// yet, returning whether the transaction was known.
+func (s *TransactionAPI) CancelPrivateTransaction(ctx context.Context, hash common.Hash) bool {
+	return s.b.CancelPrivateTx(ctx, hash)
+}
+
 // Sign calculates an ECDSA signature for:
 // keccak256("\x19QRL Signed Message:\n" + len(message) + message).
 //
@@ -0,0 +1,405 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package qrlapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/common/hexutil"
+	"github.com/theQRL/go-zond/consensus"
+	"github.com/theQRL/go-zond/consensus/misc/eip1559"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/state"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/params"
+	"github.com/theQRL/go-zond/rpc"
+	"github.com/theQRL/go-zond/trie"
+)
+
+const (
+	// maxSimulateBlocks is the maximum number of blocks that can be simulated
+	// in a single request.
+	maxSimulateBlocks = 256
+
+	// timestampIncrement is the default increment between block timestamps.
+	timestampIncrement = 12
+)
+
+// simBlock is a batch of calls to be simulated sequentially.
+type simBlock struct {
+	BlockOverrides *BlockOverrides
+	StateOverrides *StateOverride
+	Calls          []TransactionArgs
+}
+
+// simCallResult is the result of a simulated call.
+type simCallResult struct {
+	ReturnValue hexutil.Bytes  `json:"returnData"`
+	Logs        []*types.Log   `json:"logs"`
+	GasUsed     hexutil.Uint64 `json:"gasUsed"`
+	Status      hexutil.Uint64 `json:"status"`
+	Error       *callError     `json:"error,omitempty"`
+}
+
+func (r *simCallResult) MarshalJSON() ([]byte, error) {
+	type callResultAlias simCallResult
+	// Marshal logs to be an empty array instead of nil when empty
+	if r.Logs == nil {
+		r.Logs = []*types.Log{}
+	}
+	return json.Marshal((*callResultAlias)(r))
+}
+
+// simOpts are the inputs to qrl_simulateV1.
+type simOpts struct {
+	BlockStateCalls        []simBlock
+	Validation             bool
+	ReturnFullTransactions bool
+}
+
+// simulator is a stateful object that simulates a series of blocks.
+// It is not safe for concurrent use.
+type simulator struct {
+	b           Backend
+	state       *state.StateDB
+	base        *types.Header
+	chainConfig *params.ChainConfig
+	gp          *core.GasPool
+	validate    bool
+	fullTx      bool
+}
+
+// execute runs the simulation of a series of blocks.
+func (sim *simulator) execute(ctx context.Context, blocks []simBlock) ([]map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var (
+		cancel  context.CancelFunc
+		timeout = sim.b.RPCQRVMTimeout()
+	)
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	// Make sure the context is cancelled when the call has completed
+	// this makes sure resources are cleaned up.
+	defer cancel()
+
+	var err error
+	blocks, err = sim.sanitizeChain(blocks)
+	if err != nil {
+		return nil, err
+	}
+	// Prepare block headers with preliminary fields for the response.
+	headers, err := sim.makeHeaders(blocks)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		results = make([]map[string]interface{}, len(blocks))
+		parent  = sim.base
+	)
+	for bi, block := range blocks {
+		result, senders, callResults, err := sim.processBlock(ctx, &block, headers[bi], parent, headers[:bi], timeout)
+		if err != nil {
+			return nil, err
+		}
+		enc := RPCMarshalBlock(result, true, sim.fullTx, sim.chainConfig)
+		if sim.fullTx {
+			// Simulated transactions are unsigned, fill in the senders.
+			for i, tx := range enc["transactions"].([]interface{}) {
+				tx.(*RPCTransaction).From = senders[i]
+			}
+		}
+		enc["calls"] = callResults
+		results[bi] = enc
+
+		// Later blocks must reference the finalized header, which has the
+		// transaction and receipt roots and the bloom filled in.
+		headers[bi] = result.Header()
+		parent = headers[bi]
+	}
+	return results, nil
+}
+
+// processBlock executes the calls of a single simulated block on top of the
+// state left behind by the previous ones.
+func (sim *simulator) processBlock(ctx context.Context, block *simBlock, header, parent *types.Header, headers []*types.Header, timeout time.Duration) (*types.Block, []common.Address, []simCallResult, error) {
+	// Set header fields that depend only on parent block.
+	// Parent hash is needed for qrvm.GetHashFn to work.
+	header.ParentHash = parent.Hash()
+	if header.BaseFee == nil {
+		// In non-validation mode base fee is set to 0 if it is not overridden,
+		// as calls are not required to pay for gas.
+		if sim.validate {
+			header.BaseFee = eip1559.CalcBaseFee(sim.chainConfig, parent)
+		} else {
+			header.BaseFee = big.NewInt(0)
+		}
+	}
+	blockContext := core.NewQRVMBlockContext(header, sim.newSimulatedChainContext(ctx, headers), nil)
+
+	// State overrides are applied prior to execution of a block
+	if err := block.StateOverrides.Apply(sim.state); err != nil {
+		return nil, nil, nil, err
+	}
+	var (
+		gasUsed     uint64
+		txes        = make([]*types.Transaction, len(block.Calls))
+		senders     = make([]common.Address, len(block.Calls))
+		callResults = make([]simCallResult, len(block.Calls))
+		receipts    = make([]*types.Receipt, len(block.Calls))
+		vmConfig    = vm.Config{NoBaseFee: !sim.validate}
+	)
+	qrvm := vm.NewQRVM(blockContext, vm.TxContext{GasPrice: new(big.Int)}, sim.state, sim.chainConfig, vmConfig)
+	core.DeployBeaconRoots(sim.chainConfig, parent.Time, header.Time, sim.state)
+	if header.ParentBeaconRoot != nil {
+		core.ProcessBeaconBlockRoot(*header.ParentBeaconRoot, qrvm, sim.state)
+	}
+
+	// Wait for the context to be done and cancel the qrvm. Even if the
+	// QRVM has finished, cancelling may be done (repeatedly)
+	go func() {
+		<-ctx.Done()
+		qrvm.Cancel()
+	}()
+	for i, call := range block.Calls {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, nil, err
+		}
+		if err := sim.sanitizeCall(&call, header, blockContext, gasUsed); err != nil {
+			return nil, nil, nil, err
+		}
+		tx := call.ToTransaction()
+		txes[i], senders[i] = tx, call.from()
+
+		msg, err := call.ToMessage(0, header.BaseFee)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		msg.Nonce = uint64(*call.Nonce)
+		msg.SkipAccountChecks = !sim.validate
+
+		sim.state.SetTxContext(tx.Hash(), i)
+		qrvm.Reset(core.NewQRVMTxContext(msg), sim.state)
+		result, err := core.ApplyMessage(qrvm, msg, sim.gp)
+		if err := sim.state.Error(); err != nil {
+			return nil, nil, nil, err
+		}
+		if qrvm.Cancelled() {
+			return nil, nil, nil, fmt.Errorf("execution aborted (timeout = %v)", timeout)
+		}
+		if err != nil {
+			return nil, nil, nil, txValidationError(err)
+		}
+		// Update the state with pending changes.
+		sim.state.Finalise(true)
+		gasUsed += result.UsedGas
+
+		receipt := &types.Receipt{Type: tx.Type(), CumulativeGasUsed: gasUsed, TxHash: tx.Hash(), GasUsed: result.UsedGas}
+		receipt.Logs = sim.state.GetLogs(tx.Hash(), header.Number.Uint64(), common.Hash{})
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+		receipts[i] = receipt
+
+		callRes := simCallResult{ReturnValue: result.Return(), Logs: receipt.Logs, GasUsed: hexutil.Uint64(result.UsedGas)}
+		if result.Failed() {
+			receipt.Status = types.ReceiptStatusFailed
+			callRes.Status = hexutil.Uint64(types.ReceiptStatusFailed)
+			callRes.Error = newCallError(result)
+		} else {
+			receipt.Status = types.ReceiptStatusSuccessful
+			callRes.Status = hexutil.Uint64(types.ReceiptStatusSuccessful)
+		}
+		callResults[i] = callRes
+	}
+	header.Root = sim.state.IntermediateRoot(true)
+	header.GasUsed = gasUsed
+
+	b := types.NewBlock(header, &types.Body{Transactions: txes, Withdrawals: make([]*types.Withdrawal, 0)}, receipts, trie.NewStackTrie(nil))
+	repairLogs(callResults, b.Hash())
+	return b, senders, callResults, nil
+}
+
+// repairLogs updates the block hash in the logs present in the result of
+// a simulated block, and numbers the logs within the block.
+func repairLogs(calls []simCallResult, hash common.Hash) {
+	var index uint
+	for i := range calls {
+		for j := range calls[i].Logs {
+			calls[i].Logs[j].BlockHash = hash
+			calls[i].Logs[j].Index = index
+			index++
+		}
+	}
+}
+
+// sanitizeCall fills in the defaults of a simulated call and checks it fits
+// into the block.
+func (sim *simulator) sanitizeCall(call *TransactionArgs, header *types.Header, blockContext vm.BlockContext, gasUsed uint64) error {
+	if call.Nonce == nil {
+		nonce := sim.state.GetNonce(call.from())
+		call.Nonce = (*hexutil.Uint64)(&nonce)
+	}
+	// Let the call run wild unless explicitly specified.
+	if call.Gas == nil {
+		remaining := blockContext.GasLimit - gasUsed
+		call.Gas = (*hexutil.Uint64)(&remaining)
+	}
+	if gasUsed+uint64(*call.Gas) > blockContext.GasLimit {
+		return &blockGasLimitReachedError{fmt.Sprintf("block gas limit reached: %d >= %d", gasUsed, blockContext.GasLimit)}
+	}
+	if call.ChainID != nil {
+		if have, want := call.ChainID.ToInt(), sim.chainConfig.ChainID; have.Cmp(want) != 0 {
+			return &invalidParamsError{message: fmt.Sprintf("chainId does not match node's (have=%v, want=%v)", have, want)}
+		}
+	} else {
+		call.ChainID = (*hexutil.Big)(sim.chainConfig.ChainID)
+	}
+	if call.MaxFeePerGas == nil {
+		call.MaxFeePerGas = new(hexutil.Big)
+	}
+	if call.MaxPriorityFeePerGas == nil {
+		call.MaxPriorityFeePerGas = new(hexutil.Big)
+	}
+	if call.Value == nil {
+		call.Value = new(hexutil.Big)
+	}
+	return nil
+}
+
+// sanitizeChain checks the chain integrity. Specifically it checks that
+// block numbers and timestamp are strictly increasing, setting default values
+// when necessary. Gaps in block numbers are filled with empty blocks.
+func (sim *simulator) sanitizeChain(blocks []simBlock) ([]simBlock, error) {
+	var (
+		res           = make([]simBlock, 0, len(blocks))
+		base          = sim.base
+		prevNumber    = base.Number
+		prevTimestamp = base.Time
+	)
+	for _, block := range blocks {
+		if block.BlockOverrides == nil {
+			block.BlockOverrides = new(BlockOverrides)
+		}
+		if block.BlockOverrides.Number == nil {
+			n := new(big.Int).Add(prevNumber, big.NewInt(1))
+			block.BlockOverrides.Number = (*hexutil.Big)(n)
+		}
+		diff := new(big.Int).Sub(block.BlockOverrides.Number.ToInt(), prevNumber)
+		if diff.Sign() <= 0 {
+			return nil, &invalidBlockNumberError{fmt.Sprintf("block numbers must be in order: %d <= %d", block.BlockOverrides.Number.ToInt().Uint64(), prevNumber)}
+		}
+		if total := new(big.Int).Sub(block.BlockOverrides.Number.ToInt(), base.Number); total.Cmp(big.NewInt(maxSimulateBlocks)) > 0 {
+			return nil, &clientLimitExceededError{message: "too many blocks"}
+		}
+		if diff.Cmp(big.NewInt(1)) > 0 {
+			// Fill the gap with empty blocks.
+			gap := new(big.Int).Sub(diff, big.NewInt(1))
+			for i := uint64(0); i < gap.Uint64(); i++ {
+				n := new(big.Int).Add(prevNumber, big.NewInt(int64(i+1)))
+				t := prevTimestamp + timestampIncrement
+				b := simBlock{BlockOverrides: &BlockOverrides{Number: (*hexutil.Big)(n), Time: (*hexutil.Uint64)(&t)}}
+				prevTimestamp = t
+				res = append(res, b)
+			}
+		}
+		// Only append block after filling a potential gap.
+		prevNumber = block.BlockOverrides.Number.ToInt()
+		var t uint64
+		if block.BlockOverrides.Time == nil {
+			t = prevTimestamp + timestampIncrement
+			block.BlockOverrides.Time = (*hexutil.Uint64)(&t)
+		} else {
+			t = uint64(*block.BlockOverrides.Time)
+			if t <= prevTimestamp {
+				return nil, &invalidBlockTimestampError{fmt.Sprintf("block timestamps must be in order: %d <= %d", t, prevTimestamp)}
+			}
+		}
+		prevTimestamp = t
+		res = append(res, block)
+	}
+	return res, nil
+}
+
+// makeHeaders makes header object with preliminary fields based on a simulated block.
+// Some fields have to be filled post-execution.
+// It assumes blocks are in order and numbers have been validated.
+func (sim *simulator) makeHeaders(blocks []simBlock) ([]*types.Header, error) {
+	var (
+		res    = make([]*types.Header, len(blocks))
+		header = sim.base
+	)
+	for bi, block := range blocks {
+		if block.BlockOverrides == nil || block.BlockOverrides.Number == nil {
+			return nil, errors.New("empty block number")
+		}
+		header = block.BlockOverrides.MakeHeader(&types.Header{
+			ReceiptHash:     types.EmptyReceiptsHash,
+			TxHash:          types.EmptyTxsHash,
+			Coinbase:        header.Coinbase,
+			GasLimit:        header.GasLimit,
+			WithdrawalsHash: &types.EmptyWithdrawalsHash,
+		})
+		if sim.chainConfig.IsPrague(header.Time) {
+			header.ParentBeaconRoot = new(common.Hash)
+		}
+		res[bi] = header
+	}
+	return res, nil
+}
+
+func (sim *simulator) newSimulatedChainContext(ctx context.Context, headers []*types.Header) *ChainContext {
+	return NewChainContext(ctx, &simBackend{base: sim.base, b: sim.b, headers: headers})
+}
+
+// simBackend resolves headers of both the canonical chain and the blocks
+// simulated so far.
+type simBackend struct {
+	b       ChainContextBackend
+	base    *types.Header
+	headers []*types.Header
+}
+
+func (b *simBackend) Engine() consensus.Engine {
+	return b.b.Engine()
+}
+
+func (b *simBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
+	if uint64(number) == b.base.Number.Uint64() {
+		return b.base, nil
+	}
+	if uint64(number) < b.base.Number.Uint64() {
+		// Resolve canonical header.
+		return b.b.HeaderByNumber(ctx, number)
+	}
+	// Simulated block.
+	for _, header := range b.headers {
+		if header.Number.Uint64() == uint64(number) {
+			return header, nil
+		}
+	}
+	return nil, errors.New("header not found")
+}
@@ -51,6 +51,12 @@ type TransactionArgs struct {
 
 	AccessList *types.AccessList `json:"accessList,omitempty"`
 	ChainID    *hexutil.Big      `json:"chainId,omitempty"`
+
+	// Introduced by account abstraction transactions. If ValidationGas is set,
+	// From is the sender contract and Signature the authorization data it
+	// validates.
+	ValidationGas *hexutil.Uint64 `json:"validationGas,omitempty"`
+	Signature     *hexutil.Bytes  `json:"signature,omitempty"`
 }
 
 // from retrieves the transaction sender address.
@@ -61,6 +67,20 @@ func (args *TransactionArgs) from() common.Address {
 	return *args.From
 }
 
+// isAccountAbstraction returns whether the arguments describe an account
+// abstraction transaction.
+func (args *TransactionArgs) isAccountAbstraction() bool {
+	return args.ValidationGas != nil
+}
+
+// signature retrieves the authorization data of an account abstraction transaction.
+func (args *TransactionArgs) signature() []byte {
+	if args.Signature != nil {
+		return *args.Signature
+	}
+	return nil
+}
+
 // data retrieves the transaction calldata. Input field is preferred.
 func (args *TransactionArgs) data() []byte {
 	if args.Input != nil {
@@ -106,6 +126,8 @@ func (args *TransactionArgs) setDefaults(ctx context.Context, b Backend) error {
 			Value:                args.Value,
 			Data:                 (*hexutil.Bytes)(&data),
 			AccessList:           args.AccessList,
+			ValidationGas:        args.ValidationGas,
+			Signature:            args.Signature,
 		}
 		pendingBlockNr := rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber)
 		estimated, err := DoEstimateGas(ctx, b, callArgs, pendingBlockNr, nil, b.RPCGasCap())
@@ -241,6 +263,11 @@ func (args *TransactionArgs) ToMessage(globalGasCap uint64, baseFee *big.Int) (*
 		AccessList:        accessList,
 		SkipAccountChecks: true,
 	}
+	if args.isAccountAbstraction() {
+		msg.IsAccountAbstraction = true
+		msg.ValidationGas = uint64(*args.ValidationGas)
+		msg.Authorization = args.signature()
+	}
 	return msg, nil
 }
 
@@ -254,16 +281,34 @@ func (args *TransactionArgs) toTransaction() *types.Transaction {
 		al = *args.AccessList
 	}
 
-	data = &types.DynamicFeeTx{
-		To:         args.To,
-		ChainID:    (*big.Int)(args.ChainID),
-		Nonce:      uint64(*args.Nonce),
-		Gas:        uint64(*args.Gas),
-		GasFeeCap:  (*big.Int)(args.MaxFeePerGas),
-		GasTipCap:  (*big.Int)(args.MaxPriorityFeePerGas),
-		Value:      (*big.Int)(args.Value),
-		Data:       args.data(),
-		AccessList: al,
+	switch {
+	case args.isAccountAbstraction():
+		data = &types.AccountAbstractionTx{
+			To:            args.To,
+			ChainID:       (*big.Int)(args.ChainID),
+			Nonce:         uint64(*args.Nonce),
+			Gas:           uint64(*args.Gas),
+			ValidationGas: uint64(*args.ValidationGas),
+			Sender:        args.from(),
+			GasFeeCap:     (*big.Int)(args.MaxFeePerGas),
+			GasTipCap:     (*big.Int)(args.MaxPriorityFeePerGas),
+			Value:         (*big.Int)(args.Value),
+			Data:          args.data(),
+			AccessList:    al,
+			Signature:     args.signature(),
+		}
+	default:
+		data = &types.DynamicFeeTx{
+			To:         args.To,
+			ChainID:    (*big.Int)(args.ChainID),
+			Nonce:      uint64(*args.Nonce),
+			Gas:        uint64(*args.Gas),
+			GasFeeCap:  (*big.Int)(args.MaxFeePerGas),
+			GasTipCap:  (*big.Int)(args.MaxPriorityFeePerGas),
+			Value:      (*big.Int)(args.Value),
+			Data:       args.data(),
+			AccessList: al,
+		}
 	}
 
 	return types.NewTx(data)
@@ -335,6 +335,12 @@ web3._extend({
 			params: 3,
 			inputFormatter: [null, null, null]
 		}),
+		new web3._extend.Method({
+			name: 'traceCallMany',
+			call: 'debug_traceCallMany',
+			params: 3,
+			inputFormatter: [null, null, null]
+		}),
 		new web3._extend.Method({
 			name: 'preimage',
 			call: 'debug_preimage',
@@ -492,6 +498,18 @@ web3._extend({
 			params: 3,
 			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, web3._extend.formatters.inputBlockNumberFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'getAccount',
+			call: 'qrl_getAccount',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getAccounts',
+			call: 'qrl_getAccounts',
+			params: 2,
+			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
 		new web3._extend.Method({
 			name: 'createAccessList',
 			call: 'qrl_createAccessList',
@@ -520,6 +538,22 @@ web3._extend({
 			call: 'qrl_getBlockReceipts',
 			params: 1,
 		}),
+		new web3._extend.Method({
+			name: 'simulateV1',
+			call: 'qrl_simulateV1',
+			params: 2,
+			inputFormatter: [null, web3._extend.formatters.inputDefaultBlockNumberFormatter],
+		}),
+		new web3._extend.Method({
+			name: 'sendPrivateTransaction',
+			call: 'qrl_sendPrivateTransaction',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'cancelPrivateTransaction',
+			call: 'qrl_cancelPrivateTransaction',
+			params: 1,
+		}),
 	],
 	properties: [
 		new web3._extend.Property({
@@ -30,6 +30,7 @@ import (
 	"github.com/theQRL/go-zond/core/state"
 	"github.com/theQRL/go-zond/core/txpool"
 	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/log"
 	"github.com/theQRL/go-zond/params"
 )
 
@@ -47,6 +48,9 @@ type Config struct {
 	GasCeil             uint64         // Target gas ceiling for mined blocks.
 	GasPrice            *big.Int       // Minimum gas price for mining a transaction
 	Recommit            time.Duration  // The time interval for miner to re-create mining work.
+	Ordering            string         // Strategy for selecting and ordering the payload transactions
+	MaxTxsPerSender     int            // Maximum transactions per sender with the fair ordering
+	Bundles             BundleSource   `toml:"-"` // External source of bundles to place at the top of the payloads
 }
 
 // DefaultConfig contains default settings for miner.
@@ -59,30 +63,45 @@ var DefaultConfig = Config{
 	// for payload generation. It should be enough for Gzond to
 	// run 3 rounds.
 	Recommit: 10 * time.Second,
+
+	Ordering:        OrderingLocal,
+	MaxTxsPerSender: 16,
 }
 
 // Miner is the main object which takes care of submitting new work to consensus
 // engine and gathering the sealing result.
 type Miner struct {
-	confMu      sync.RWMutex // The lock used to protect the config fields: GasCeil, GasTip and Extradata
+	confMu      sync.RWMutex // The lock used to protect the config fields: GasCeil, GasTip, Extradata and the ordering
 	config      *Config
+	ordering    OrderingStrategy // Strategy selecting the transactions of the payloads
 	chainConfig *params.ChainConfig
 	engine      consensus.Engine
 	txpool      *txpool.TxPool
 	chain       *core.BlockChain
 	pending     *pending
-	pendingMu   sync.Mutex // Lock protects the pending block
+	pendingMu   sync.Mutex  // Lock protects the pending block
+	private     *privateTxs // Transactions to include without propagating them
 }
 
 // New creates a new miner with provided config.
 func New(qrl Backend, config Config, engine consensus.Engine) *Miner {
+	ordering, err := NewOrderingStrategy(&config)
+	if err != nil {
+		log.Warn("Invalid transaction ordering, using default", "ordering", config.Ordering, "err", err)
+		ordering = localStrategy{}
+	}
+	if config.Bundles != nil {
+		ordering = NewBundleStrategy(config.Bundles, ordering)
+	}
 	return &Miner{
 		config:      &config,
+		ordering:    ordering,
 		chainConfig: qrl.BlockChain().Config(),
 		engine:      engine,
 		txpool:      qrl.TxPool(),
 		chain:       qrl.BlockChain(),
 		pending:     &pending{},
+		private:     newPrivateTxs(),
 	}
 }
 
@@ -124,6 +143,14 @@ func (miner *Miner) SetGasTip(tip *big.Int) error {
 	return nil
 }
 
+// SetOrderingStrategy replaces the strategy selecting and ordering the transactions
+// of the payloads built from now on.
+func (miner *Miner) SetOrderingStrategy(ordering OrderingStrategy) {
+	miner.confMu.Lock()
+	miner.ordering = ordering
+	miner.confMu.Unlock()
+}
+
 // BuildPayload builds the payload according to the provided parameters.
 func (miner *Miner) BuildPayload(args *BuildPayloadArgs) (*Payload, error) {
 	return miner.buildPayload(args)
@@ -147,6 +174,7 @@ func (miner *Miner) getPending() *newPayloadResult {
 		random:      common.Hash{},
 		withdrawals: []*types.Withdrawal{},
 		noTxs:       false,
+		noPrivate:   true, // the pending block is public, don't leak private txs
 	})
 	if ret.err != nil {
 		return nil
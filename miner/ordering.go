@@ -80,14 +80,55 @@ func (s *txByPriceAndTime) Pop() interface{} {
 	return x
 }
 
+// txByTimeAndPrice orders transactions by the time they were first seen, using
+// the effective miner tip to break the ties.
+type txByTimeAndPrice struct {
+	txByPriceAndTime
+}
+
+func (s txByTimeAndPrice) Less(i, j int) bool {
+	a, b := s.txByPriceAndTime[i], s.txByPriceAndTime[j]
+	if a.tx.Time.Equal(b.tx.Time) {
+		return a.fees.Cmp(b.fees) > 0
+	}
+	return a.tx.Time.Before(b.tx.Time)
+}
+
+// TransactionOrdering is an iterator over a set of pending transactions in the
+// order they should be committed into a payload. Transactions of the same
+// account are always returned in nonce order.
+type TransactionOrdering interface {
+	// Peek returns the next transaction to commit along with its effective
+	// miner tip, or nil if the set is exhausted.
+	Peek() (*txpool.LazyTransaction, *big.Int)
+
+	// Shift replaces the current transaction with the next one from the same
+	// account, called after the transaction was committed.
+	Shift()
+
+	// Pop removes the current transaction along with all the subsequent ones
+	// from the same account, called if the transaction cannot be executed.
+	Pop()
+
+	// Empty returns whether the set is exhausted.
+	Empty() bool
+
+	// Clear removes the entire content of the set.
+	Clear()
+}
+
 // transactionsByPriceAndNonce represents a set of transactions that can return
-// transactions in a profit-maximizing sorted order, while supporting removing
-// entire batches of transactions for non-executable accounts.
+// transactions in a profit-maximizing (or arrival) sorted order, while supporting
+// removing entire batches of transactions for non-executable accounts.
 type transactionsByPriceAndNonce struct {
 	txs     map[common.Address][]*txpool.LazyTransaction // Per account nonce-sorted list of transactions
-	heads   txByPriceAndTime                             // Next transaction for each unique account (price heap)
+	heads   *txByPriceAndTime                            // Next transaction for each unique account
+	order   heap.Interface                               // Heap view over the heads (by price or by time)
 	signer  types.Signer                                 // Signer for the set of transactions
 	baseFee *big.Int                                     // Current base fee
+
+	limit  int                    // Maximum number of transactions per account (0 = unlimited)
+	counts map[common.Address]int // Number of transactions shifted out per account
 }
 
 // newTransactionsByPriceAndNonce creates a transaction set that can retrieve
@@ -96,63 +137,93 @@ type transactionsByPriceAndNonce struct {
 // Note, the input map is reowned so the caller should not interact any more with
 // if after providing it to the constructor.
 func newTransactionsByPriceAndNonce(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) *transactionsByPriceAndNonce {
-	// Initialize a price and received time based heap with the head transactions
-	heads := make(txByPriceAndTime, 0, len(txs))
+	heads := new(txByPriceAndTime)
+	return newTransactionsByNonce(signer, txs, baseFee, heads, heads)
+}
+
+// newTransactionsByTimeAndNonce creates a transaction set that can retrieve
+// transactions in the order they were first seen, in a nonce-honouring way.
+//
+// Note, the input map is reowned so the caller should not interact any more with
+// if after providing it to the constructor.
+func newTransactionsByTimeAndNonce(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) *transactionsByPriceAndNonce {
+	order := new(txByTimeAndPrice)
+	return newTransactionsByNonce(signer, txs, baseFee, &order.txByPriceAndTime, order)
+}
+
+// newTransactionsByNonce creates a transaction set sorting the account heads
+// with the given heap view.
+func newTransactionsByNonce(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int, heads *txByPriceAndTime, order heap.Interface) *transactionsByPriceAndNonce {
+	// Initialize the heap with the head transactions
+	*heads = make(txByPriceAndTime, 0, len(txs))
 	for from, accTxs := range txs {
 		wrapped, err := newTxWithMinerFee(accTxs[0], from, baseFee)
 		if err != nil {
 			delete(txs, from)
 			continue
 		}
-		heads = append(heads, wrapped)
+		*heads = append(*heads, wrapped)
 		txs[from] = accTxs[1:]
 	}
-	heap.Init(&heads)
+	heap.Init(order)
 
 	// Assemble and return the transaction set
 	return &transactionsByPriceAndNonce{
 		txs:     txs,
 		heads:   heads,
+		order:   order,
 		signer:  signer,
 		baseFee: baseFee,
 	}
 }
 
-// Peek returns the next transaction by price.
+// limitPerAccount caps the number of transactions retrieved from any single
+// account, leaving room in the block for the others.
+func (t *transactionsByPriceAndNonce) limitPerAccount(limit int) {
+	t.limit, t.counts = limit, make(map[common.Address]int)
+}
+
+// Peek returns the next transaction by price (or time).
 func (t *transactionsByPriceAndNonce) Peek() (*txpool.LazyTransaction, *big.Int) {
-	if len(t.heads) == 0 {
+	if len(*t.heads) == 0 {
 		return nil, nil
 	}
-	return t.heads[0].tx, t.heads[0].fees
+	return (*t.heads)[0].tx, (*t.heads)[0].fees
 }
 
 // Shift replaces the current best head with the next one from the same account.
 func (t *transactionsByPriceAndNonce) Shift() {
-	acc := t.heads[0].from
+	acc := (*t.heads)[0].from
+	if t.limit > 0 {
+		if t.counts[acc]++; t.counts[acc] >= t.limit {
+			heap.Pop(t.order)
+			return
+		}
+	}
 	if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
 		if wrapped, err := newTxWithMinerFee(txs[0], acc, t.baseFee); err == nil {
-			t.heads[0], t.txs[acc] = wrapped, txs[1:]
-			heap.Fix(&t.heads, 0)
+			(*t.heads)[0], t.txs[acc] = wrapped, txs[1:]
+			heap.Fix(t.order, 0)
 			return
 		}
 	}
-	heap.Pop(&t.heads)
+	heap.Pop(t.order)
 }
 
 // Pop removes the best transaction, *not* replacing it with the next one from
 // the same account. This should be used when a transaction cannot be executed
 // and hence all subsequent ones should be discarded from the same account.
 func (t *transactionsByPriceAndNonce) Pop() {
-	heap.Pop(&t.heads)
+	heap.Pop(t.order)
 }
 
 // Empty returns if the price heap is empty. It can be used to check it simpler
 // than calling peek and checking for nil return.
 func (t *transactionsByPriceAndNonce) Empty() bool {
-	return len(t.heads) == 0
+	return len(*t.heads) == 0
 }
 
 // Clear removes the entire content of the heap.
 func (t *transactionsByPriceAndNonce) Clear() {
-	t.heads, t.txs = nil, nil
+	*t.heads, t.txs = nil, nil
 }
@@ -22,11 +22,11 @@ import (
 	"testing"
 	"time"
 
-	walletmldsa87 "github.com/theQRL/go-qrllib/wallet/ml_dsa_87"
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/core/txpool"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/crypto"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
 )
 
 func TestTransactionPriceNonceSort1559(t *testing.T) {
@@ -41,7 +41,7 @@ func TestTransactionPriceNonceSort1559(t *testing.T) {
 // the same account.
 func testTransactionPriceNonceSort(t *testing.T, baseFee *big.Int) {
 	// Generate a batch of accounts to start with
-	keys := make([]*walletmldsa87.Wallet, 25)
+	keys := make([]pqcrypto.Wallet, 25)
 	for i := 0; i < len(keys); i++ {
 		keys[i], _ = crypto.GenerateMLDSA87Key()
 	}
@@ -125,7 +125,7 @@ func testTransactionPriceNonceSort(t *testing.T, baseFee *big.Int) {
 func TestTransactionTimeSort(t *testing.T) {
 	t.Parallel()
 	// Generate a batch of accounts to start with
-	keys := make([]*walletmldsa87.Wallet, 5)
+	keys := make([]pqcrypto.Wallet, 5)
 	for i := 0; i < len(keys); i++ {
 		keys[i], _ = crypto.GenerateMLDSA87Key()
 	}
@@ -41,6 +41,7 @@ type BuildPayloadArgs struct {
 	FeeRecipient common.Address    // The provided recipient address for collecting transaction fee
 	Random       common.Hash       // The provided randomness value
 	Withdrawals  types.Withdrawals // The provided withdrawals
+	BeaconRoot   *common.Hash      // The provided beaconRoot (Prague)
 }
 
 // Id computes an 8-byte identifier by hashing the components of the payload arguments.
@@ -51,6 +52,9 @@ func (args *BuildPayloadArgs) Id() engine.PayloadID {
 	hasher.Write(args.Random[:])
 	hasher.Write(args.FeeRecipient[:])
 	rlp.Encode(hasher, args.Withdrawals)
+	if args.BeaconRoot != nil {
+		hasher.Write(args.BeaconRoot[:])
+	}
 	var out engine.PayloadID
 	copy(out[:], hasher.Sum(nil)[:8])
 	return out
@@ -66,6 +70,7 @@ type Payload struct {
 	empty    *types.Block
 	full     *types.Block
 	fullFees *big.Int
+	ordering string // Ordering strategy of the full block
 	stop     chan struct{}
 	lock     sync.Mutex
 	cond     *sync.Cond
@@ -99,6 +104,7 @@ func (payload *Payload) update(r *newPayloadResult, elapsed time.Duration) {
 	if payload.full == nil || r.fees.Cmp(payload.fullFees) > 0 {
 		payload.full = r.block
 		payload.fullFees = r.fees
+		payload.ordering = r.ordering
 
 		feesInQuanta := new(big.Float).Quo(new(big.Float).SetInt(r.fees), big.NewFloat(params.Quanta))
 		log.Info("Updated payload",
@@ -109,6 +115,7 @@ func (payload *Payload) update(r *newPayloadResult, elapsed time.Duration) {
 			"withdrawals", len(r.block.Withdrawals()),
 			"gas", r.block.GasUsed(),
 			"fees", feesInQuanta,
+			"ordering", r.ordering,
 			"root", r.block.Root(),
 			"elapsed", common.PrettyDuration(elapsed),
 		)
@@ -128,9 +135,9 @@ func (payload *Payload) Resolve() *engine.ExecutionPayloadEnvelope {
 		close(payload.stop)
 	}
 	if payload.full != nil {
-		return engine.BlockToExecutableData(payload.full, payload.fullFees)
+		return envelope(payload.full, payload.fullFees, payload.ordering)
 	}
-	return engine.BlockToExecutableData(payload.empty, big.NewInt(0))
+	return envelope(payload.empty, big.NewInt(0), "")
 }
 
 // ResolveEmpty is basically identical to Resolve, but it expects empty block only.
@@ -139,7 +146,7 @@ func (payload *Payload) ResolveEmpty() *engine.ExecutionPayloadEnvelope {
 	payload.lock.Lock()
 	defer payload.lock.Unlock()
 
-	return engine.BlockToExecutableData(payload.empty, big.NewInt(0))
+	return envelope(payload.empty, big.NewInt(0), "")
 }
 
 // ResolveFull is basically identical to Resolve, but it expects full block only.
@@ -165,7 +172,23 @@ func (payload *Payload) ResolveFull() *engine.ExecutionPayloadEnvelope {
 	default:
 		close(payload.stop)
 	}
-	return engine.BlockToExecutableData(payload.full, payload.fullFees)
+	return envelope(payload.full, payload.fullFees, payload.ordering)
+}
+
+// envelope converts a built block into its engine API representation, reporting
+// the fee revenue of the block.
+func envelope(block *types.Block, fees *big.Int, ordering string) *engine.ExecutionPayloadEnvelope {
+	burnt := new(big.Int)
+	if baseFee := block.BaseFee(); baseFee != nil {
+		burnt.Mul(baseFee, new(big.Int).SetUint64(block.GasUsed()))
+	}
+	env := engine.BlockToExecutableData(block, fees)
+	env.Revenue = &engine.PayloadRevenue{
+		Tips:      fees,
+		BurntFees: burnt,
+		Ordering:  ordering,
+	}
+	return env
 }
 
 // buildPayload builds the payload according to the provided parameters.
@@ -180,6 +203,7 @@ func (miner *Miner) buildPayload(args *BuildPayloadArgs) (*Payload, error) {
 		coinbase:    args.FeeRecipient,
 		random:      args.Random,
 		withdrawals: args.Withdrawals,
+		beaconRoot:  args.BeaconRoot,
 		noTxs:       true,
 	}
 	empty := miner.generateWork(emptyParams)
@@ -210,6 +234,7 @@ func (miner *Miner) buildPayload(args *BuildPayloadArgs) (*Payload, error) {
 			coinbase:    args.FeeRecipient,
 			random:      args.Random,
 			withdrawals: args.Withdrawals,
+			beaconRoot:  args.BeaconRoot,
 			noTxs:       false,
 		}
 
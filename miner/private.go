@@ -0,0 +1,285 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/txpool"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/log"
+)
+
+const (
+	// privateTxMaxSize is the maximum size a single private transaction can have,
+	// matching the limit of the legacy transaction pool.
+	privateTxMaxSize = 128 * 1024
+
+	// maxPrivateTxsPerAccount is the maximum number of private transactions a
+	// single account may have waiting for inclusion at the same time.
+	maxPrivateTxsPerAccount = 16
+
+	// maxPrivateTxs is the maximum number of private transactions waiting for
+	// inclusion across all accounts.
+	maxPrivateTxs = 1024
+
+	// maxPrivateTxBlocks is the maximum number of blocks a transaction can be
+	// kept private for. Later expiries are capped to this.
+	maxPrivateTxBlocks = 256
+)
+
+var (
+	// errPrivateTxExpired is returned if a private transaction is submitted with
+	// an expiry block which was already reached by the chain.
+	errPrivateTxExpired = errors.New("private transaction expiry already reached")
+
+	// errPrivateTxsFull is returned if a new private transaction is submitted
+	// while the maximum number of private transactions is already tracked.
+	errPrivateTxsFull = errors.New("private transaction limit reached")
+)
+
+// privateTx is a transaction submitted to be included in blocks built by this
+// node only. It is kept out of the transaction pool so it's never propagated
+// to the network.
+type privateTx struct {
+	tx     *types.Transaction
+	from   common.Address
+	expiry uint64 // Block number until which the transaction is kept private
+}
+
+// privateTxs is the set of private transactions waiting for inclusion.
+type privateTxs struct {
+	txs  map[common.Hash]*privateTx
+	lock sync.RWMutex
+}
+
+// newPrivateTxs creates an empty set of private transactions.
+func newPrivateTxs() *privateTxs {
+	return &privateTxs{txs: make(map[common.Hash]*privateTx)}
+}
+
+// pending returns the private transactions grouped by sender and sorted by
+// nonce, ready to be committed into a block.
+func (p *privateTxs) pending() map[common.Address][]*txpool.LazyTransaction {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	pending := make(map[common.Address][]*txpool.LazyTransaction)
+	for _, ptx := range p.txs {
+		pending[ptx.from] = append(pending[ptx.from], &txpool.LazyTransaction{
+			Hash:      ptx.tx.Hash(),
+			Tx:        ptx.tx,
+			Time:      ptx.tx.Time(),
+			GasFeeCap: ptx.tx.GasFeeCap(),
+			GasTipCap: ptx.tx.GasTipCap(),
+			Gas:       ptx.tx.Gas(),
+		})
+	}
+	for _, txs := range pending {
+		sort.Slice(txs, func(i, j int) bool { return txs[i].Tx.Nonce() < txs[j].Tx.Nonce() })
+	}
+	return pending
+}
+
+// find returns the private transaction of an account with the given nonce, if
+// any. The caller must hold the lock.
+func (p *privateTxs) find(from common.Address, nonce uint64) *privateTx {
+	for _, ptx := range p.txs {
+		if ptx.from == from && ptx.tx.Nonce() == nonce {
+			return ptx
+		}
+	}
+	return nil
+}
+
+// AddPrivateTx submits a transaction to be included in the payloads built by
+// this node only, up to and including the block with the given number, which
+// is capped to maxPrivateTxBlocks ahead of the current head. The transaction is
+// never propagated to the network by the miner, it is up to the caller to
+// publish it once it expires.
+//
+// Private transactions are validated the same way as the local transactions of
+// the pool, and replace any private transaction of the same sender and nonce.
+func (miner *Miner) AddPrivateTx(tx *types.Transaction, expiry uint64) error {
+	head := miner.chain.CurrentHeader()
+	if expiry <= head.Number.Uint64() {
+		return fmt.Errorf("%w: expiry %d, head %d", errPrivateTxExpired, expiry, head.Number)
+	}
+	if limit := head.Number.Uint64() + maxPrivateTxBlocks; expiry > limit {
+		expiry = limit
+	}
+	// Ensure the transaction adheres to the consensus rules and to the limits of
+	// the local pool, private transactions are local so the tip is not enforced
+	signer := types.LatestSigner(miner.chainConfig)
+	opts := &txpool.ValidationOptions{
+		Config: miner.chainConfig,
+		Accept: 0 |
+			1<<types.DynamicFeeTxType |
+			1<<types.AccountAbstractionTxType,
+		MaxSize: privateTxMaxSize,
+		MinTip:  new(big.Int),
+	}
+	if err := txpool.ValidateTransaction(tx, head, signer, opts); err != nil {
+		return err
+	}
+	from, _ := types.Sender(signer, tx) // already validated above
+
+	statedb, err := miner.chain.StateAt(head.Root)
+	if err != nil {
+		return err
+	}
+	miner.private.lock.Lock()
+	defer miner.private.lock.Unlock()
+
+	if _, ok := miner.private.txs[tx.Hash()]; ok {
+		return txpool.ErrAlreadyKnown
+	}
+	prev := miner.private.find(from, tx.Nonce())
+	if prev == nil && len(miner.private.txs) >= maxPrivateTxs {
+		return fmt.Errorf("%w: tracking %d txs", errPrivateTxsFull, len(miner.private.txs))
+	}
+	// Ensure the sender can afford the transaction on top of its other private
+	// ones and that it doesn't go over its share of private transactions. The
+	// sender contract of account abstraction transactions must approve them,
+	// validated in the context of the next block as for pooled transactions.
+	stateOpts := &txpool.ValidationOptionsWithState{
+		State: statedb,
+		Validation: &txpool.ValidationEnv{
+			Config:  miner.chainConfig,
+			Context: core.NewQRVMPendingBlockContext(miner.chainConfig, head, miner.chain),
+			State:   statedb,
+		},
+
+		UsedAndLeftSlots: func(addr common.Address) (int, int) {
+			var have int
+			for _, ptx := range miner.private.txs {
+				if ptx.from == addr {
+					have++
+				}
+			}
+			return have, maxPrivateTxsPerAccount - have
+		},
+		ExistingExpenditure: func(addr common.Address) *big.Int {
+			spent := new(big.Int)
+			for _, ptx := range miner.private.txs {
+				if ptx.from == addr {
+					spent.Add(spent, ptx.tx.Cost())
+				}
+			}
+			return spent
+		},
+		ExistingCost: func(addr common.Address, nonce uint64) *big.Int {
+			if ptx := miner.private.find(addr, nonce); ptx != nil {
+				return ptx.tx.Cost()
+			}
+			return nil
+		},
+	}
+	if err := txpool.ValidateTransactionWithState(tx, signer, stateOpts); err != nil {
+		return err
+	}
+	if prev != nil {
+		delete(miner.private.txs, prev.tx.Hash())
+		log.Debug("Replaced private transaction", "hash", prev.tx.Hash(), "replacement", tx.Hash())
+	}
+	miner.private.txs[tx.Hash()] = &privateTx{tx: tx, from: from, expiry: expiry}
+	log.Debug("Added private transaction", "hash", tx.Hash(), "from", from, "nonce", tx.Nonce(), "expiry", expiry)
+	return nil
+}
+
+// CancelPrivateTx drops a private transaction before its inclusion, reporting
+// whether it was known.
+func (miner *Miner) CancelPrivateTx(hash common.Hash) bool {
+	miner.private.lock.Lock()
+	defer miner.private.lock.Unlock()
+
+	if _, ok := miner.private.txs[hash]; !ok {
+		return false
+	}
+	delete(miner.private.txs, hash)
+	log.Debug("Cancelled private transaction", "hash", hash)
+	return true
+}
+
+// ExpirePrivateTxs updates the private transactions to the given chain head,
+// dropping the ones which were included (or invalidated by the nonce of their
+// sender) and returning the ones which reached their expiry without inclusion.
+// The expired transactions are not tracked by the miner any more.
+func (miner *Miner) ExpirePrivateTxs(head *types.Header) []*types.Transaction {
+	miner.private.lock.Lock()
+	defer miner.private.lock.Unlock()
+
+	if len(miner.private.txs) == 0 {
+		return nil
+	}
+	statedb, err := miner.chain.StateAt(head.Root)
+	if err != nil {
+		log.Warn("Failed to update private transactions", "number", head.Number, "err", err)
+		return nil
+	}
+	var expired []*types.Transaction
+	for hash, ptx := range miner.private.txs {
+		switch {
+		case ptx.tx.Nonce() < statedb.GetNonce(ptx.from):
+			delete(miner.private.txs, hash)
+		case ptx.expiry <= head.Number.Uint64():
+			delete(miner.private.txs, hash)
+			expired = append(expired, ptx.tx)
+		}
+	}
+	sort.Slice(expired, func(i, j int) bool { return expired[i].Nonce() < expired[j].Nonce() })
+	return expired
+}
+
+// mergePrivateTxs merges the private transactions of an account into its pooled
+// ones, ordered by nonce. On nonce collisions, the private transaction wins.
+func mergePrivateTxs(private, pooled []*txpool.LazyTransaction) []*txpool.LazyTransaction {
+	type nonced struct {
+		ltx   *txpool.LazyTransaction
+		nonce uint64
+	}
+	var (
+		merged = make([]nonced, 0, len(private)+len(pooled))
+		taken  = make(map[uint64]struct{}, len(private))
+	)
+	for _, ltx := range private {
+		merged = append(merged, nonced{ltx, ltx.Tx.Nonce()})
+		taken[ltx.Tx.Nonce()] = struct{}{}
+	}
+	for _, ltx := range pooled {
+		tx := ltx.Resolve()
+		if tx == nil {
+			continue
+		}
+		if _, ok := taken[tx.Nonce()]; !ok {
+			merged = append(merged, nonced{ltx, tx.Nonce()})
+		}
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].nonce < merged[j].nonce })
+
+	txs := make([]*txpool.LazyTransaction, len(merged))
+	for i, item := range merged {
+		txs[i] = item.ltx
+	}
+	return txs
+}
@@ -0,0 +1,197 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/consensus/beacon"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/txpool"
+	"github.com/theQRL/go-zond/core/txpool/legacypool"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/params"
+)
+
+// Tests that private transactions are included in the built payloads, but are
+// left out of the pool and the pending block, and handed back once expired.
+func TestPrivateTransactions(t *testing.T) {
+	w, b := newTestWorker(t, params.TestChainConfig, beacon.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+	private := newTxs[0]
+
+	if err := w.AddPrivateTx(private, 0); !errors.Is(err, errPrivateTxExpired) {
+		t.Fatalf("expired submission error mismatch: have %v, want %v", err, errPrivateTxExpired)
+	}
+	if err := w.AddPrivateTx(private, 1); err != nil {
+		t.Fatalf("failed to add private transaction: %v", err)
+	}
+	if b.txPool.Has(private.Hash()) {
+		t.Fatalf("private transaction leaked into the pool")
+	}
+	// The pending block is public, it must not contain the private transaction
+	block, _, _ := w.Pending()
+	if block == nil {
+		t.Fatalf("failed to build pending block")
+	}
+	for _, tx := range block.Transactions() {
+		if tx.Hash() == private.Hash() {
+			t.Fatalf("private transaction leaked into the pending block")
+		}
+	}
+	// Payloads built for the consensus layer must contain it
+	payload, err := w.buildPayload(&BuildPayloadArgs{
+		Parent:    b.chain.CurrentBlock().Hash(),
+		Timestamp: uint64(time.Now().Unix()),
+	})
+	if err != nil {
+		t.Fatalf("failed to build payload: %v", err)
+	}
+	full := payload.ResolveFull().ExecutionPayload
+	if have, want := len(full.Transactions), len(pendingTxs)+1; have != want {
+		t.Fatalf("payload transaction count mismatch: have %d, want %d", have, want)
+	}
+	// Cancelling drops the transaction
+	if !w.CancelPrivateTx(private.Hash()) {
+		t.Fatalf("failed to cancel private transaction")
+	}
+	if w.CancelPrivateTx(private.Hash()) {
+		t.Fatalf("cancelled private transaction still tracked")
+	}
+	// Reaching the expiry hands the transaction back for publishing
+	if err := w.AddPrivateTx(private, 1); err != nil {
+		t.Fatalf("failed to add private transaction: %v", err)
+	}
+	head := types.CopyHeader(b.chain.CurrentBlock())
+	if expired := w.ExpirePrivateTxs(head); len(expired) != 0 {
+		t.Fatalf("private transaction expired early: %v", expired)
+	}
+	head.Number = big.NewInt(1)
+	expired := w.ExpirePrivateTxs(head)
+	if len(expired) != 1 || expired[0].Hash() != private.Hash() {
+		t.Fatalf("expired transactions mismatch: have %v, want [%x]", expired, private.Hash())
+	}
+	if len(w.private.pending()) != 0 {
+		t.Fatalf("expired private transaction still tracked")
+	}
+}
+
+// Tests that private transactions are validated like pooled ones, that their
+// number is capped per sender and that their expiry is capped too.
+func TestPrivateTransactionLimits(t *testing.T) {
+	w, b := newTestWorker(t, params.TestChainConfig, beacon.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+
+	makeTx := func(nonce uint64, chainID *big.Int, value *big.Int) *types.Transaction {
+		return types.MustSignNewTx(testBankKey, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     nonce,
+			To:        &testUserAddress,
+			Value:     value,
+			Gas:       params.TxGas,
+			GasFeeCap: big.NewInt(params.InitialBaseFee),
+		})
+	}
+	chainID := params.TestChainConfig.ChainID
+
+	// Invalid and unaffordable transactions are rejected
+	if err := w.AddPrivateTx(makeTx(1, big.NewInt(1337), common.Big0), 1); err == nil {
+		t.Fatalf("transaction with wrong chain id accepted")
+	}
+	if err := w.AddPrivateTx(makeTx(1, chainID, testBankFunds), 1); !errors.Is(err, core.ErrInsufficientFunds) {
+		t.Fatalf("unaffordable transaction error mismatch: have %v, want %v", err, core.ErrInsufficientFunds)
+	}
+	// Far away expiries are capped
+	first := makeTx(1, chainID, common.Big0)
+	if err := w.AddPrivateTx(first, math.MaxUint64); err != nil {
+		t.Fatalf("failed to add private transaction: %v", err)
+	}
+	if have, want := w.private.txs[first.Hash()].expiry, b.chain.CurrentBlock().Number.Uint64()+maxPrivateTxBlocks; have != want {
+		t.Fatalf("expiry mismatch: have %d, want %d", have, want)
+	}
+	// Transactions with the same nonce replace each other
+	replacement := makeTx(1, chainID, common.Big1)
+	if err := w.AddPrivateTx(replacement, 1); err != nil {
+		t.Fatalf("failed to replace private transaction: %v", err)
+	}
+	if _, ok := w.private.txs[first.Hash()]; ok {
+		t.Fatalf("replaced private transaction still tracked")
+	}
+	// Senders can't go over their share of private transactions
+	for nonce := uint64(2); nonce <= maxPrivateTxsPerAccount; nonce++ {
+		if err := w.AddPrivateTx(makeTx(nonce, chainID, common.Big0), 1); err != nil {
+			t.Fatalf("failed to add private transaction %d: %v", nonce, err)
+		}
+	}
+	if err := w.AddPrivateTx(makeTx(maxPrivateTxsPerAccount+1, chainID, common.Big0), 1); !errors.Is(err, txpool.ErrAccountLimitExceeded) {
+		t.Fatalf("over limit transaction error mismatch: have %v, want %v", err, txpool.ErrAccountLimitExceeded)
+	}
+}
+
+// Tests that private account abstraction transactions are only accepted if
+// their sender contract approves them.
+func TestPrivateAccountAbstraction(t *testing.T) {
+	var (
+		eoa      = common.BytesToAddress([]byte{0xaa, 0x01})
+		rejecter = common.BytesToAddress([]byte{0xaa, 0x02})
+		engine   = beacon.NewFaker()
+		gspec    = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: core.GenesisAlloc{
+				testBankAddress: {Balance: testBankFunds},
+				eoa:             {Balance: testBankFunds},
+				rejecter:        {Balance: testBankFunds, Code: []byte{0x00}},
+			},
+		}
+	)
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, engine, vm.Config{}, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	pool, _ := txpool.New(new(big.Int).SetUint64(testTxPoolConfig.PriceLimit), chain, []txpool.SubPool{legacypool.New(testTxPoolConfig, chain)})
+	defer pool.Close()
+
+	w := New(&testWorkerBackend{chain: chain, txPool: pool, genesis: gspec}, testConfig, engine)
+
+	makeTx := func(sender common.Address) *types.Transaction {
+		return types.NewTx(&types.AccountAbstractionTx{
+			ChainID:       params.TestChainConfig.ChainID,
+			GasFeeCap:     big.NewInt(params.InitialBaseFee),
+			Gas:           100000,
+			ValidationGas: 50000,
+			Sender:        sender,
+			To:            &testUserAddress,
+			Value:         common.Big0,
+		})
+	}
+	if err, want := w.AddPrivateTx(makeTx(eoa), 1), core.ErrSenderNoContract; !errors.Is(err, want) {
+		t.Fatalf("externally owned sender error mismatch: have %v, want %v", err, want)
+	}
+	if err, want := w.AddPrivateTx(makeTx(rejecter), 1), core.ErrValidationFailed; !errors.Is(err, want) {
+		t.Fatalf("rejecting sender error mismatch: have %v, want %v", err, want)
+	}
+	if len(w.private.txs) != 0 {
+		t.Fatalf("unapproved private transactions tracked: %d", len(w.private.txs))
+	}
+}
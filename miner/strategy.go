@@ -0,0 +1,178 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/txpool"
+	"github.com/theQRL/go-zond/core/types"
+)
+
+// Names of the built-in transaction ordering strategies, selectable through the
+// miner configuration.
+const (
+	OrderingPrice = "price" // Highest effective tip first
+	OrderingFIFO  = "fifo"  // Earliest seen first
+	OrderingFair  = "fair"  // Highest effective tip first, capped per sender
+	OrderingLocal = "local" // Local and private transactions first, then by tip (default)
+)
+
+// PendingTransactions is the set of executable transactions a payload can be
+// filled with, grouped by sender and sorted by nonce.
+type PendingTransactions struct {
+	Locals  map[common.Address][]*txpool.LazyTransaction // Transactions of local accounts and private ones
+	Remotes map[common.Address][]*txpool.LazyTransaction // Transactions received from the network
+}
+
+// all merges the local and remote transactions into a single set.
+func (p *PendingTransactions) all() map[common.Address][]*txpool.LazyTransaction {
+	txs := p.Remotes
+	for account, ltxs := range p.Locals {
+		txs[account] = ltxs
+	}
+	return txs
+}
+
+// OrderingStrategy selects and orders the pending transactions to be filled
+// into a payload.
+type OrderingStrategy interface {
+	// Name returns the identifier of the strategy, reported along with the
+	// payloads built with it.
+	Name() string
+
+	// Order returns the transaction sets to commit one after the other. The
+	// pending transactions are owned by the strategy after the call.
+	Order(signer types.Signer, pending *PendingTransactions, baseFee *big.Int) []TransactionOrdering
+}
+
+// Bundle is a group of transactions to be included atomically and in order.
+type Bundle struct {
+	Txs             []*types.Transaction // Transactions of the bundle
+	RevertingHashes []common.Hash        // Transactions allowed to revert without failing the bundle
+}
+
+// allowsRevert reports whether the given transaction of the bundle is allowed
+// to revert.
+func (b *Bundle) allowsRevert(hash common.Hash) bool {
+	for _, h := range b.RevertingHashes {
+		if h == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// BundleSource is an external provider of transaction bundles, e.g. a relay or
+// a searcher endpoint. Bundles are placed at the top of the payload and are
+// included atomically: if any transaction of a bundle fails or reverts without
+// being allowed to, the entire bundle is discarded.
+type BundleSource interface {
+	// Bundles returns the bundles to include into the payload with the given
+	// header, in order of preference.
+	Bundles(header *types.Header) []Bundle
+}
+
+// NewOrderingStrategy creates the built-in ordering strategy selected by the
+// miner configuration.
+func NewOrderingStrategy(config *Config) (OrderingStrategy, error) {
+	switch config.Ordering {
+	case "", OrderingLocal:
+		return localStrategy{}, nil
+	case OrderingPrice:
+		return priceStrategy{}, nil
+	case OrderingFIFO:
+		return fifoStrategy{}, nil
+	case OrderingFair:
+		if config.MaxTxsPerSender <= 0 {
+			return nil, fmt.Errorf("invalid per-sender transaction cap %d", config.MaxTxsPerSender)
+		}
+		return fairStrategy{limit: config.MaxTxsPerSender}, nil
+	default:
+		return nil, fmt.Errorf("unknown transaction ordering %q", config.Ordering)
+	}
+}
+
+// NewBundleStrategy creates an ordering strategy placing the bundles of the
+// given source at the top of the payloads, filling the remaining space with
+// the pending transactions as ordered by the fallback strategy.
+func NewBundleStrategy(source BundleSource, fallback OrderingStrategy) OrderingStrategy {
+	if fallback == nil {
+		fallback = localStrategy{}
+	}
+	return &bundleStrategy{source: source, fallback: fallback}
+}
+
+// priceStrategy orders all pending transactions by their effective tip.
+type priceStrategy struct{}
+
+func (priceStrategy) Name() string { return OrderingPrice }
+
+func (priceStrategy) Order(signer types.Signer, pending *PendingTransactions, baseFee *big.Int) []TransactionOrdering {
+	return []TransactionOrdering{newTransactionsByPriceAndNonce(signer, pending.all(), baseFee)}
+}
+
+// fifoStrategy orders all pending transactions by the time they were first seen.
+type fifoStrategy struct{}
+
+func (fifoStrategy) Name() string { return OrderingFIFO }
+
+func (fifoStrategy) Order(signer types.Signer, pending *PendingTransactions, baseFee *big.Int) []TransactionOrdering {
+	return []TransactionOrdering{newTransactionsByTimeAndNonce(signer, pending.all(), baseFee)}
+}
+
+// fairStrategy orders all pending transactions by their effective tip, but caps
+// the number of transactions included from any single sender.
+type fairStrategy struct {
+	limit int
+}
+
+func (fairStrategy) Name() string { return OrderingFair }
+
+func (s fairStrategy) Order(signer types.Signer, pending *PendingTransactions, baseFee *big.Int) []TransactionOrdering {
+	txs := newTransactionsByPriceAndNonce(signer, pending.all(), baseFee)
+	txs.limitPerAccount(s.limit)
+	return []TransactionOrdering{txs}
+}
+
+// localStrategy orders the local and private transactions ahead of the remote
+// ones, each group by their effective tip.
+type localStrategy struct{}
+
+func (localStrategy) Name() string { return OrderingLocal }
+
+func (localStrategy) Order(signer types.Signer, pending *PendingTransactions, baseFee *big.Int) []TransactionOrdering {
+	return []TransactionOrdering{
+		newTransactionsByPriceAndNonce(signer, pending.Locals, baseFee),
+		newTransactionsByPriceAndNonce(signer, pending.Remotes, baseFee),
+	}
+}
+
+// bundleStrategy includes the bundles of an external source ahead of the pending
+// transactions ordered by a fallback strategy.
+type bundleStrategy struct {
+	source   BundleSource
+	fallback OrderingStrategy
+}
+
+func (s *bundleStrategy) Name() string { return "bundle+" + s.fallback.Name() }
+
+func (s *bundleStrategy) Order(signer types.Signer, pending *PendingTransactions, baseFee *big.Int) []TransactionOrdering {
+	return s.fallback.Order(signer, pending, baseFee)
+}
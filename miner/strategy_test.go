@@ -0,0 +1,244 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/theQRL/go-zond/beacon/engine"
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/consensus/beacon"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/txpool"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/crypto"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
+	"github.com/theQRL/go-zond/params"
+)
+
+// Tests that the built-in ordering strategies select and order the pending
+// transactions according to their policies.
+func TestOrderingStrategies(t *testing.T) {
+	t.Parallel()
+
+	var (
+		whale, _  = crypto.GenerateMLDSA87Key() // Sends high tips, seen last
+		minnow, _ = crypto.GenerateMLDSA87Key() // Sends low tips, seen first
+		signer    = types.LatestSignerForChainID(common.Big1)
+	)
+	makeTxs := func(key pqcrypto.Wallet, count int, tip int64, seen int64) []*txpool.LazyTransaction {
+		var txs []*txpool.LazyTransaction
+		for i := 0; i < count; i++ {
+			tx, _ := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+				Nonce:     uint64(i),
+				To:        &common.Address{},
+				Gas:       params.TxGas,
+				GasFeeCap: big.NewInt(tip),
+				GasTipCap: big.NewInt(tip),
+			}), signer, key)
+			tx.SetTime(time.Unix(seen+int64(i), 0))
+
+			txs = append(txs, &txpool.LazyTransaction{
+				Hash:      tx.Hash(),
+				Tx:        tx,
+				Time:      tx.Time(),
+				GasFeeCap: tx.GasFeeCap(),
+				GasTipCap: tx.GasTipCap(),
+				Gas:       tx.Gas(),
+			})
+		}
+		return txs
+	}
+	whaleTxs, minnowTxs := makeTxs(whale, 3, 10, 100), makeTxs(minnow, 2, 1, 0)
+
+	tests := []struct {
+		ordering string
+		want     []*txpool.LazyTransaction
+	}{
+		{OrderingPrice, []*txpool.LazyTransaction{whaleTxs[0], whaleTxs[1], whaleTxs[2], minnowTxs[0], minnowTxs[1]}},
+		{OrderingFIFO, []*txpool.LazyTransaction{minnowTxs[0], minnowTxs[1], whaleTxs[0], whaleTxs[1], whaleTxs[2]}},
+		{OrderingFair, []*txpool.LazyTransaction{whaleTxs[0], whaleTxs[1], minnowTxs[0], minnowTxs[1]}},
+		{OrderingLocal, []*txpool.LazyTransaction{minnowTxs[0], minnowTxs[1], whaleTxs[0], whaleTxs[1], whaleTxs[2]}},
+	}
+	for _, tt := range tests {
+		strategy, err := NewOrderingStrategy(&Config{Ordering: tt.ordering, MaxTxsPerSender: 2})
+		if err != nil {
+			t.Fatalf("%s: failed to create strategy: %v", tt.ordering, err)
+		}
+		if strategy.Name() != tt.ordering {
+			t.Errorf("%s: name mismatch: have %s", tt.ordering, strategy.Name())
+		}
+		pending := &PendingTransactions{
+			Locals: map[common.Address][]*txpool.LazyTransaction{
+				minnow.GetAddress(): minnowTxs,
+			},
+			Remotes: map[common.Address][]*txpool.LazyTransaction{
+				whale.GetAddress(): whaleTxs,
+			},
+		}
+		var have []*txpool.LazyTransaction
+		for _, txs := range strategy.Order(signer, pending, nil) {
+			for tx, _ := txs.Peek(); tx != nil; tx, _ = txs.Peek() {
+				have = append(have, tx)
+				txs.Shift()
+			}
+		}
+		if len(have) != len(tt.want) {
+			t.Fatalf("%s: transaction count mismatch: have %d, want %d", tt.ordering, len(have), len(tt.want))
+		}
+		for i := range have {
+			if have[i].Hash != tt.want[i].Hash {
+				t.Errorf("%s: transaction %d mismatch: have %x, want %x", tt.ordering, i, have[i].Hash, tt.want[i].Hash)
+			}
+		}
+	}
+	if _, err := NewOrderingStrategy(&Config{Ordering: "random"}); err == nil {
+		t.Errorf("unknown ordering accepted")
+	}
+	if _, err := NewOrderingStrategy(&Config{Ordering: OrderingFair}); err == nil {
+		t.Errorf("fair ordering without sender cap accepted")
+	}
+}
+
+// testBundleSource is a bundle source serving a fixed set of bundles.
+type testBundleSource []Bundle
+
+func (s testBundleSource) Bundles(header *types.Header) []Bundle { return s }
+
+// Tests that externally sourced bundles are placed at the top of the payloads
+// atomically, and that the fee revenue of the payload is reported.
+func TestBundleStrategy(t *testing.T) {
+	var (
+		signer   = types.LatestSigner(params.TestChainConfig)
+		broke, _ = crypto.GenerateMLDSA87Key()
+		to       = common.Address{0xff}
+	)
+	transfer := types.MustSignNewTx(testBankKey, signer, &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     0,
+		To:        &to,
+		Value:     big.NewInt(1),
+		Gas:       params.TxGas,
+		GasFeeCap: big.NewInt(params.InitialBaseFee),
+	})
+	unfunded := types.MustSignNewTx(broke, signer, &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     0,
+		To:        &to,
+		Value:     big.NewInt(1),
+		Gas:       params.TxGas,
+		GasFeeCap: big.NewInt(params.InitialBaseFee),
+	})
+	// Deployment of a contract with an invalid opcode as its init code
+	reverting := types.MustSignNewTx(testBankKey, signer, &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     1,
+		Value:     big.NewInt(0),
+		Gas:       100000,
+		GasFeeCap: big.NewInt(params.InitialBaseFee),
+		Data:      []byte{0xfe},
+	})
+	// The first bundles fail on their second transaction, the last one is valid
+	// and supersedes the pooled transaction of the same sender.
+	config := testConfig
+	config.Bundles = testBundleSource{
+		{Txs: []*types.Transaction{transfer, unfunded}},
+		{Txs: []*types.Transaction{transfer, reverting}},
+		{Txs: []*types.Transaction{pendingTxs[0], newTxs[0]}},
+	}
+	b := newTestWorkerBackend(t, params.TestChainConfig, beacon.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+	b.txPool.Add(pendingTxs, true, true)
+	w := New(b, config, beacon.NewFaker())
+
+	env, err := w.prepareWork(&generateParams{
+		parentHash: b.chain.CurrentBlock().Hash(),
+		timestamp:  uint64(time.Now().Unix()),
+	})
+	if err != nil {
+		t.Fatalf("failed to prepare work: %v", err)
+	}
+	failed := []struct {
+		bundle Bundle
+		err    error
+	}{
+		{Bundle{Txs: []*types.Transaction{transfer, unfunded}}, core.ErrInsufficientFunds},
+		{Bundle{Txs: []*types.Transaction{transfer, reverting}}, errBundleTxReverted},
+	}
+	for i, tt := range failed {
+		if err := w.commitBundle(env, tt.bundle); !errors.Is(err, tt.err) {
+			t.Fatalf("bundle %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+		if env.tcount != 0 || len(env.txs) != 0 || env.header.GasUsed != 0 || env.state.GetNonce(testBankAddress) != 0 || env.state.GetBalance(to).Sign() != 0 {
+			t.Fatalf("bundle %d: not reverted: txs %d, gas %d, nonce %d", i, len(env.txs), env.header.GasUsed, env.state.GetNonce(testBankAddress))
+		}
+	}
+	// Reverting transactions are included if the bundle allows them to
+	allowed := Bundle{Txs: []*types.Transaction{transfer, reverting}, RevertingHashes: []common.Hash{reverting.Hash()}}
+	if err := w.commitBundle(env, allowed); err != nil {
+		t.Fatalf("failed to commit bundle allowing reverts: %v", err)
+	}
+	if env.tcount != 2 || env.receipts[1].Status != types.ReceiptStatusFailed || env.state.GetNonce(testBankAddress) != 2 {
+		t.Fatalf("bundle allowing reverts not included: txs %d, nonce %d", env.tcount, env.state.GetNonce(testBankAddress))
+	}
+	payload, err := w.buildPayload(&BuildPayloadArgs{
+		Parent:    b.chain.CurrentBlock().Hash(),
+		Timestamp: uint64(time.Now().Unix()),
+	})
+	if err != nil {
+		t.Fatalf("failed to build payload: %v", err)
+	}
+	full := payload.ResolveFull()
+	if have, want := len(full.ExecutionPayload.Transactions), 2; have != want {
+		t.Fatalf("payload transaction count mismatch: have %d, want %d", have, want)
+	}
+	block, err := engine.ExecutableDataToBlock(*full.ExecutionPayload, nil)
+	if err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	if block.Transactions()[0].Hash() != pendingTxs[0].Hash() || block.Transactions()[1].Hash() != newTxs[0].Hash() {
+		t.Fatalf("payload transactions mismatch: have %v", block.Transactions())
+	}
+	// Check the reported fee revenue of the payload
+	revenue := full.Revenue
+	if revenue == nil {
+		t.Fatalf("payload revenue missing")
+	}
+	if revenue.Ordering != "bundle+"+OrderingLocal {
+		t.Errorf("ordering mismatch: have %s, want %s", revenue.Ordering, "bundle+"+OrderingLocal)
+	}
+	if revenue.Tips.Cmp(full.BlockValue) != 0 {
+		t.Errorf("tips mismatch: have %v, want %v", revenue.Tips, full.BlockValue)
+	}
+	burnt := new(big.Int).Mul(block.BaseFee(), new(big.Int).SetUint64(block.GasUsed()))
+	if revenue.BurntFees.Cmp(burnt) != 0 {
+		t.Errorf("burnt fees mismatch: have %v, want %v", revenue.BurntFees, burnt)
+	}
+	// The pending block is public, it must not contain the bundles
+	pending, _, _ := w.Pending()
+	if pending == nil {
+		t.Fatalf("failed to build pending block")
+	}
+	for _, tx := range pending.Transactions() {
+		if tx.Hash() == newTxs[0].Hash() {
+			t.Fatalf("bundle leaked into the pending block")
+		}
+	}
+}
@@ -38,6 +38,7 @@ var (
 	errBlockInterruptedByNewHead  = errors.New("new head arrived while building block")
 	errBlockInterruptedByRecommit = errors.New("recommit interrupt while building block")
 	errBlockInterruptedByTimeout  = errors.New("timeout while building block")
+	errBundleTxReverted           = errors.New("bundle transaction reverted")
 )
 
 // environment is the worker's current environment and holds all
@@ -66,6 +67,7 @@ type newPayloadResult struct {
 	err      error
 	block    *types.Block
 	fees     *big.Int         // total block fees
+	ordering string           // Name of the strategy the transactions were selected with
 	stateDB  *state.StateDB   // StateDB after executing the transactions
 	receipts []*types.Receipt // Receipts collected during construction
 }
@@ -78,7 +80,9 @@ type generateParams struct {
 	coinbase    common.Address    // The fee recipient address for including transaction
 	random      common.Hash       // The randomness generated by beacon chain, empty before the merge
 	withdrawals types.Withdrawals // List of withdrawals to include in block.
+	beaconRoot  *common.Hash      // The beacon root (Prague field)
 	noTxs       bool              // Flag whether an empty block without any transaction is expected
+	noPrivate   bool              // Flag whether the private transactions must be left out
 }
 
 // generateWork generates a sealing block based on the given parameters.
@@ -87,14 +91,20 @@ func (miner *Miner) generateWork(params *generateParams) *newPayloadResult {
 	if err != nil {
 		return &newPayloadResult{err: err}
 	}
+	var ordering string
 	if !params.noTxs {
+		miner.confMu.RLock()
+		strategy := miner.ordering
+		miner.confMu.RUnlock()
+		ordering = strategy.Name()
+
 		interrupt := new(atomic.Int32)
 		timer := time.AfterFunc(miner.config.Recommit, func() {
 			interrupt.Store(commitInterruptTimeout)
 		})
 		defer timer.Stop()
 
-		err := miner.fillTransactions(interrupt, work)
+		err := miner.fillTransactions(interrupt, work, strategy, !params.noPrivate)
 		if errors.Is(err, errBlockInterruptedByTimeout) {
 			log.Warn("Block building is interrupted", "allowance", common.PrettyDuration(miner.config.Recommit))
 		}
@@ -107,6 +117,7 @@ func (miner *Miner) generateWork(params *generateParams) *newPayloadResult {
 	return &newPayloadResult{
 		block:    block,
 		fees:     totalFees(block, work.receipts),
+		ordering: ordering,
 		stateDB:  work.state,
 		receipts: work.receipts,
 	}
@@ -156,12 +167,22 @@ func (miner *Miner) prepareWork(genParams *generateParams) (*environment, error)
 	// Set baseFee and GasLimit if we are on an EIP-1559 chain
 	header.BaseFee = eip1559.CalcBaseFee(miner.chainConfig, parent)
 
+	// Apply the beacon root if we are post Prague
+	if miner.chainConfig.IsPrague(header.Time) {
+		header.ParentBeaconRoot = genParams.beaconRoot
+	}
 	// Could potentially happen if starting to mine in an odd state..
 	env, err := miner.makeEnv(parent, header, genParams.coinbase)
 	if err != nil {
 		log.Error("Failed to create sealing context", "err", err)
 		return nil, err
 	}
+	core.DeployBeaconRoots(miner.chainConfig, parent.Time, header.Time, env.state)
+	if header.ParentBeaconRoot != nil {
+		context := core.NewQRVMBlockContext(header, miner.chain, nil)
+		vmenv := vm.NewQRVM(context, vm.TxContext{}, env.state, miner.chainConfig, vm.Config{})
+		core.ProcessBeaconBlockRoot(*header.ParentBeaconRoot, vmenv, env.state)
+	}
 	return env, nil
 }
 
@@ -174,7 +195,7 @@ func (miner *Miner) makeEnv(parent *types.Header, header *types.Header, coinbase
 	}
 	// Note the passed coinbase may be different with header.Coinbase.
 	return &environment{
-		signer:   types.MakeSigner(miner.chainConfig),
+		signer:   types.MakeSigner(miner.chainConfig, header.Time),
 		state:    state,
 		coinbase: coinbase,
 		header:   header,
@@ -206,7 +227,7 @@ func (miner *Miner) applyTransaction(env *environment, tx *types.Transaction) (*
 	return receipt, err
 }
 
-func (miner *Miner) commitTransactions(env *environment, txs *transactionsByPriceAndNonce, interrupt *atomic.Int32) error {
+func (miner *Miner) commitTransactions(env *environment, txs TransactionOrdering, interrupt *atomic.Int32) error {
 	gasLimit := env.header.GasLimit
 	if env.gasPool == nil {
 		env.gasPool = new(core.GasPool).AddGas(gasLimit)
@@ -273,14 +294,59 @@ func (miner *Miner) commitTransactions(env *environment, txs *transactionsByPric
 	return nil
 }
 
+// commitBundle commits all the transactions of a bundle, or none of them if any
+// fails to execute or reverts without being allowed to.
+func (miner *Miner) commitBundle(env *environment, bundle Bundle) error {
+	if env.gasPool == nil {
+		env.gasPool = new(core.GasPool).AddGas(env.header.GasLimit)
+	}
+	var (
+		snap    = env.state.Copy()
+		gp      = env.gasPool.Gas()
+		gasUsed = env.header.GasUsed
+		tcount  = env.tcount
+	)
+	for _, tx := range bundle.Txs {
+		env.state.SetTxContext(tx.Hash(), env.tcount)
+		err := miner.commitTransaction(env, tx)
+		if err == nil && env.receipts[len(env.receipts)-1].Status == types.ReceiptStatusFailed && !bundle.allowsRevert(tx.Hash()) {
+			err = errBundleTxReverted
+		}
+		if err != nil {
+			env.state = snap
+			env.gasPool.SetGas(gp)
+			env.header.GasUsed = gasUsed
+			env.txs, env.receipts, env.tcount = env.txs[:tcount], env.receipts[:tcount], tcount
+			return fmt.Errorf("transaction %x: %w", tx.Hash(), err)
+		}
+	}
+	return nil
+}
+
 // fillTransactions retrieves the pending transactions from the txpool and fills them
-// into the given sealing block. The transaction selection and ordering strategy can
-// be customized with the plugin in the future.
-func (miner *Miner) fillTransactions(interrupt *atomic.Int32, env *environment) error {
+// into the given sealing block, selected and ordered by the given strategy.
+//
+// If requested, the private transactions submitted to this node and the bundles
+// of an external source are filled in too. Private transactions are treated as
+// local ones.
+func (miner *Miner) fillTransactions(interrupt *atomic.Int32, env *environment, strategy OrderingStrategy, private bool) error {
 	miner.confMu.RLock()
 	tip := miner.config.GasPrice
 	miner.confMu.RUnlock()
 
+	// Place the externally sourced bundles at the top of the block
+	if bundles, ok := strategy.(*bundleStrategy); ok && private {
+		for _, bundle := range bundles.source.Bundles(env.header) {
+			if interrupt != nil {
+				if signal := interrupt.Load(); signal != commitInterruptNone {
+					return signalToErr(signal)
+				}
+			}
+			if err := miner.commitBundle(env, bundle); err != nil {
+				log.Debug("Bundle failed, skipped", "txs", len(bundle.Txs), "err", err)
+			}
+		}
+	}
 	// Retrieve the pending transactions pre-filtered by the 1559 dynamic fees
 	filter := txpool.PendingFilter{
 		MinTip: tip,
@@ -298,15 +364,24 @@ func (miner *Miner) fillTransactions(interrupt *atomic.Int32, env *environment)
 			localTxs[account] = txs
 		}
 	}
-	// Fill the block with all available pending transactions.
-	if len(localTxs) > 0 {
-		txs := newTransactionsByPriceAndNonce(env.signer, localTxs, env.header.BaseFee)
-		if err := miner.commitTransactions(env, txs, interrupt); err != nil {
-			return err
+	// Treat the private transactions as local ones, interleaved by nonce with
+	// the pooled transactions of the same sender.
+	if private {
+		for account, txs := range miner.private.pending() {
+			pooled, ok := localTxs[account]
+			if !ok {
+				pooled = remoteTxs[account]
+				delete(remoteTxs, account)
+			}
+			localTxs[account] = mergePrivateTxs(txs, pooled)
 		}
 	}
-	if len(remoteTxs) > 0 {
-		txs := newTransactionsByPriceAndNonce(env.signer, remoteTxs, env.header.BaseFee)
+	// Fill the block with all available pending transactions.
+	pending := &PendingTransactions{Locals: localTxs, Remotes: remoteTxs}
+	for _, txs := range strategy.Order(env.signer, pending, env.header.BaseFee) {
+		if txs.Empty() {
+			continue
+		}
 		if err := miner.commitTransactions(env, txs, interrupt); err != nil {
 			return err
 		}
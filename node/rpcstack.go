@@ -200,6 +200,14 @@ func (h *httpServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if ws != nil && isWebsocket(r) {
 		if checkPath(r, h.wsConfig.prefix) {
 			ws.ServeHTTP(w, r)
+			return
+		}
+		// Not an RPC request, but handlers registered in the mux may speak
+		// websocket too (e.g. GraphQL subscriptions).
+		if h.httpHandler.Load().(*rpcHandler) != nil {
+			if handler, pattern := h.mux.Handler(r); pattern != "" {
+				handler.ServeHTTP(w, r)
+			}
 		}
 		return
 	}
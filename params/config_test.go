@@ -17,6 +17,8 @@
 package params
 
 import (
+	"math"
+	"math/big"
 	"reflect"
 	"testing"
 	"time"
@@ -102,24 +104,35 @@ func TestCheckCompatible(t *testing.T) {
 					RewindToBlock: 30,
 				},
 			},
-			{
-				stored:        &ChainConfig{},
-				new:           &ChainConfig{},
-				headTimestamp: 9,
-				wantErr:       nil,
+		*/
+		{
+			stored:        &ChainConfig{CancunTime: newUint64(10)},
+			new:           &ChainConfig{CancunTime: newUint64(20)},
+			headTimestamp: 9,
+			wantErr:       nil,
+		},
+		{
+			stored:        &ChainConfig{CancunTime: newUint64(10)},
+			new:           &ChainConfig{CancunTime: newUint64(20)},
+			headTimestamp: 25,
+			wantErr: &ConfigCompatError{
+				What:         "Cancun fork timestamp",
+				StoredTime:   newUint64(10),
+				NewTime:      newUint64(20),
+				RewindToTime: 9,
 			},
-			{
-				stored:        &ChainConfig{},
-				new:           &ChainConfig{},
-				headTimestamp: 25,
-				wantErr: &ConfigCompatError{
-					What:         "Shanghai fork timestamp",
-					StoredTime:   newUint64(10),
-					NewTime:      newUint64(20),
-					RewindToTime: 9,
-				},
+		},
+		{
+			stored:        &ChainConfig{CancunTime: newUint64(10)},
+			new:           &ChainConfig{},
+			headTimestamp: 25,
+			wantErr: &ConfigCompatError{
+				What:         "Cancun fork timestamp",
+				StoredTime:   newUint64(10),
+				NewTime:      nil,
+				RewindToTime: 9,
 			},
-		*/
+		},
 	}
 
 	for _, test := range tests {
@@ -130,23 +143,20 @@ func TestCheckCompatible(t *testing.T) {
 	}
 }
 
-// NOTE(rgeraldes24): not valid at the moment
-/*
 func TestConfigRules(t *testing.T) {
 	c := &ChainConfig{
-		ShanghaiTime: newUint64(500),
+		CancunTime: newUint64(500),
 	}
 	var stamp uint64
-	if r := c.Rules(big.NewInt(0), true, stamp); r.IsShanghai {
-		t.Errorf("expected %v to not be shanghai", stamp)
+	if r := c.Rules(big.NewInt(0), stamp); r.IsCancun {
+		t.Errorf("expected %v to not be cancun", stamp)
 	}
 	stamp = 500
-	if r := c.Rules(big.NewInt(0), true, stamp); !r.IsShanghai {
-		t.Errorf("expected %v to be shanghai", stamp)
+	if r := c.Rules(big.NewInt(0), stamp); !r.IsCancun {
+		t.Errorf("expected %v to be cancun", stamp)
 	}
 	stamp = math.MaxInt64
-	if r := c.Rules(big.NewInt(0), true, stamp); !r.IsShanghai {
-		t.Errorf("expected %v to be shanghai", stamp)
+	if r := c.Rules(big.NewInt(0), stamp); !r.IsCancun {
+		t.Errorf("expected %v to be cancun", stamp)
 	}
 }
-*/
@@ -16,6 +16,8 @@
 
 package params
 
+import "github.com/theQRL/go-zond/common"
+
 const (
 	GasLimitBoundDivisor uint64 = 1024               // The bound divisor of the gas limit, used in update calculations.
 	MinGasLimit          uint64 = 5000               // Minimum the gas limit may ever be.
@@ -82,6 +84,8 @@ const (
 	MaxCodeSize     = 24576           // Maximum bytecode to permit for a contract
 	MaxInitCodeSize = 2 * MaxCodeSize // Maximum initcode to permit in a creation transaction and create instructions
 
+	MaxValidationGas uint64 = 250000 // Maximum validation gas of an account abstraction transaction accepted into the txpool
+
 	// Precompiled contract gas prices
 	DepositrootGas     uint64 = 3000 // Deposit root operation gas price
 	Sha256BaseGas      uint64 = 60   // Base price for a SHA256 operation
@@ -89,6 +93,9 @@ const (
 	IdentityBaseGas    uint64 = 15   // Base price for a data copy operation
 	IdentityPerWordGas uint64 = 3    // Per-work price for a data copy operation
 
+	MLDSA87VerifyBaseGas    uint64 = 15000 // Base price for an ML-DSA-87 signature verification
+	MLDSA87VerifyPerWordGas uint64 = 3     // Per-word price for an ML-DSA-87 signature verification input
+
 	Bn256AddGasIstanbul             uint64 = 150   // Gas needed for an elliptic curve addition
 	Bn256ScalarMulGasIstanbul       uint64 = 6000  // Gas needed for an elliptic curve scalar multiplication
 	Bn256PairingBaseGasIstanbul     uint64 = 45000 // Base price for an elliptic curve pairing check
@@ -99,3 +106,21 @@ const (
 	RefundQuotient        uint64 = 2
 	RefundQuotientEIP3529 uint64 = 5
 )
+
+// AccountAbstractionEntryPoint is the caller of the validation phase of account
+// abstraction transactions. Sender contracts can use it to tell validation calls
+// apart from regular ones.
+var AccountAbstractionEntryPoint = common.BytesToAddress([]byte{0xaa, 0xaa})
+
+var (
+	// SystemAddress is where the system-transaction is sent from as per EIP-4788.
+	SystemAddress = common.BytesToAddress(common.FromHex("fffffffffffffffffffffffffffffffffffffffe"))
+
+	// BeaconRootsAddress is the address where historical beacon roots are stored
+	// as per EIP-4788.
+	BeaconRootsAddress = common.BytesToAddress(common.FromHex("000F3df6D732807Ef1319fB7B8bB8522d0Beac02"))
+
+	// BeaconRootsCode is the code where historical beacon roots are stored as per
+	// EIP-4788.
+	BeaconRootsCode = common.FromHex("3373fffffffffffffffffffffffffffffffffffffffe14604d57602036146024575f5ffd5b5f35801560495762001fff810690815414603c575f5ffd5b62001fff01545f5260205ff35b5f5ffd5b62001fff42064281555f359062001fff015500")
+)
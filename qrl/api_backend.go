@@ -278,6 +278,14 @@ func (b *QRLAPIBackend) SendTx(ctx context.Context, signedTx *types.Transaction)
 	return b.qrl.txPool.Add([]*types.Transaction{signedTx}, true, false)[0]
 }
 
+func (b *QRLAPIBackend) SendPrivateTx(ctx context.Context, signedTx *types.Transaction, expiry uint64) error {
+	return b.qrl.miner.AddPrivateTx(signedTx, expiry)
+}
+
+func (b *QRLAPIBackend) CancelPrivateTx(ctx context.Context, txHash common.Hash) bool {
+	return b.qrl.miner.CancelPrivateTx(txHash)
+}
+
 func (b *QRLAPIBackend) GetPoolTransactions() (types.Transactions, error) {
 	pending := b.qrl.txPool.Pending(txpool.PendingFilter{})
 	var txs types.Transactions
@@ -324,6 +332,10 @@ func (b *QRLAPIBackend) SubscribeNewTxsEvent(ch chan<- core.NewTxsEvent) event.S
 	return b.qrl.txPool.SubscribeTransactions(ch)
 }
 
+func (b *QRLAPIBackend) SubscribeTxPoolEvents(ch chan<- []*txpool.TxEvent) event.Subscription {
+	return b.qrl.txPool.SubscribeTxEvents(ch)
+}
+
 func (b *QRLAPIBackend) SyncProgress() qrl.SyncProgress {
 	return b.qrl.Downloader().Progress()
 }
@@ -18,6 +18,7 @@
 package qrl
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"runtime"
@@ -32,6 +33,7 @@ import (
 	"github.com/theQRL/go-zond/core/rawdb"
 	"github.com/theQRL/go-zond/core/state/pruner"
 	"github.com/theQRL/go-zond/core/txpool"
+	"github.com/theQRL/go-zond/core/txpool/largepool"
 	"github.com/theQRL/go-zond/core/txpool/legacypool"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/core/vm"
@@ -50,6 +52,7 @@ import (
 	"github.com/theQRL/go-zond/qrl/protocols/qrl"
 	"github.com/theQRL/go-zond/qrl/protocols/snap"
 	"github.com/theQRL/go-zond/qrl/qrlconfig"
+	"github.com/theQRL/go-zond/qrl/tracers"
 	"github.com/theQRL/go-zond/qrldb"
 	"github.com/theQRL/go-zond/rlp"
 	"github.com/theQRL/go-zond/rpc"
@@ -78,6 +81,9 @@ type QRL struct {
 	bloomIndexer      *core.ChainIndexer             // Bloom indexer operating during block imports
 	closeBloomHandler chan struct{}
 
+	closePrivateTxExpirer chan struct{}  // Channel to stop publishing expired private transactions
+	privateTxExpirerWg    sync.WaitGroup // Wait group to not close the pool under the expirer
+
 	APIBackend *QRLAPIBackend
 
 	miner    *miner.Miner
@@ -148,6 +154,8 @@ func New(stack *node.Node, config *qrlconfig.Config) (*QRL, error) {
 		bloomIndexer:      core.NewBloomIndexer(chainDb, params.BloomBitsBlocks, params.BloomConfirms),
 		p2pServer:         stack.Server(),
 		shutdownTracker:   shutdowncheck.NewShutdownTracker(chainDb),
+
+		closePrivateTxExpirer: make(chan struct{}),
 	}
 	bcVersion := rawdb.ReadDatabaseVersion(chainDb)
 	var dbVer = "<nil>"
@@ -166,6 +174,7 @@ func New(stack *node.Node, config *qrlconfig.Config) (*QRL, error) {
 			rawdb.WriteDatabaseVersion(chainDb, core.BlockChainVersion)
 		}
 	}
+	rawdb.WriteCompactBodies(chainDb, config.CompactBodies)
 	var (
 		vmConfig = vm.Config{
 			EnablePreimageRecording: config.EnablePreimageRecording,
@@ -179,9 +188,21 @@ func New(stack *node.Node, config *qrlconfig.Config) (*QRL, error) {
 			SnapshotLimit:       config.SnapshotCache,
 			Preimages:           config.Preimages,
 			StateHistory:        config.StateHistory,
+			ChainHistory:        config.ChainHistory,
 			StateScheme:         config.StateScheme,
 		}
 	)
+	if config.VMTrace != "" {
+		var traceConfig json.RawMessage
+		if config.VMTraceJsonConfig != "" {
+			traceConfig = json.RawMessage(config.VMTraceJsonConfig)
+		}
+		t, err := tracers.LiveDirectory.New(config.VMTrace, traceConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create tracer %s: %v", config.VMTrace, err)
+		}
+		vmConfig.LiveTracer = t
+	}
 	qrl.blockchain, err = core.NewBlockChain(chainDb, cacheConfig, config.Genesis, qrl.engine, vmConfig, &config.TransactionHistory)
 	if err != nil {
 		return nil, err
@@ -191,8 +212,19 @@ func New(stack *node.Node, config *qrlconfig.Config) (*QRL, error) {
 	if config.TxPool.Journal != "" {
 		config.TxPool.Journal = stack.ResolvePath(config.TxPool.Journal)
 	}
+	if config.TxPool.PersistDir != "" {
+		config.TxPool.PersistDir = stack.ResolvePath(config.TxPool.PersistDir)
+	}
 	legacyPool := legacypool.New(config.TxPool, qrl.blockchain)
-	qrl.txPool, err = txpool.New(new(big.Int).SetUint64(config.TxPool.PriceLimit), qrl.blockchain, []txpool.SubPool{legacyPool})
+
+	if config.LargePool.Datadir != "" {
+		config.LargePool.Datadir = stack.ResolvePath(config.LargePool.Datadir)
+	}
+	largePool := largepool.New(config.LargePool, qrl.blockchain)
+
+	// The large pool is consulted first, so deployments and heavy calls don't
+	// end up competing for the legacy pool's slots
+	qrl.txPool, err = txpool.New(new(big.Int).SetUint64(config.TxPool.PriceLimit), qrl.blockchain, []txpool.SubPool{largePool, legacyPool})
 	if err != nil {
 		return nil, err
 	}
@@ -331,6 +363,9 @@ func (s *QRL) Start() error {
 	// Start the bloom bits servicing goroutines
 	s.startBloomHandlers(params.BloomBitsBlocks)
 
+	// Start publishing the private transactions once they expire
+	s.startPrivateTxExpirer()
+
 	// Regularly update shutdown marker
 	s.shutdownTracker.Start()
 
@@ -353,6 +388,8 @@ func (s *QRL) Stop() error {
 	// Then stop everything else.
 	s.bloomIndexer.Close()
 	close(s.closeBloomHandler)
+	close(s.closePrivateTxExpirer)
+	s.privateTxExpirerWg.Wait()
 	s.txPool.Close()
 	s.blockchain.Stop()
 	s.engine.Close()
@@ -296,7 +296,7 @@ func TestNewBlock(t *testing.T) {
 		if err != nil {
 			t.Fatalf("Failed to create the executable data %v", err)
 		}
-		block, err := engine.ExecutableDataToBlock(*execData)
+		block, err := engine.ExecutableDataToBlock(*execData, nil)
 		if err != nil {
 			t.Fatalf("Failed to convert executable data to block %v", err)
 		}
@@ -338,7 +338,7 @@ func TestNewBlock(t *testing.T) {
 		if err != nil {
 			t.Fatalf("Failed to create the executable data %v", err)
 		}
-		block, err := engine.ExecutableDataToBlock(*execData)
+		block, err := engine.ExecutableDataToBlock(*execData, nil)
 		if err != nil {
 			t.Fatalf("Failed to convert executable data to block %v", err)
 		}
@@ -870,7 +870,7 @@ func TestSimultaneousNewBlock(t *testing.T) {
 				t.Fatal(testErr)
 			}
 		}
-		block, err := engine.ExecutableDataToBlock(*execData)
+		block, err := engine.ExecutableDataToBlock(*execData, nil)
 		if err != nil {
 			t.Fatalf("Failed to convert executable data to block %v", err)
 		}
@@ -1350,3 +1350,83 @@ func equalBody(a *types.Body, b *engine.ExecutionPayloadBodyV1) bool {
 	}
 	return reflect.DeepEqual(a.Withdrawals, b.Withdrawals)
 }
+
+// Tests that V3 payloads carry the parent beacon block root into the chain
+// post Prague, and that the API versions are gated by the fork.
+func TestNewPayloadV3(t *testing.T) {
+	genesis, _ := generateChain(0)
+	genesis.Config.PragueTime = new(uint64)
+	genesis.Alloc[params.BeaconRootsAddress] = core.GenesisAccount{Nonce: 1, Code: params.BeaconRootsCode, Balance: common.Big0}
+
+	n, qrlservice := startQRLService(t, genesis, nil)
+	defer n.Close()
+
+	var (
+		api        = newConsensusAPIWithoutHeartbeat(qrlservice)
+		parent     = qrlservice.BlockChain().CurrentBlock()
+		beaconRoot = common.Hash{0xbe, 0xac}
+		recipient  = common.Address{0x77}
+		fcState    = engine.ForkchoiceStateV1{HeadBlockHash: parent.Hash()}
+		attributes = engine.PayloadAttributes{
+			Timestamp:   parent.Time + 5,
+			Withdrawals: []*types.Withdrawal{{Index: 0, Validator: 1, Address: recipient, Amount: 10}},
+		}
+	)
+	// V2 must be rejected post Prague, V3 must carry the beacon root
+	if _, err := api.ForkchoiceUpdatedV2(fcState, &attributes); err == nil {
+		t.Fatal("forkchoiceUpdatedV2 accepted post prague")
+	}
+	if _, err := api.ForkchoiceUpdatedV3(fcState, &attributes); err == nil {
+		t.Fatal("forkchoiceUpdatedV3 accepted without beacon root")
+	}
+	attributes.BeaconRoot = &beaconRoot
+	resp, err := api.ForkchoiceUpdatedV3(fcState, &attributes)
+	if err != nil {
+		t.Fatalf("failed to request payload: %v", err)
+	}
+	if _, err := api.GetPayloadV2(*resp.PayloadID); err == nil {
+		t.Fatal("getPayloadV2 accepted post prague")
+	}
+	envelope, err := api.GetPayloadV3(*resp.PayloadID)
+	if err != nil {
+		t.Fatalf("failed to retrieve payload: %v", err)
+	}
+	payload := envelope.ExecutionPayload
+
+	if _, err := api.NewPayloadV2(*payload); err == nil {
+		t.Fatal("newPayloadV2 accepted post prague")
+	}
+	if status, _ := api.NewPayloadV3(*payload, &common.Hash{}); status.Status != engine.INVALID {
+		t.Fatalf("payload with wrong beacon root accepted: %v", status.Status)
+	}
+	missing := *payload
+	missing.Withdrawals = nil
+	if _, err := api.NewPayloadV3(missing, &beaconRoot); err == nil {
+		t.Fatal("newPayloadV3 accepted without withdrawals")
+	}
+	status, err := api.NewPayloadV3(*payload, &beaconRoot)
+	if err != nil || status.Status != engine.VALID {
+		t.Fatalf("failed to insert payload: %v %v", status.Status, err)
+	}
+	fcState.HeadBlockHash = payload.BlockHash
+	if _, err := api.ForkchoiceUpdatedV3(fcState, nil); err != nil {
+		t.Fatalf("failed to update forkchoice: %v", err)
+	}
+	// Check that the beacon root is available to contracts
+	head := qrlservice.BlockChain().GetBlockByHash(payload.BlockHash)
+	if head == nil || head.BeaconRoot() == nil || *head.BeaconRoot() != beaconRoot {
+		t.Fatalf("beacon root not in header")
+	}
+	statedb, _ := qrlservice.BlockChain().State()
+	index := common.BigToHash(new(big.Int).SetUint64(payload.Timestamp%8191 + 8191))
+	if have := statedb.GetState(params.BeaconRootsAddress, index); have != beaconRoot {
+		t.Fatalf("stored beacon root mismatch: have %x, want %x", have, beaconRoot)
+	}
+	// Check that the withdrawals are accounted for as in V2 payloads
+	if len(head.Withdrawals()) != 1 || head.Withdrawals()[0].Address != recipient {
+		t.Fatalf("withdrawals mismatch: have %v", head.Withdrawals())
+	}
+	if have, want := statedb.GetBalance(recipient), big.NewInt(10*params.Shor); have.Cmp(want) != 0 {
+		t.Fatalf("withdrawn balance mismatch: have %v, want %v", have, want)
+	}
+}
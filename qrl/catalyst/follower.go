@@ -0,0 +1,302 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package catalyst
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/theQRL/go-zond/beacon/engine"
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/internal/era"
+	"github.com/theQRL/go-zond/log"
+	"github.com/theQRL/go-zond/node"
+	"github.com/theQRL/go-zond/qrl"
+	"github.com/theQRL/go-zond/qrlclient"
+	"github.com/theQRL/go-zond/rpc"
+)
+
+// followRecheckInterval is the time to wait before checking the source for new
+// finalized blocks once the follower caught up with it, or failed to.
+const followRecheckInterval = 12 * time.Second
+
+// errFollowerDiverged is returned if the source has a block which does not
+// extend the local chain.
+var errFollowerDiverged = errors.New("local chain diverged from source")
+
+// FollowerSource is a trusted provider of finalized blocks for the follower.
+type FollowerSource interface {
+	// Head retrieves the number of the latest finalized block of the source.
+	Head(ctx context.Context) (uint64, error)
+
+	// BlockByNumber retrieves a finalized block of the source by number.
+	BlockByNumber(ctx context.Context, number uint64) (*types.Block, error)
+
+	// Close releases any resources held by the source.
+	Close() error
+}
+
+// LocalFollower is an auxiliary service that allows Gzond to follow an already
+// finalized chain without a consensus-layer attached. The blocks are retrieved
+// from a trusted source and fed through the engine API, as a beacon client
+// would do via newPayload and forkchoiceUpdated.
+//
+// This follower can only be applied for full-sync.
+type LocalFollower struct {
+	qrl       *qrl.QRL
+	engineAPI *ConsensusAPI
+	source    FollowerSource
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewLocalFollower creates a follower importing the finalized blocks of the
+// given source into the chain.
+func NewLocalFollower(qrl *qrl.QRL, source FollowerSource) *LocalFollower {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &LocalFollower{
+		qrl:       qrl,
+		engineAPI: newConsensusAPIWithoutHeartbeat(qrl),
+		source:    source,
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+// RegisterLocalFollower registers the local follower service into the node
+// stack for launching and stopping the service controlled by node.
+func RegisterLocalFollower(stack *node.Node, backend *qrl.QRL, source FollowerSource) (*LocalFollower, error) {
+	follower := NewLocalFollower(backend, source)
+	stack.RegisterLifecycle(follower)
+	return follower, nil
+}
+
+// Start launches the import loop of the follower.
+func (f *LocalFollower) Start() error {
+	f.wg.Add(1)
+	go f.loop()
+	return nil
+}
+
+// Stop terminates the import loop and releases the source. This function can
+// only be called for one time.
+func (f *LocalFollower) Stop() error {
+	f.cancel()
+	f.wg.Wait()
+	return f.source.Close()
+}
+
+// loop keeps importing the newly finalized blocks of the source until the
+// follower is stopped.
+func (f *LocalFollower) loop() {
+	defer f.wg.Done()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			if err := f.follow(); err != nil && f.ctx.Err() == nil {
+				log.Warn("Failed to follow chain", "err", err)
+			}
+			timer.Reset(followRecheckInterval)
+
+		case <-f.ctx.Done():
+			return
+		}
+	}
+}
+
+// follow imports the blocks of the source on top of the local head, up to the
+// latest finalized block of the source.
+func (f *LocalFollower) follow() error {
+	target, err := f.source.Head(f.ctx)
+	if err != nil {
+		return err
+	}
+	var (
+		head  = f.qrl.BlockChain().CurrentBlock()
+		start = time.Now()
+	)
+	if target <= head.Number.Uint64() {
+		return nil
+	}
+	for number := head.Number.Uint64() + 1; number <= target; number++ {
+		if f.ctx.Err() != nil {
+			return f.ctx.Err()
+		}
+		block, err := f.source.BlockByNumber(f.ctx, number)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve block %d: %w", number, err)
+		}
+		if block.ParentHash() != head.Hash() {
+			return fmt.Errorf("%w: block %d parent %x, local head %x", errFollowerDiverged, number, block.ParentHash(), head.Hash())
+		}
+		if err := f.insert(block); err != nil {
+			return fmt.Errorf("failed to import block %d: %w", number, err)
+		}
+		head = block.Header()
+	}
+	log.Info("Followed finalized chain", "number", head.Number, "hash", head.Hash(), "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// insert feeds a single finalized block through the engine API and makes it the
+// head of the chain.
+func (f *LocalFollower) insert(block *types.Block) error {
+	payload := engine.BlockToExecutableData(block, nil).ExecutionPayload
+	if payload.Withdrawals == nil {
+		payload.Withdrawals = []*types.Withdrawal{}
+	}
+	var (
+		status engine.PayloadStatusV1
+		err    error
+	)
+	if beaconRoot := block.BeaconRoot(); beaconRoot != nil {
+		status, err = f.engineAPI.NewPayloadV3(*payload, beaconRoot)
+	} else {
+		status, err = f.engineAPI.NewPayloadV2(*payload)
+	}
+	if err != nil {
+		return err
+	}
+	if status.Status != engine.VALID {
+		return payloadError(status)
+	}
+	// The source only serves finalized blocks, so they are safe and final too
+	update := engine.ForkchoiceStateV1{
+		HeadBlockHash:      block.Hash(),
+		SafeBlockHash:      block.Hash(),
+		FinalizedBlockHash: block.Hash(),
+	}
+	res, err := f.engineAPI.ForkchoiceUpdatedV2(update, nil)
+	if err != nil {
+		return err
+	}
+	if res.PayloadStatus.Status != engine.VALID {
+		return payloadError(res.PayloadStatus)
+	}
+	return nil
+}
+
+// payloadError converts a non-valid payload status into an error.
+func payloadError(status engine.PayloadStatusV1) error {
+	if status.ValidationError != nil {
+		return fmt.Errorf("payload %s: %s", status.Status, *status.ValidationError)
+	}
+	return fmt.Errorf("payload %s", status.Status)
+}
+
+// archiveSource is a follower source serving the blocks of local era archives.
+type archiveSource struct {
+	eras []*era.Era
+}
+
+// NewArchiveSource creates a follower source from the era archives of the given
+// network in dir. The archives are verified upfront.
+func NewArchiveSource(dir, network string) (FollowerSource, error) {
+	files, err := era.ReadDir(dir, network)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no %s archives in %s", network, dir)
+	}
+	source := new(archiveSource)
+	for _, file := range files {
+		e, err := era.Open(filepath.Join(dir, file))
+		if err != nil {
+			source.Close()
+			return nil, err
+		}
+		source.eras = append(source.eras, e)
+		if _, err := e.Verify(); err != nil {
+			source.Close()
+			return nil, fmt.Errorf("invalid archive %s: %w", file, err)
+		}
+	}
+	return source, nil
+}
+
+// Head implements FollowerSource, returning the last block of the archives.
+func (s *archiveSource) Head(ctx context.Context) (uint64, error) {
+	last := s.eras[len(s.eras)-1]
+	return last.Start() + last.Count() - 1, nil
+}
+
+// BlockByNumber implements FollowerSource.
+func (s *archiveSource) BlockByNumber(ctx context.Context, number uint64) (*types.Block, error) {
+	for _, e := range s.eras {
+		if number >= e.Start() && number < e.Start()+e.Count() {
+			return e.GetBlockByNumber(number)
+		}
+	}
+	return nil, era.ErrNotFound
+}
+
+// Close implements FollowerSource, closing all the archives.
+func (s *archiveSource) Close() error {
+	for _, e := range s.eras {
+		e.Close()
+	}
+	s.eras = nil
+	return nil
+}
+
+// rpcSource is a follower source serving the finalized blocks of a remote node.
+type rpcSource struct {
+	client *qrlclient.Client
+}
+
+// NewRPCSource creates a follower source retrieving the finalized blocks of the
+// node at the given RPC endpoint.
+func NewRPCSource(ctx context.Context, endpoint string) (FollowerSource, error) {
+	client, err := qrlclient.DialContext(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcSource{client: client}, nil
+}
+
+// Head implements FollowerSource, returning the finalized block of the remote.
+func (s *rpcSource) Head(ctx context.Context) (uint64, error) {
+	header, err := s.client.HeaderByNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+	if err != nil {
+		return 0, err
+	}
+	return header.Number.Uint64(), nil
+}
+
+// BlockByNumber implements FollowerSource.
+func (s *rpcSource) BlockByNumber(ctx context.Context, number uint64) (*types.Block, error) {
+	return s.client.BlockByNumber(ctx, new(big.Int).SetUint64(number))
+}
+
+// Close implements FollowerSource, disconnecting from the remote.
+func (s *rpcSource) Close() error {
+	s.client.Close()
+	return nil
+}
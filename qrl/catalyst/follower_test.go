@@ -0,0 +1,122 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package catalyst
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/internal/era"
+)
+
+// testSource is a follower source serving an in-memory chain.
+type testSource struct {
+	head   uint64
+	blocks map[uint64]*types.Block
+}
+
+func (s *testSource) Head(ctx context.Context) (uint64, error) { return s.head, nil }
+func (s *testSource) Close() error                             { return nil }
+
+func (s *testSource) BlockByNumber(ctx context.Context, number uint64) (*types.Block, error) {
+	if block, ok := s.blocks[number]; ok {
+		return block, nil
+	}
+	return nil, errors.New("not found")
+}
+
+// Tests that the local follower imports the finalized chain of an archive
+// through the engine API.
+func TestLocalFollowerArchive(t *testing.T) {
+	genesis, blocks := generateChain(10)
+	n, qrlservice := startQRLService(t, genesis, blocks)
+	defer n.Close()
+
+	// Archive the source chain
+	var (
+		dir     = t.TempDir()
+		tmp     = filepath.Join(dir, "archive.tmp")
+		chain   = qrlservice.BlockChain()
+		f, _    = os.Create(tmp)
+		builder = era.NewBuilder(f)
+	)
+	for i := uint64(0); i <= chain.CurrentBlock().Number.Uint64(); i++ {
+		block := chain.GetBlockByNumber(i)
+		if err := builder.Add(block, chain.GetReceiptsByHash(block.Hash())); err != nil {
+			t.Fatalf("failed to archive block %d: %v", i, err)
+		}
+	}
+	root, err := builder.Finalize()
+	if err != nil {
+		t.Fatalf("failed to finalize archive: %v", err)
+	}
+	f.Close()
+	if err := os.Rename(tmp, filepath.Join(dir, era.Filename("test", 0, root))); err != nil {
+		t.Fatalf("failed to rename archive: %v", err)
+	}
+	// Follow the archive from a fresh node
+	source, err := NewArchiveSource(dir, "test")
+	if err != nil {
+		t.Fatalf("failed to open archive source: %v", err)
+	}
+	replica, replicaservice := startQRLService(t, genesis, nil)
+	defer replica.Close()
+
+	follower := NewLocalFollower(replicaservice, source)
+	defer source.Close()
+
+	if err := follower.follow(); err != nil {
+		t.Fatalf("failed to follow archive: %v", err)
+	}
+	want := blocks[len(blocks)-1].Hash()
+	if head := replicaservice.BlockChain().CurrentBlock().Hash(); head != want {
+		t.Fatalf("head mismatch: have %x, want %x", head, want)
+	}
+	if final := replicaservice.BlockChain().CurrentFinalBlock(); final == nil || final.Hash() != want {
+		t.Fatalf("finalized block mismatch: have %v, want %x", final, want)
+	}
+	// Following again without new blocks is a noop
+	if err := follower.follow(); err != nil {
+		t.Fatalf("failed to re-follow archive: %v", err)
+	}
+}
+
+// Tests that the local follower refuses to import blocks not extending the
+// local chain.
+func TestLocalFollowerDiverged(t *testing.T) {
+	genesis, blocks := generateChain(10)
+	n, qrlservice := startQRLService(t, genesis, nil)
+	defer n.Close()
+
+	source := &testSource{head: 10, blocks: make(map[uint64]*types.Block)}
+	for _, block := range blocks[:5] {
+		source.blocks[block.NumberU64()] = block
+	}
+	source.blocks[6] = blocks[6] // block 7 served as 6, not extending the chain
+
+	follower := NewLocalFollower(qrlservice, source)
+	if err := follower.follow(); !errors.Is(err, errFollowerDiverged) {
+		t.Fatalf("diverged source not rejected: %v", err)
+	}
+	if head := qrlservice.BlockChain().CurrentBlock().Number.Uint64(); head != 5 {
+		t.Fatalf("head mismatch: have %d, want 5", head)
+	}
+}
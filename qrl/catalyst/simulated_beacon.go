@@ -156,12 +156,28 @@ func (c *SimulatedBeacon) sealBlock(withdrawals []*types.Withdrawal, timestamp u
 
 	var random [32]byte
 	rand.Read(random[:])
-	fcResponse, err := c.engineAPI.ForkchoiceUpdatedV2(c.curForkchoiceState, &engine.PayloadAttributes{
+	attributes := &engine.PayloadAttributes{
 		Timestamp:             timestamp,
 		SuggestedFeeRecipient: feeRecipient,
 		Withdrawals:           withdrawals,
 		Random:                random,
-	})
+	}
+	// Post Prague, simulate a parent beacon block root for the payload
+	prague := c.qrl.BlockChain().Config().IsPrague(timestamp)
+	if prague {
+		var beaconRoot common.Hash
+		rand.Read(beaconRoot[:])
+		attributes.BeaconRoot = &beaconRoot
+	}
+	var (
+		fcResponse engine.ForkChoiceResponse
+		err        error
+	)
+	if prague {
+		fcResponse, err = c.engineAPI.ForkchoiceUpdatedV3(c.curForkchoiceState, attributes)
+	} else {
+		fcResponse, err = c.engineAPI.ForkchoiceUpdatedV2(c.curForkchoiceState, attributes)
+	}
 	if err != nil {
 		return err
 	}
@@ -186,7 +202,12 @@ func (c *SimulatedBeacon) sealBlock(withdrawals []*types.Withdrawal, timestamp u
 	}
 
 	// Mark the payload as canon
-	if _, err = c.engineAPI.NewPayloadV2(*payload); err != nil {
+	if prague {
+		_, err = c.engineAPI.NewPayloadV3(*payload, attributes.BeaconRoot)
+	} else {
+		_, err = c.engineAPI.NewPayloadV2(*payload)
+	}
+	if err != nil {
 		return err
 	}
 	c.setCurrentState(payload.BlockHash, finalizedHash)
@@ -237,7 +237,7 @@ func (oracle *Oracle) getBlockValues(ctx context.Context, blockNum uint64, limit
 		}
 		return
 	}
-	signer := types.MakeSigner(oracle.backend.ChainConfig())
+	signer := types.MakeSigner(oracle.backend.ChainConfig(), block.Time())
 
 	// Sort the transaction by effective tip in ascending sort.
 	txs := block.Transactions()
@@ -29,6 +29,7 @@ import (
 	"github.com/theQRL/go-zond/core/forkid"
 	"github.com/theQRL/go-zond/core/rawdb"
 	"github.com/theQRL/go-zond/core/txpool"
+	"github.com/theQRL/go-zond/core/txpool/largepool"
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/crypto"
 	"github.com/theQRL/go-zond/event"
@@ -51,7 +52,7 @@ const (
 
 	// txMaxBroadcastSize is the max size of a transaction that will be broadcasted.
 	// All transactions with a higher size will be announced and need to be fetched
-	// by the peer.
+	// by the peer. Transactions handled by the large pool are always announced.
 	txMaxBroadcastSize = 4096
 )
 
@@ -485,7 +486,7 @@ func (h *handler) BroadcastTransactions(txs types.Transactions) {
 	for _, tx := range txs {
 		var maybeDirect bool
 		switch {
-		case tx.Size() > txMaxBroadcastSize:
+		case tx.Size() > txMaxBroadcastSize || largepool.IsLarge(tx):
 			largeTxs++
 		default:
 			maybeDirect = true
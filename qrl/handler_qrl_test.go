@@ -72,6 +72,7 @@ func (h *testQRLHandler) Handle(peer *qrl.Peer, packet qrl.Packet) error {
 // Tests that peers are correctly accepted (or rejected) based on the advertised
 // fork IDs in the protocol handshake.
 func TestForkIDSplit1(t *testing.T) { testForkIDSplit(t, qrl.QRL1) }
+func TestForkIDSplit2(t *testing.T) { testForkIDSplit(t, qrl.QRL2) }
 
 func testForkIDSplit(t *testing.T, protocol uint) {
 	t.Parallel()
@@ -221,6 +222,7 @@ func testForkIDSplit(t *testing.T, protocol uint) {
 
 // Tests that received transactions are added to the local pool.
 func TestRecvTransactions1(t *testing.T) { testRecvTransactions(t, qrl.QRL1) }
+func TestRecvTransactions2(t *testing.T) { testRecvTransactions(t, qrl.QRL2) }
 
 func testRecvTransactions(t *testing.T, protocol uint) {
 	t.Parallel()
@@ -284,6 +286,7 @@ func testRecvTransactions(t *testing.T, protocol uint) {
 
 // This test checks that pending transactions are sent.
 func TestSendTransactions1(t *testing.T) { testSendTransactions(t, qrl.QRL1) }
+func TestSendTransactions2(t *testing.T) { testSendTransactions(t, qrl.QRL2) }
 
 func testSendTransactions(t *testing.T, protocol uint) {
 	t.Parallel()
@@ -347,7 +350,7 @@ func testSendTransactions(t *testing.T, protocol uint) {
 	seen := make(map[common.Hash]struct{})
 	for len(seen) < len(insert) {
 		switch protocol {
-		case 1:
+		case 1, 2:
 			select {
 			case hashes := <-anns:
 				for _, hash := range hashes {
@@ -374,6 +377,7 @@ func testSendTransactions(t *testing.T, protocol uint) {
 // Tests that transactions get propagated to all attached peers, either via direct
 // broadcasts or via announcements/retrievals.
 func TestTransactionPropagation1(t *testing.T) { testTransactionPropagation(t, qrl.QRL1) }
+func TestTransactionPropagation2(t *testing.T) { testTransactionPropagation(t, qrl.QRL2) }
 
 func testTransactionPropagation(t *testing.T, protocol uint) {
 	t.Parallel()
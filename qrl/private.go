@@ -0,0 +1,59 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package qrl
+
+import (
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/log"
+)
+
+// startPrivateTxExpirer starts a goroutine which keeps the private transactions
+// of the miner in sync with the chain head, publishing the expired ones through
+// the transaction pool so they get propagated to the network. The goroutine is
+// waited for on shutdown, so the pool is never added to after being closed.
+func (qrl *QRL) startPrivateTxExpirer() {
+	heads := make(chan core.ChainHeadEvent, 16)
+	sub := qrl.blockchain.SubscribeChainHeadEvent(heads)
+
+	qrl.privateTxExpirerWg.Add(1)
+	go func() {
+		defer qrl.privateTxExpirerWg.Done()
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case <-qrl.closePrivateTxExpirer:
+				return
+
+			case <-sub.Err():
+				return
+
+			case head := <-heads:
+				expired := qrl.miner.ExpirePrivateTxs(head.Block.Header())
+				if len(expired) == 0 {
+					continue
+				}
+				log.Info("Publishing expired private transactions", "count", len(expired), "number", head.Block.Number())
+				for i, err := range qrl.txPool.Add(expired, true, false) {
+					if err != nil {
+						log.Debug("Failed to publish expired private transaction", "hash", expired[i].Hash(), "err", err)
+					}
+				}
+			}
+		}
+	}()
+}
@@ -121,6 +121,7 @@ func (b *testBackend) Handle(*Peer, Packet) error {
 
 // Tests that block headers can be retrieved from a remote chain based on user queries.
 func TestGetBlockHeaders1(t *testing.T) { testGetBlockHeaders(t, QRL1) }
+func TestGetBlockHeaders2(t *testing.T) { testGetBlockHeaders(t, QRL2) }
 
 func testGetBlockHeaders(t *testing.T, protocol uint) {
 	t.Parallel()
@@ -306,6 +307,7 @@ func testGetBlockHeaders(t *testing.T, protocol uint) {
 
 // Tests that block contents can be retrieved from a remote chain based on their hashes.
 func TestGetBlockBodies1(t *testing.T) { testGetBlockBodies(t, QRL1) }
+func TestGetBlockBodies2(t *testing.T) { testGetBlockBodies(t, QRL2) }
 
 func testGetBlockBodies(t *testing.T, protocol uint) {
 	t.Parallel()
@@ -400,6 +402,7 @@ func testGetBlockBodies(t *testing.T, protocol uint) {
 
 // Tests that the transaction receipts can be retrieved based on hashes.
 func TestGetBlockReceipts1(t *testing.T) { testGetBlockReceipts(t, QRL1) }
+func TestGetBlockReceipts2(t *testing.T) { testGetBlockReceipts(t, QRL2) }
 
 func testGetBlockReceipts(t *testing.T, protocol uint) {
 	t.Parallel()
@@ -299,6 +299,33 @@ func handleBlockBodies(backend Backend, msg Decoder, peer *Peer) error {
 	if err := msg.Decode(res); err != nil {
 		return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
 	}
+	return deliverBlockBodies(peer, res)
+}
+
+func handleBlockBodies2(backend Backend, msg Decoder, peer *Peer) error {
+	// A batch of block bodies arrived to one of our previous requests, restore
+	// any public keys elided by the remote peer
+	var enc BlockBodiesRLPPacket
+	if err := msg.Decode(&enc); err != nil {
+		return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+	}
+	res := &BlockBodiesPacket{
+		RequestId:           enc.RequestId,
+		BlockBodiesResponse: make(BlockBodiesResponse, len(enc.BlockBodiesRLPResponse)),
+	}
+	for i, body := range enc.BlockBodiesRLPResponse {
+		decoded, err := peer.decodeBody(body)
+		if err != nil {
+			return fmt.Errorf("%w: message %v: body %d: %v", errDecode, msg, i, err)
+		}
+		res.BlockBodiesResponse[i] = decoded
+	}
+	return deliverBlockBodies(peer, res)
+}
+
+// deliverBlockBodies dispatches a batch of block bodies to the request it
+// answers.
+func deliverBlockBodies(peer *Peer, res *BlockBodiesPacket) error {
 	metadata := func() interface{} {
 		var (
 			txsHashes        = make([]common.Hash, len(res.BlockBodiesResponse))
@@ -409,6 +436,29 @@ func handleTransactions(backend Backend, msg Decoder, peer *Peer) error {
 	if err := msg.Decode(&txs); err != nil {
 		return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
 	}
+	return deliverTransactions(backend, peer, txs)
+}
+
+func handleTransactions2(backend Backend, msg Decoder, peer *Peer) error {
+	// Public keys need to be tracked even if transactions are not accepted, as
+	// later messages may elide them
+	var enc TransactionsRLPPacket
+	if err := msg.Decode(&enc); err != nil {
+		return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+	}
+	txs, err := peer.decodeTransactions(enc)
+	if err != nil {
+		return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+	}
+	if !backend.AcceptTxs() {
+		return nil
+	}
+	return deliverTransactions(backend, peer, txs)
+}
+
+// deliverTransactions marks broadcast transactions as known by the peer and
+// hands them to the backend.
+func deliverTransactions(backend Backend, peer *Peer, txs TransactionsPacket) error {
 	for i, tx := range txs {
 		// Validate and mark the remote transaction
 		if tx == nil {
@@ -429,6 +479,32 @@ func handlePooledTransactions(backend Backend, msg Decoder, peer *Peer) error {
 	if err := msg.Decode(&txs); err != nil {
 		return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
 	}
+	return deliverPooledTransactions(backend, peer, &txs)
+}
+
+func handlePooledTransactions2(backend Backend, msg Decoder, peer *Peer) error {
+	// Public keys need to be tracked even if transactions are not accepted, as
+	// later messages may elide them
+	var enc PooledTransactionsRLPPacket
+	if err := msg.Decode(&enc); err != nil {
+		return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+	}
+	txs, err := peer.decodeTransactions(enc.PooledTransactionsRLPResponse)
+	if err != nil {
+		return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+	}
+	if !backend.AcceptTxs() {
+		return nil
+	}
+	return deliverPooledTransactions(backend, peer, &PooledTransactionsPacket{
+		RequestId:                  enc.RequestId,
+		PooledTransactionsResponse: txs,
+	})
+}
+
+// deliverPooledTransactions marks requested transactions as known by the peer,
+// fulfils the request and hands them to the backend.
+func deliverPooledTransactions(backend Backend, peer *Peer, txs *PooledTransactionsPacket) error {
 	for i, tx := range txs.PooledTransactionsResponse {
 		// Validate and mark the remote transaction
 		if tx == nil {
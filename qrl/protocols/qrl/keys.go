@@ -0,0 +1,256 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package qrl
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/theQRL/go-qrllib/wallet/common/descriptor"
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/common/lru"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
+	"github.com/theQRL/go-zond/rlp"
+)
+
+const (
+	// maxKnownKeys is the maximum number of public keys remembered per direction
+	// of a qrl/2 connection for public key elision.
+	maxKnownKeys = 1024
+
+	// maxKnownKeysSize is the maximum total size of the public keys remembered
+	// per direction of a qrl/2 connection. It bounds the memory a peer can make
+	// the receiving end hold to about a hundred ML-DSA-87 keys.
+	maxKnownKeysSize = 256 * 1024
+)
+
+// errUnknownPublicKey is returned if a remote peer elides a public key which it
+// never sent over the connection.
+var errUnknownPublicKey = errors.New("unknown elided public key")
+
+// keylessTransaction is the qrl/2 wire form of a transaction whose public key
+// was already sent over the connection. Regular transactions are encoded as RLP
+// strings, keyless ones as lists, so the two can be told apart.
+//
+// Only public keys sent over the same connection are elided, keys of senders
+// already on-chain are not. The public key table is only kept by nodes storing
+// compact bodies and only covers frozen blocks, so the sending end can't tell
+// whether the receiving end is able to restore such a key, and a wrong guess
+// would leave the bodies served to a syncing node undecodable.
+type keylessTransaction struct {
+	Sender common.Address // Address the elided public key belongs to
+	Tx     []byte         // Binary encoding of the transaction without public key
+}
+
+// keyCache is an LRU set of the public keys sent over one direction of a
+// connection, bounded both in count and total key size. Both ends insert and
+// look up keys in wire order and evict the same way, so a key the sender still
+// considers known is guaranteed to be known by the receiver too.
+type keyCache struct {
+	keys    lru.BasicLRU[common.Address, cachedKey]
+	size    int  // Total size of the public keys in the cache
+	maxKeys int  // Maximum number of public keys in the cache
+	maxSize int  // Maximum total size of the public keys in the cache
+	retain  bool // Whether the public keys are retained or only tracked
+}
+
+// cachedKey is a public key tracked by a keyCache. The sending end of the
+// connection only needs to know whether a key was sent, so it drops the key
+// itself and keeps its size for evicting in lockstep with the receiving end.
+type cachedKey struct {
+	pk   []byte
+	size int
+}
+
+// newKeyCache creates a new keyCache with a max count and total size. If retain
+// is not set, only the presence of the keys is tracked.
+func newKeyCache(maxKeys int, maxSize int, retain bool) *keyCache {
+	return &keyCache{
+		keys:    lru.NewBasicLRU[common.Address, cachedKey](maxKeys),
+		maxKeys: maxKeys,
+		maxSize: maxSize,
+		retain:  retain,
+	}
+}
+
+// add inserts a public key, evicting the least recently used ones until it
+// fits. Already known keys are left in place.
+func (c *keyCache) add(addr common.Address, pk []byte) {
+	if c.keys.Contains(addr) {
+		return
+	}
+	for c.keys.Len() > 0 && (c.keys.Len() >= c.maxKeys || c.size+len(pk) > c.maxSize) {
+		_, evicted, _ := c.keys.RemoveOldest()
+		c.size -= evicted.size
+	}
+	entry := cachedKey{size: len(pk)}
+	if c.retain {
+		entry.pk = pk
+	}
+	c.keys.Add(addr, entry)
+	c.size += entry.size
+}
+
+// get retrieves a public key from the cache, marking it as recently used. The
+// returned key is nil if the cache doesn't retain keys.
+func (c *keyCache) get(addr common.Address) ([]byte, bool) {
+	entry, ok := c.keys.Get(addr)
+	return entry.pk, ok
+}
+
+// publicKeySender derives the address of the public key carried by a transaction,
+// if it has a valid one.
+func publicKeySender(tx *types.Transaction) (common.Address, []byte, bool) {
+	pk := tx.RawPublicKeyValue()
+	if len(pk) == 0 {
+		return common.Address{}, nil, false
+	}
+	desc, err := descriptor.FromBytes(tx.RawDescriptorValue())
+	if err != nil {
+		return common.Address{}, nil, false
+	}
+	addr, err := pqcrypto.PKToAddress(pk, desc)
+	if err != nil {
+		return common.Address{}, nil, false
+	}
+	return addr, pk, true
+}
+
+// encodeTransaction encodes a transaction for the wire, eliding its public key
+// if it was already sent to the peer. The caller must hold keyLock until the
+// encoded transaction is written, and must drop the peer if the write fails.
+func (p *Peer) encodeTransaction(tx *types.Transaction) (rlp.RawValue, error) {
+	addr, pk, ok := publicKeySender(tx)
+	if !ok {
+		return rlp.EncodeToBytes(tx)
+	}
+	if _, known := p.sentKeys.get(addr); !known {
+		p.sentKeys.add(addr, pk)
+		return rlp.EncodeToBytes(tx)
+	}
+	enc, err := tx.WithPublicKey(nil).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return rlp.EncodeToBytes(&keylessTransaction{Sender: addr, Tx: enc})
+}
+
+// encodeTransactionRLP re-encodes an RLP encoded transaction for the wire,
+// eliding its public key if it was already sent to the peer.
+func (p *Peer) encodeTransactionRLP(enc rlp.RawValue) (rlp.RawValue, error) {
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(enc, tx); err != nil {
+		return nil, err
+	}
+	return p.encodeTransaction(tx)
+}
+
+// encodeBodyRLP re-encodes an RLP encoded block body for the wire, eliding the
+// public keys already sent to the peer.
+func (p *Peer) encodeBodyRLP(body rlp.RawValue) (rlp.RawValue, error) {
+	content, _, err := rlp.SplitList(body)
+	if err != nil {
+		return nil, err
+	}
+	txs, rest, err := rlp.SplitList(content)
+	if err != nil {
+		return nil, err
+	}
+	w := rlp.NewEncoderBuffer(nil)
+	outer := w.List()
+	inner := w.List()
+	for len(txs) > 0 {
+		_, _, remaining, err := rlp.Split(txs)
+		if err != nil {
+			return nil, err
+		}
+		enc, err := p.encodeTransactionRLP(txs[:len(txs)-len(remaining)])
+		if err != nil {
+			return nil, err
+		}
+		w.Write(enc)
+		txs = remaining
+	}
+	w.ListEnd(inner)
+	w.Write(rest)
+	w.ListEnd(outer)
+	return w.ToBytes(), nil
+}
+
+// decodeTransaction decodes a transaction received over the wire, restoring its
+// public key if it was elided by the peer.
+func (p *Peer) decodeTransaction(enc rlp.RawValue) (*types.Transaction, error) {
+	kind, _, _, err := rlp.Split(enc)
+	if err != nil {
+		return nil, err
+	}
+	if kind != rlp.List {
+		tx := new(types.Transaction)
+		if err := rlp.DecodeBytes(enc, tx); err != nil {
+			return nil, err
+		}
+		if addr, pk, ok := publicKeySender(tx); ok {
+			p.recvKeys.add(addr, pk)
+		}
+		return tx, nil
+	}
+	var keyless keylessTransaction
+	if err := rlp.DecodeBytes(enc, &keyless); err != nil {
+		return nil, err
+	}
+	pk, ok := p.recvKeys.get(keyless.Sender)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", errUnknownPublicKey, keyless.Sender)
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(keyless.Tx); err != nil {
+		return nil, err
+	}
+	return tx.WithPublicKey(pk), nil
+}
+
+// decodeTransactions decodes a list of transactions received over the wire,
+// restoring any elided public keys.
+func (p *Peer) decodeTransactions(encs []rlp.RawValue) ([]*types.Transaction, error) {
+	txs := make([]*types.Transaction, len(encs))
+	for i, enc := range encs {
+		tx, err := p.decodeTransaction(enc)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+		txs[i] = tx
+	}
+	return txs, nil
+}
+
+// decodeBody decodes a block body received over the wire, restoring any elided
+// public keys.
+func (p *Peer) decodeBody(enc rlp.RawValue) (*BlockBody, error) {
+	var body struct {
+		Transactions []rlp.RawValue
+		Withdrawals  []*types.Withdrawal `rlp:"optional"`
+	}
+	if err := rlp.DecodeBytes(enc, &body); err != nil {
+		return nil, err
+	}
+	txs, err := p.decodeTransactions(body.Transactions)
+	if err != nil {
+		return nil, err
+	}
+	return &BlockBody{Transactions: txs, Withdrawals: body.Withdrawals}, nil
+}
@@ -53,6 +53,9 @@ type Peer struct {
 
 	txpool      TxPool             // Transaction pool used by the broadcasters for liveness checks
 	knownTxs    *knownCache        // Set of transaction hashes known to be known by this peer
+	sentKeys    *keyCache          // Public keys sent to this peer, elided on resend (qrl/2)
+	recvKeys    *keyCache          // Public keys received from this peer, restored if elided (qrl/2)
+	keyLock     sync.Mutex         // Mutex serialising sends that may elide public keys
 	txBroadcast chan []common.Hash // Channel used to queue transaction propagation requests
 	txAnnounce  chan []common.Hash // Channel used to queue transaction announcement requests
 
@@ -73,6 +76,8 @@ func NewPeer(version uint, p *p2p.Peer, rw p2p.MsgReadWriter, txpool TxPool) *Pe
 		rw:          rw,
 		version:     version,
 		knownTxs:    newKnownCache(maxKnownTxs),
+		sentKeys:    newKeyCache(maxKnownKeys, maxKnownKeysSize, false),
+		recvKeys:    newKeyCache(maxKnownKeys, maxKnownKeysSize, true),
 		txBroadcast: make(chan []common.Hash),
 		txAnnounce:  make(chan []common.Hash),
 		reqDispatch: make(chan *request),
@@ -149,7 +154,21 @@ func (p *Peer) SendTransactions(txs types.Transactions) error {
 	for _, tx := range txs {
 		p.knownTxs.Add(tx.Hash())
 	}
-	return p2p.Send(p.rw, TransactionsMsg, txs)
+	if p.version < QRL2 {
+		return p2p.Send(p.rw, TransactionsMsg, txs)
+	}
+	p.keyLock.Lock()
+	defer p.keyLock.Unlock()
+
+	encs := make(TransactionsRLPPacket, len(txs))
+	for i, tx := range txs {
+		enc, err := p.encodeTransaction(tx)
+		if err != nil {
+			return err
+		}
+		encs[i] = enc
+	}
+	return p2p.Send(p.rw, TransactionsMsg, encs)
 }
 
 // AsyncSendTransactions queues a list of transactions (by hash) to eventually
@@ -196,6 +215,20 @@ func (p *Peer) ReplyPooledTransactionsRLP(id uint64, hashes []common.Hash, txs [
 	// Mark all the transactions as known, but ensure we don't overflow our limits
 	p.knownTxs.Add(hashes...)
 
+	if p.version >= QRL2 {
+		p.keyLock.Lock()
+		defer p.keyLock.Unlock()
+
+		encs := make([]rlp.RawValue, len(txs))
+		for i, tx := range txs {
+			enc, err := p.encodeTransactionRLP(tx)
+			if err != nil {
+				return err
+			}
+			encs[i] = enc
+		}
+		txs = encs
+	}
 	// Not packed into PooledTransactionsResponse to avoid RLP decoding
 	return p2p.Send(p.rw, PooledTransactionsMsg, &PooledTransactionsRLPPacket{
 		RequestId:                     id,
@@ -213,6 +246,20 @@ func (p *Peer) ReplyBlockHeadersRLP(id uint64, headers []rlp.RawValue) error {
 
 // ReplyBlockBodiesRLP is the response to GetBlockBodies.
 func (p *Peer) ReplyBlockBodiesRLP(id uint64, bodies []rlp.RawValue) error {
+	if p.version >= QRL2 {
+		p.keyLock.Lock()
+		defer p.keyLock.Unlock()
+
+		encs := make([]rlp.RawValue, len(bodies))
+		for i, body := range bodies {
+			enc, err := p.encodeBodyRLP(body)
+			if err != nil {
+				return err
+			}
+			encs[i] = enc
+		}
+		bodies = encs
+	}
 	// Not packed into BlockBodiesResponse to avoid RLP decoding
 	return p2p.Send(p.rw, BlockBodiesMsg, &BlockBodiesRLPPacket{
 		RequestId:              id,
@@ -21,11 +21,15 @@ package qrl
 
 import (
 	"crypto/rand"
+	"errors"
+	"math/big"
 	"testing"
 
 	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/p2p"
 	"github.com/theQRL/go-zond/p2p/qnode"
+	"github.com/theQRL/go-zond/rlp"
 )
 
 // testPeer is a simulated peer to allow testing direct network calls.
@@ -88,3 +92,150 @@ func TestPeerSet(t *testing.T) {
 		t.Fatalf("bad size")
 	}
 }
+
+func TestKeyCacheEviction(t *testing.T) {
+	c := newKeyCache(2, 4, true)
+	for i := 0; i < 3; i++ {
+		c.add(common.Address{byte(i)}, []byte{byte(i)})
+	}
+	if _, ok := c.get(common.Address{0}); ok {
+		t.Fatalf("oldest key not evicted")
+	}
+	// Looking up a key refreshes it, re-adding it doesn't
+	c.get(common.Address{1})
+	c.add(common.Address{2}, []byte{2})
+	c.add(common.Address{3}, []byte{3})
+	if _, ok := c.get(common.Address{2}); ok {
+		t.Fatalf("least recently used key not evicted")
+	}
+	for _, addr := range []common.Address{{1}, {3}} {
+		if pk, ok := c.get(addr); !ok || pk[0] != addr[0] {
+			t.Fatalf("key %x missing", addr)
+		}
+	}
+	// Keys are evicted until the new one fits in the size limit
+	c.add(common.Address{4}, []byte{4, 4, 4})
+	if _, ok := c.get(common.Address{1}); ok {
+		t.Fatalf("least recently used key not evicted for size")
+	}
+	for _, addr := range []common.Address{{3}, {4}} {
+		if _, ok := c.get(addr); !ok {
+			t.Fatalf("key %x missing", addr)
+		}
+	}
+	if c.size != 4 {
+		t.Fatalf("cache size mismatch: have %d, want 4", c.size)
+	}
+	// Caches not retaining keys track them all the same
+	c = newKeyCache(2, 4, false)
+	c.add(common.Address{1}, []byte{1, 1, 1})
+	if pk, ok := c.get(common.Address{1}); !ok || pk != nil {
+		t.Fatalf("tracked key mismatch: have %x, %v", pk, ok)
+	}
+	c.add(common.Address{2}, []byte{2, 2})
+	if _, ok := c.get(common.Address{1}); ok {
+		t.Fatalf("tracked key not evicted")
+	}
+}
+
+// Tests that public keys already sent over a qrl/2 connection are elided from
+// later transactions and restored by the receiving side.
+func TestPublicKeyElision(t *testing.T) {
+	app, net := p2p.MsgPipe()
+	defer app.Close()
+
+	var id qnode.ID
+	rand.Read(id[:])
+	sender := NewPeer(QRL2, p2p.NewPeer(id, "sender", nil), net, nil)
+	defer sender.Close()
+	receiver := NewPeer(QRL2, p2p.NewPeer(id, "receiver", nil), app, nil)
+	defer receiver.Close()
+
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+	txs := make(types.Transactions, 3)
+	for i := range txs {
+		txs[i] = types.MustSignNewTx(testKey, signer, &types.DynamicFeeTx{
+			ChainID:   big.NewInt(1),
+			Nonce:     uint64(i),
+			Gas:       21000,
+			GasFeeCap: big.NewInt(1),
+		})
+	}
+	for i, batch := range []types.Transactions{txs[:1], txs[1:]} {
+		errc := make(chan error, 1)
+		go func() { errc <- sender.SendTransactions(batch) }()
+
+		msg, err := app.ReadMsg()
+		if err != nil {
+			t.Fatalf("batch %d: failed to read message: %v", i, err)
+		}
+		var enc TransactionsRLPPacket
+		if err := msg.Decode(&enc); err != nil {
+			t.Fatalf("batch %d: failed to decode message: %v", i, err)
+		}
+		if err := <-errc; err != nil {
+			t.Fatalf("batch %d: failed to send transactions: %v", i, err)
+		}
+		for j, tx := range enc {
+			kind, _, _, _ := rlp.Split(tx)
+			if elided := kind == rlp.List; elided != (i > 0) {
+				t.Errorf("batch %d, tx %d: elided %v, want %v", i, j, elided, i > 0)
+			}
+		}
+		have, err := receiver.decodeTransactions(enc)
+		if err != nil {
+			t.Fatalf("batch %d: failed to restore transactions: %v", i, err)
+		}
+		for j, tx := range have {
+			if tx.Hash() != batch[j].Hash() {
+				t.Errorf("batch %d, tx %d: hash mismatch: have %x, want %x", i, j, tx.Hash(), batch[j].Hash())
+			}
+		}
+	}
+	// A receiver that never saw the key must reject elided transactions
+	enc, err := rlp.EncodeToBytes(&keylessTransaction{Sender: testAddr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (&Peer{recvKeys: newKeyCache(maxKnownKeys, maxKnownKeysSize, true)}).decodeTransaction(enc); !errors.Is(err, errUnknownPublicKey) {
+		t.Fatalf("unexpected error: have %v, want %v", err, errUnknownPublicKey)
+	}
+}
+
+// Tests that public keys are never elided over a qrl/1 connection.
+func TestPublicKeyElisionFallback(t *testing.T) {
+	app, net := p2p.MsgPipe()
+	defer app.Close()
+
+	var id qnode.ID
+	rand.Read(id[:])
+	sender := NewPeer(QRL1, p2p.NewPeer(id, "sender", nil), net, nil)
+	defer sender.Close()
+
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+	for i := 0; i < 2; i++ {
+		tx := types.MustSignNewTx(testKey, signer, &types.DynamicFeeTx{
+			ChainID:   big.NewInt(1),
+			Nonce:     uint64(i),
+			Gas:       21000,
+			GasFeeCap: big.NewInt(1),
+		})
+		errc := make(chan error, 1)
+		go func() { errc <- sender.SendTransactions(types.Transactions{tx}) }()
+
+		msg, err := app.ReadMsg()
+		if err != nil {
+			t.Fatalf("tx %d: failed to read message: %v", i, err)
+		}
+		var enc TransactionsRLPPacket
+		if err := msg.Decode(&enc); err != nil {
+			t.Fatalf("tx %d: failed to decode message: %v", i, err)
+		}
+		if err := <-errc; err != nil {
+			t.Fatalf("tx %d: failed to send transactions: %v", i, err)
+		}
+		if kind, _, _, _ := rlp.Split(enc[0]); kind == rlp.List {
+			t.Errorf("tx %d: public key elided over qrl/1", i)
+		}
+	}
+}
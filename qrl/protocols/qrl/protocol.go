@@ -30,6 +30,7 @@ import (
 // Constants to match up protocol versions and messages
 const (
 	QRL1 = 1
+	QRL2 = 2
 )
 
 // ProtocolName is the official short name of the `qrl` protocol used during
@@ -38,11 +39,11 @@ const ProtocolName = "qrl"
 
 // ProtocolVersions are the supported versions of the `qrl` protocol (first
 // is primary).
-var ProtocolVersions = []uint{QRL1}
+var ProtocolVersions = []uint{QRL2, QRL1}
 
 // protocolLengths are the number of implemented message corresponding to
 // different protocol versions.
-var protocolLengths = map[uint]uint64{QRL1: 17}
+var protocolLengths = map[uint]uint64{QRL2: 17, QRL1: 17}
 
 // maxMessageSize is the maximum cap on the size of a protocol message.
 const maxMessageSize = 10 * 1024 * 1024
@@ -90,6 +91,11 @@ type StatusPacket struct {
 // TransactionsPacket is the network packet for broadcasting new transactions.
 type TransactionsPacket []*types.Transaction
 
+// TransactionsRLPPacket is the network packet for broadcasting new transactions
+// on qrl/2, where the transactions are kept encoded as their public keys might
+// have been elided.
+type TransactionsRLPPacket []rlp.RawValue
+
 // GetBlockHeadersRequest represents a block header query.
 type GetBlockHeadersRequest struct {
 	Origin  HashOrNumber // Block from which to retrieve headers
@@ -25,6 +25,7 @@ import (
 	"github.com/theQRL/go-zond/consensus/beacon"
 	"github.com/theQRL/go-zond/core"
 	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/txpool/largepool"
 	"github.com/theQRL/go-zond/core/txpool/legacypool"
 	"github.com/theQRL/go-zond/miner"
 	"github.com/theQRL/go-zond/params"
@@ -57,6 +58,7 @@ var Defaults = Config{
 	FilterLogCacheSize: 32,
 	Miner:              miner.DefaultConfig,
 	TxPool:             legacypool.DefaultConfig,
+	LargePool:          largepool.DefaultConfig,
 	RPCGasCap:          50000000,
 	RPCQRVMTimeout:     5 * time.Second,
 	GPO:                FullNodeGPO,
@@ -86,6 +88,7 @@ type Config struct {
 
 	TransactionHistory uint64 `toml:",omitempty"` // The maximum number of blocks from head whose tx indices are reserved.
 	StateHistory       uint64 `toml:",omitempty"` // The maximum number of blocks from head whose state histories are reserved.
+	ChainHistory       uint64 `toml:",omitempty"` // The maximum number of blocks from head whose bodies and receipts are reserved.
 
 	// State scheme represents the scheme used to store qrl states and trie
 	// nodes on top. It can be 'hash', 'path', or none which means use the scheme
@@ -103,6 +106,10 @@ type Config struct {
 	DatabaseCache      int
 	DatabaseFreezer    string
 
+	// CompactBodies moves transaction public keys out of frozen block bodies
+	// into a table keyed by sender address.
+	CompactBodies bool
+
 	TrieCleanCache int
 	TrieDirtyCache int
 	TrieTimeout    time.Duration
@@ -116,7 +123,8 @@ type Config struct {
 	Miner miner.Config
 
 	// Transaction pool options
-	TxPool legacypool.Config
+	TxPool    legacypool.Config
+	LargePool largepool.Config
 
 	// Gas Price Oracle options
 	GPO gasprice.Config
@@ -124,6 +132,10 @@ type Config struct {
 	// Enables tracking of SHA3 preimages in the VM
 	EnablePreimageRecording bool
 
+	// Enables the named live tracer during block import
+	VMTrace           string
+	VMTraceJsonConfig string
+
 	// Miscellaneous options
 	DocRoot string `toml:"-"`
 
@@ -232,11 +232,18 @@ func (qrl *QRL) stateAtTransaction(ctx context.Context, block *types.Block, txIn
 	if err != nil {
 		return nil, vm.BlockContext{}, nil, nil, err
 	}
+	// Insert parent beacon block root in the state as per EIP-4788.
+	core.DeployBeaconRoots(qrl.blockchain.Config(), parent.Time(), block.Time(), statedb)
+	if beaconRoot := block.BeaconRoot(); beaconRoot != nil {
+		context := core.NewQRVMBlockContext(block.Header(), qrl.blockchain, nil)
+		vmenv := vm.NewQRVM(context, vm.TxContext{}, statedb, qrl.blockchain.Config(), vm.Config{})
+		core.ProcessBeaconBlockRoot(*beaconRoot, vmenv, statedb)
+	}
 	if txIndex == 0 && len(block.Transactions()) == 0 {
 		return nil, vm.BlockContext{}, statedb, release, nil
 	}
 	// Recompute transactions up to the target index.
-	signer := types.MakeSigner(qrl.blockchain.Config())
+	signer := types.MakeSigner(qrl.blockchain.Config(), block.Time())
 	for idx, tx := range block.Transactions() {
 		// Assemble the transaction call message and return if the requested offset
 		msg, _ := core.TransactionToMessage(tx, signer, block.BaseFee())
@@ -65,6 +65,10 @@ const (
 	// for tracing. The creation of trace state will be paused if the unused
 	// trace states exceed this limit.
 	maximumPendingTraceStates = 128
+
+	// maxTraceCallManyCalls is the maximum number of calls TraceCallMany traces
+	// in a single request, across all bundles.
+	maxTraceCallManyCalls = 1000
 )
 
 var errTxNotFound = errors.New("transaction not found")
@@ -144,6 +148,28 @@ func (api *API) blockByNumberAndHash(ctx context.Context, number rpc.BlockNumber
 	return api.blockByHash(ctx, hash)
 }
 
+// blockByNumberOrHash is the wrapper of the chain access function offered by
+// the backend. It will return an error if the block is not found or if the
+// pending block is requested, as the miner is not accessible here.
+func (api *API) blockByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*types.Block, error) {
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		return api.blockByHash(ctx, hash)
+	}
+	number, ok := blockNrOrHash.Number()
+	if !ok {
+		return nil, errors.New("invalid arguments; neither block nor hash specified")
+	}
+	if number == rpc.PendingBlockNumber {
+		// We don't have access to the miner here. For tracing 'future' transactions,
+		// it can be done with block- and state-overrides instead, which offers
+		// more flexibility and stability than trying to trace on 'pending', since
+		// the contents of 'pending' is unstable and probably not a true representation
+		// of what the next actual block is likely to contain.
+		return nil, errors.New("tracing on top of pending is not supported")
+	}
+	return api.blockByNumber(ctx, number)
+}
+
 // TraceConfig holds extra parameters to trace functions.
 type TraceConfig struct {
 	*logger.Config
@@ -261,7 +287,7 @@ func (api *API) traceChain(start, end *types.Block, config *TraceConfig, closed
 			// Fetch and execute the block trace taskCh
 			for task := range taskCh {
 				var (
-					signer   = types.MakeSigner(api.backend.ChainConfig())
+					signer   = types.MakeSigner(api.backend.ChainConfig(), task.block.Time())
 					blockCtx = core.NewQRVMBlockContext(task.block.Header(), api.chainContext(ctx), nil)
 				)
 				// Trace all the transactions contained within
@@ -379,6 +405,14 @@ func (api *API) traceChain(start, end *types.Block, config *TraceConfig, closed
 			// may fail if we release too early.
 			tracker.callReleases()
 
+			// Insert block's parent beacon block root in the state
+			// as per EIP-4788.
+			core.DeployBeaconRoots(api.backend.ChainConfig(), block.Time(), next.Time(), statedb)
+			if beaconRoot := next.BeaconRoot(); beaconRoot != nil {
+				context := core.NewQRVMBlockContext(next.Header(), api.chainContext(ctx), nil)
+				vmenv := vm.NewQRVM(context, vm.TxContext{}, statedb, api.backend.ChainConfig(), vm.Config{})
+				core.ProcessBeaconBlockRoot(*beaconRoot, vmenv, statedb)
+			}
 			// Send the block over to the concurrent tracers (if not in the fast-forward phase)
 			txs := next.Transactions()
 			select {
@@ -517,11 +551,16 @@ func (api *API) IntermediateRoots(ctx context.Context, hash common.Hash, config
 
 	var (
 		roots              []common.Hash
-		signer             = types.MakeSigner(api.backend.ChainConfig())
+		signer             = types.MakeSigner(api.backend.ChainConfig(), block.Time())
 		chainConfig        = api.backend.ChainConfig()
 		vmctx              = core.NewQRVMBlockContext(block.Header(), api.chainContext(ctx), nil)
 		deleteEmptyObjects = true
 	)
+	core.DeployBeaconRoots(chainConfig, parent.Time(), block.Time(), statedb)
+	if beaconRoot := block.BeaconRoot(); beaconRoot != nil {
+		vmenv := vm.NewQRVM(vmctx, vm.TxContext{}, statedb, chainConfig, vm.Config{})
+		core.ProcessBeaconBlockRoot(*beaconRoot, vmenv, statedb)
+	}
 	for i, tx := range block.Transactions() {
 		if err := ctx.Err(); err != nil {
 			return nil, err
@@ -582,6 +621,12 @@ func (api *API) traceBlock(ctx context.Context, block *types.Block, config *Trac
 	}
 	defer release()
 
+	core.DeployBeaconRoots(api.backend.ChainConfig(), parent.Time(), block.Time(), statedb)
+	if beaconRoot := block.BeaconRoot(); beaconRoot != nil {
+		blockCtx := core.NewQRVMBlockContext(block.Header(), api.chainContext(ctx), nil)
+		vmenv := vm.NewQRVM(blockCtx, vm.TxContext{}, statedb, api.backend.ChainConfig(), vm.Config{})
+		core.ProcessBeaconBlockRoot(*beaconRoot, vmenv, statedb)
+	}
 	// JS tracers have high overhead. In this case run a parallel
 	// process that generates states in one thread and traces txes
 	// in separate worker threads.
@@ -595,7 +640,7 @@ func (api *API) traceBlock(ctx context.Context, block *types.Block, config *Trac
 		txs       = block.Transactions()
 		blockHash = block.Hash()
 		blockCtx  = core.NewQRVMBlockContext(block.Header(), api.chainContext(ctx), nil)
-		signer    = types.MakeSigner(api.backend.ChainConfig())
+		signer    = types.MakeSigner(api.backend.ChainConfig(), block.Time())
 		results   = make([]*txTraceResult, len(txs))
 	)
 	for i, tx := range txs {
@@ -628,7 +673,7 @@ func (api *API) traceBlockParallel(ctx context.Context, block *types.Block, stat
 		txs       = block.Transactions()
 		blockHash = block.Hash()
 		blockCtx  = core.NewQRVMBlockContext(block.Header(), api.chainContext(ctx), nil)
-		signer    = types.MakeSigner(api.backend.ChainConfig())
+		signer    = types.MakeSigner(api.backend.ChainConfig(), block.Time())
 		results   = make([]*txTraceResult, len(txs))
 		pend      sync.WaitGroup
 	)
@@ -737,7 +782,7 @@ func (api *API) standardTraceBlockToFile(ctx context.Context, block *types.Block
 	// Execute transaction, either tracing all or just the requested one
 	var (
 		dumps       []string
-		signer      = types.MakeSigner(api.backend.ChainConfig())
+		signer      = types.MakeSigner(api.backend.ChainConfig(), block.Time())
 		chainConfig = api.backend.ChainConfig()
 		vmctx       = core.NewQRVMBlockContext(block.Header(), api.chainContext(ctx), nil)
 		canon       = true
@@ -751,6 +796,11 @@ func (api *API) standardTraceBlockToFile(ctx context.Context, block *types.Block
 		// Note: This copies the config, to not screw up the main config
 		chainConfig, canon = overrideConfig(chainConfig, config.Overrides)
 	}
+	core.DeployBeaconRoots(chainConfig, parent.Time(), block.Time(), statedb)
+	if beaconRoot := block.BeaconRoot(); beaconRoot != nil {
+		vmenv := vm.NewQRVM(vmctx, vm.TxContext{}, statedb, chainConfig, vm.Config{})
+		core.ProcessBeaconBlockRoot(*beaconRoot, vmenv, statedb)
+	}
 	for i, tx := range block.Transactions() {
 		// Prepare the transaction for un-traced execution
 		var (
@@ -861,25 +911,7 @@ func (api *API) TraceTransaction(ctx context.Context, hash common.Hash, config *
 // top of the provided block and returns them as a JSON object.
 func (api *API) TraceCall(ctx context.Context, args qrlapi.TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, config *TraceCallConfig) (interface{}, error) {
 	// Try to retrieve the specified block
-	var (
-		err   error
-		block *types.Block
-	)
-	if hash, ok := blockNrOrHash.Hash(); ok {
-		block, err = api.blockByHash(ctx, hash)
-	} else if number, ok := blockNrOrHash.Number(); ok {
-		if number == rpc.PendingBlockNumber {
-			// We don't have access to the miner here. For tracing 'future' transactions,
-			// it can be done with block- and state-overrides instead, which offers
-			// more flexibility and stability than trying to trace on 'pending', since
-			// the contents of 'pending' is unstable and probably not a true representation
-			// of what the next actual block is likely to contain.
-			return nil, errors.New("tracing on top of pending is not supported")
-		}
-		block, err = api.blockByNumber(ctx, number)
-	} else {
-		return nil, errors.New("invalid arguments; neither block nor hash specified")
-	}
+	block, err := api.blockByNumberOrHash(ctx, blockNrOrHash)
 	if err != nil {
 		return nil, err
 	}
@@ -915,10 +947,104 @@ func (api *API) TraceCall(ctx context.Context, args qrlapi.TransactionArgs, bloc
 	return api.traceTx(ctx, msg, new(Context), vmctx, statedb, traceConfig)
 }
 
+// Bundle is a batch of transactions traced in sequence by TraceCallMany, sharing
+// the block context given by its overrides.
+type Bundle struct {
+	Transactions   []qrlapi.TransactionArgs `json:"transactions"`
+	BlockOverrides *qrlapi.BlockOverrides   `json:"blockOverride"`
+}
+
+// TraceCallMany lets you trace a series of qrl_calls grouped into bundles. The
+// calls are executed in order on top of the provided block, each observing the
+// state changes of the previous ones, and a trace is returned per call. Block
+// overrides of a bundle are applied on top of the ones in the config.
+func (api *API) TraceCallMany(ctx context.Context, bundles []Bundle, blockNrOrHash rpc.BlockNumberOrHash, config *TraceCallConfig) ([][]interface{}, error) {
+	if len(bundles) == 0 {
+		return nil, errors.New("empty bundles")
+	}
+	var calls int
+	for _, bundle := range bundles {
+		calls += len(bundle.Transactions)
+	}
+	if calls > maxTraceCallManyCalls {
+		return nil, fmt.Errorf("too many calls: have %d, max %d", calls, maxTraceCallManyCalls)
+	}
+	block, err := api.blockByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	// try to recompute the state
+	reexec := defaultTraceReexec
+	if config != nil && config.Reexec != nil {
+		reexec = *config.Reexec
+	}
+	statedb, release, err := api.backend.StateAtBlock(ctx, block, reexec, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	blockCtx := core.NewQRVMBlockContext(block.Header(), api.chainContext(ctx), nil)
+	// Apply the customization rules if required.
+	var traceConfig *TraceConfig
+	if config != nil {
+		if err := config.StateOverrides.Apply(statedb); err != nil {
+			return nil, err
+		}
+		config.BlockOverrides.Apply(&blockCtx)
+		traceConfig = &config.TraceConfig
+	}
+	// The RPC gas cap applies to the whole batch, not to each call separately
+	var (
+		gasCap  = api.backend.RPCGasCap()
+		gasUsed uint64
+	)
+	results := make([][]interface{}, len(bundles))
+	for i, bundle := range bundles {
+		vmctx := blockCtx
+		bundle.BlockOverrides.Apply(&vmctx)
+
+		results[i] = make([]interface{}, len(bundle.Transactions))
+		for j, args := range bundle.Transactions {
+			var gasLeft uint64 // Zero if the gas is not capped
+			if gasCap != 0 {
+				if gasUsed >= gasCap {
+					return nil, fmt.Errorf("bundle %d, transaction %d: gas cap %d exhausted", i, j, gasCap)
+				}
+				gasLeft = gasCap - gasUsed
+			}
+			msg, err := args.ToMessage(gasLeft, vmctx.BaseFee)
+			if err != nil {
+				return nil, fmt.Errorf("bundle %d, transaction %d: %w", i, j, err)
+			}
+			txctx := &Context{
+				BlockNumber: vmctx.BlockNumber,
+				TxIndex:     j,
+			}
+			res, used, err := api.traceTxGas(ctx, msg, txctx, vmctx, statedb, traceConfig)
+			if err != nil {
+				return nil, fmt.Errorf("bundle %d, transaction %d: %w", i, j, err)
+			}
+			gasUsed += used
+
+			// Carry the state changes over to the next call.
+			statedb.Finalise(true)
+			results[i][j] = res
+		}
+	}
+	return results, nil
+}
+
 // traceTx configures a new tracer according to the provided configuration, and
 // executes the given message in the provided environment. The return value will
 // be tracer dependent.
 func (api *API) traceTx(ctx context.Context, message *core.Message, txctx *Context, vmctx vm.BlockContext, statedb *state.StateDB, config *TraceConfig) (interface{}, error) {
+	res, _, err := api.traceTxGas(ctx, message, txctx, vmctx, statedb, config)
+	return res, err
+}
+
+// traceTxGas is like traceTx, but also returns the gas used by the message.
+func (api *API) traceTxGas(ctx context.Context, message *core.Message, txctx *Context, vmctx vm.BlockContext, statedb *state.StateDB, config *TraceConfig) (interface{}, uint64, error) {
 	var (
 		tracer    Tracer
 		err       error
@@ -933,7 +1059,7 @@ func (api *API) traceTx(ctx context.Context, message *core.Message, txctx *Conte
 	if config.Tracer != nil {
 		tracer, err = DefaultDirectory.New(*config.Tracer, txctx, config.TracerConfig)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 	}
 	vmenv := vm.NewQRVM(vmctx, txContext, statedb, api.backend.ChainConfig(), vm.Config{Tracer: tracer, NoBaseFee: true})
@@ -941,7 +1067,7 @@ func (api *API) traceTx(ctx context.Context, message *core.Message, txctx *Conte
 	// Define a meaningful timeout of a single transaction trace
 	if config.Timeout != nil {
 		if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 	}
 	deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
@@ -957,10 +1083,12 @@ func (api *API) traceTx(ctx context.Context, message *core.Message, txctx *Conte
 
 	// Call Prepare to clear out the statedb access list
 	statedb.SetTxContext(txctx.TxHash, txctx.TxIndex)
-	if _, err = core.ApplyMessage(vmenv, message, new(core.GasPool).AddGas(message.GasLimit)); err != nil {
-		return nil, fmt.Errorf("tracing failed: %w", err)
+	result, err := core.ApplyMessage(vmenv, message, new(core.GasPool).AddGas(message.GasLimit))
+	if err != nil {
+		return nil, 0, fmt.Errorf("tracing failed: %w", err)
 	}
-	return tracer.GetResult()
+	res, err := tracer.GetResult()
+	return res, result.UsedGas, err
 }
 
 // APIs return the collection of RPC services the tracer package offers.
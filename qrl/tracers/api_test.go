@@ -28,7 +28,6 @@ import (
 	"testing"
 	"time"
 
-	walletmldsa87 "github.com/theQRL/go-qrllib/wallet/ml_dsa_87"
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/common/hexutil"
 	"github.com/theQRL/go-zond/consensus"
@@ -39,6 +38,7 @@ import (
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/core/vm"
 	"github.com/theQRL/go-zond/crypto"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
 	"github.com/theQRL/go-zond/internal/qrlapi"
 	"github.com/theQRL/go-zond/params"
 	"github.com/theQRL/go-zond/qrl/tracers/logger"
@@ -168,7 +168,7 @@ func (b *testBackend) StateAtTransaction(ctx context.Context, block *types.Block
 		return nil, vm.BlockContext{}, statedb, release, nil
 	}
 	// Recompute transactions up to the target index.
-	signer := types.MakeSigner(b.chainConfig)
+	signer := types.MakeSigner(b.chainConfig, block.Time())
 	for idx, tx := range block.Transactions() {
 		msg, _ := core.TransactionToMessage(tx, signer, block.BaseFee())
 		txContext := core.NewQRVMTxContext(msg)
@@ -328,6 +328,156 @@ func TestTraceCall(t *testing.T) {
 	}
 }
 
+func TestTraceCallMany(t *testing.T) {
+	t.Parallel()
+
+	// Initialize test accounts
+	accounts := newAccounts(2)
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: core.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Quanta)},
+		},
+	}
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {})
+	defer backend.teardown()
+	api := NewAPI(backend)
+
+	var (
+		// Increments storage slot 0 and returns the new value.
+		counter = hexutil.Bytes{
+			0x60, 0x00, 0x54, // SLOAD slot 0
+			0x60, 0x01, 0x01, 0x80, // ADD 1, DUP1
+			0x60, 0x00, 0x55, // SSTORE slot 0
+			0x60, 0x00, 0x52, // MSTORE offset 0
+			0x60, 0x20, 0x60, 0x00, 0xf3, // RETURN 32 bytes from offset 0
+		}
+		// Returns the block number.
+		number = hexutil.Bytes{
+			0x43, 0x60, 0x00, 0x52, // NUMBER, MSTORE offset 0
+			0x60, 0x20, 0x60, 0x00, 0xf3, // RETURN 32 bytes from offset 0
+		}
+		counterAddr = common.Address{0xc0}
+		numberAddr  = common.Address{0xc1}
+		call        = func(to *common.Address) qrlapi.TransactionArgs {
+			return qrlapi.TransactionArgs{From: &accounts[0].addr, To: to}
+		}
+		config = &TraceCallConfig{
+			TraceConfig: TraceConfig{Config: &logger.Config{DisableStack: true, DisableStorage: true}},
+			StateOverrides: &qrlapi.StateOverride{
+				counterAddr: qrlapi.OverrideAccount{Code: &counter},
+				numberAddr:  qrlapi.OverrideAccount{Code: &number},
+			},
+			BlockOverrides: &qrlapi.BlockOverrides{Number: (*hexutil.Big)(big.NewInt(0x10))},
+		}
+		latest = rpc.LatestBlockNumber
+	)
+	bundles := []Bundle{{
+		Transactions: []qrlapi.TransactionArgs{call(&counterAddr), call(&counterAddr), call(&numberAddr)},
+	}, {
+		Transactions:   []qrlapi.TransactionArgs{call(&counterAddr), call(&numberAddr)},
+		BlockOverrides: &qrlapi.BlockOverrides{Number: (*hexutil.Big)(big.NewInt(0x20))},
+	}}
+	want := [][]string{
+		{
+			"0000000000000000000000000000000000000000000000000000000000000001",
+			"0000000000000000000000000000000000000000000000000000000000000002",
+			"0000000000000000000000000000000000000000000000000000000000000010",
+		},
+		{
+			"0000000000000000000000000000000000000000000000000000000000000003",
+			"0000000000000000000000000000000000000000000000000000000000000020",
+		},
+	}
+	results, err := api.TraceCallMany(context.Background(), bundles, rpc.BlockNumberOrHash{BlockNumber: &latest}, config)
+	if err != nil {
+		t.Fatalf("failed to trace call bundles: %v", err)
+	}
+	if len(results) != len(want) {
+		t.Fatalf("bundle count mismatch: have %d, want %d", len(results), len(want))
+	}
+	for i := range want {
+		if len(results[i]) != len(want[i]) {
+			t.Fatalf("bundle %d: trace count mismatch: have %d, want %d", i, len(results[i]), len(want[i]))
+		}
+		for j := range want[i] {
+			var have *logger.ExecutionResult
+			if err := json.Unmarshal(results[i][j].(json.RawMessage), &have); err != nil {
+				t.Fatalf("bundle %d, transaction %d: failed to unmarshal result %v", i, j, err)
+			}
+			if have.Failed || have.ReturnValue != want[i][j] {
+				t.Errorf("bundle %d, transaction %d: return value mismatch, have %v (failed %v), want %v", i, j, have.ReturnValue, have.Failed, want[i][j])
+			}
+		}
+	}
+	// Tracing without bundles should fail
+	if _, err := api.TraceCallMany(context.Background(), nil, rpc.BlockNumberOrHash{BlockNumber: &latest}, nil); err == nil {
+		t.Error("expected error for empty bundles")
+	}
+}
+
+func TestTraceCallManyLimits(t *testing.T) {
+	t.Parallel()
+
+	accounts := newAccounts(1)
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: core.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Quanta)},
+		},
+	}
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {})
+	defer backend.teardown()
+	api := NewAPI(backend)
+
+	var (
+		// Consumes all the gas it is given.
+		invalid     = hexutil.Bytes{0xfe}
+		invalidAddr = common.Address{0xc0}
+		gas         = hexutil.Uint64(backend.RPCGasCap() * 2 / 5)
+		call        = qrlapi.TransactionArgs{From: &accounts[0].addr, To: &invalidAddr, Gas: &gas}
+		config      = &TraceCallConfig{
+			StateOverrides: &qrlapi.StateOverride{
+				invalidAddr: qrlapi.OverrideAccount{Code: &invalid},
+			},
+		}
+		latest = rpc.LatestBlockNumber
+	)
+	// Too many calls across all bundles should fail before tracing anything
+	calls := make([]qrlapi.TransactionArgs, maxTraceCallManyCalls/2+1)
+	for i := range calls {
+		calls[i] = call
+	}
+	bundles := []Bundle{{Transactions: calls}, {Transactions: calls}}
+	if _, err := api.TraceCallMany(context.Background(), bundles, rpc.BlockNumberOrHash{BlockNumber: &latest}, config); err == nil {
+		t.Error("expected error for too many calls")
+	}
+	// Two calls fit into the gas cap and the third gets the remainder
+	bundles = []Bundle{{Transactions: []qrlapi.TransactionArgs{call, call}}, {Transactions: []qrlapi.TransactionArgs{call}}}
+	results, err := api.TraceCallMany(context.Background(), bundles, rpc.BlockNumberOrHash{BlockNumber: &latest}, config)
+	if err != nil {
+		t.Fatalf("failed to trace call bundles: %v", err)
+	}
+	var used uint64
+	for i := range results {
+		for j := range results[i] {
+			var have *logger.ExecutionResult
+			if err := json.Unmarshal(results[i][j].(json.RawMessage), &have); err != nil {
+				t.Fatalf("bundle %d, transaction %d: failed to unmarshal result %v", i, j, err)
+			}
+			used += have.Gas
+		}
+	}
+	if used != backend.RPCGasCap() {
+		t.Errorf("gas used mismatch: have %d, want %d", used, backend.RPCGasCap())
+	}
+	// Once the gas cap is exhausted, any further call should fail
+	bundles[1].Transactions = append(bundles[1].Transactions, call)
+	if _, err := api.TraceCallMany(context.Background(), bundles, rpc.BlockNumberOrHash{BlockNumber: &latest}, config); err == nil {
+		t.Error("expected error for exhausted gas cap")
+	}
+}
+
 func TestTraceTransaction(t *testing.T) {
 	t.Parallel()
 
@@ -812,7 +962,7 @@ func TestTracingWithOverrides(t *testing.T) {
 }
 
 type Account struct {
-	key  *walletmldsa87.Wallet
+	key  pqcrypto.Wallet
 	addr common.Address
 }
 
@@ -0,0 +1,174 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/google/pprof/profile"
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/params"
+	"github.com/theQRL/go-zond/qrl/tracers"
+	"github.com/theQRL/go-zond/tests"
+)
+
+// gasProfile is the JSON output of the gas profiler.
+type gasProfile struct {
+	GasUsed uint64 `json:"gasUsed"`
+	Ops     []struct {
+		Address common.Address `json:"address"`
+		PC      uint64         `json:"pc"`
+		Op      string         `json:"op"`
+		Count   uint64         `json:"count"`
+		Gas     uint64         `json:"gas"`
+	} `json:"ops"`
+	Frames []struct {
+		Stack   []string `json:"stack"`
+		Count   uint64   `json:"count"`
+		Gas     uint64   `json:"gas"`
+		SelfGas uint64   `json:"selfGas"`
+	} `json:"frames"`
+}
+
+// Tests that the gas profiler attributes all the execution gas to opcodes and
+// call frames, and renders it in all the supported formats.
+func TestGasProfileTracer(t *testing.T) {
+	var (
+		to, _     = common.NewAddressFromString("Q00000000000000000000000000000000deadbeef")
+		callee, _ = common.NewAddressFromString("Q00000000000000000000000000000000000000ca")
+		origin, _ = common.NewAddressFromString("Q000000000000000000000000000000000000feed")
+		code      = []byte{
+			byte(vm.PUSH1), 0x1, byte(vm.PUSH1), 0x0, byte(vm.SSTORE), // SSTORE(0, 1)
+			byte(vm.PUSH1), 0x0, byte(vm.DUP1), byte(vm.DUP1), byte(vm.DUP1), byte(vm.DUP1), // in, outs and value zero
+			byte(vm.PUSH1), 0xca, byte(vm.GAS), byte(vm.CALL), // CALL(GAS, 0xca, ...) twice
+			byte(vm.PUSH1), 0x0, byte(vm.DUP1), byte(vm.DUP1), byte(vm.DUP1), byte(vm.DUP1),
+			byte(vm.PUSH1), 0xca, byte(vm.GAS), byte(vm.CALL),
+		}
+		calleeCode = []byte{byte(vm.PUSH1), 0x1, byte(vm.PUSH1), 0x0, byte(vm.MSTORE)} // MSTORE(0, 1)
+	)
+	run := func(format string) json.RawMessage {
+		tracer, err := tracers.DefaultDirectory.New("gasProfileTracer", nil, json.RawMessage(`{"format": "`+format+`"}`))
+		if err != nil {
+			t.Fatalf("failed to create gas profiler: %v", err)
+		}
+		triedb, _, statedb := tests.MakePreState(rawdb.NewMemoryDatabase(),
+			core.GenesisAlloc{
+				to:     core.GenesisAccount{Code: code},
+				callee: core.GenesisAccount{Code: calleeCode},
+				origin: core.GenesisAccount{Balance: big.NewInt(500000000000000)},
+			}, false, rawdb.HashScheme)
+		defer triedb.Close()
+
+		context := vm.BlockContext{
+			CanTransfer: core.CanTransfer,
+			Transfer:    core.Transfer,
+			BlockNumber: new(big.Int).SetUint64(8000000),
+			Time:        5,
+			GasLimit:    uint64(6000000),
+			BaseFee:     new(big.Int),
+		}
+		qrvm := vm.NewQRVM(context, vm.TxContext{Origin: origin, GasPrice: big.NewInt(1)}, statedb, params.MainnetChainConfig, vm.Config{Tracer: tracer})
+		msg := &core.Message{
+			To:        &to,
+			From:      origin,
+			Value:     big.NewInt(0),
+			GasLimit:  100000,
+			GasPrice:  big.NewInt(0),
+			GasFeeCap: big.NewInt(0),
+			GasTipCap: big.NewInt(0),
+		}
+		st := core.NewStateTransition(qrvm, msg, new(core.GasPool).AddGas(msg.GasLimit))
+		if _, err := st.TransitionDb(); err != nil {
+			t.Fatalf("failed to execute transaction: %v", err)
+		}
+		res, err := tracer.GetResult()
+		if err != nil {
+			t.Fatalf("failed to retrieve gas profile: %v", err)
+		}
+		return res
+	}
+	// Check the aggregated profile
+	var prof gasProfile
+	if err := json.Unmarshal(run("json"), &prof); err != nil {
+		t.Fatalf("failed to decode gas profile: %v", err)
+	}
+	if len(prof.Frames) != 2 {
+		t.Fatalf("frame count mismatch: have %d, want 2", len(prof.Frames))
+	}
+	root, child := prof.Frames[0], prof.Frames[1]
+	if have, want := strings.Join(child.Stack, ";"), "CALL "+to.Hex()+";CALL "+callee.Hex(); have != want {
+		t.Fatalf("frame stack mismatch: have %s, want %s", have, want)
+	}
+	if root.Count != 1 || child.Count != 2 {
+		t.Fatalf("frame counts mismatch: have %d/%d, want 1/2", root.Count, child.Count)
+	}
+	if have, want := root.Gas, prof.GasUsed-params.TxGas; have != want {
+		t.Fatalf("execution gas mismatch: have %d, want %d", have, want)
+	}
+	if root.SelfGas+child.Gas != root.Gas || child.SelfGas != child.Gas {
+		t.Fatalf("frame gas inconsistent: root %d/%d, child %d/%d", root.Gas, root.SelfGas, child.Gas, child.SelfGas)
+	}
+	var opsGas uint64
+	for _, op := range prof.Ops {
+		opsGas += op.Gas
+	}
+	if opsGas != root.Gas {
+		t.Fatalf("opcode gas mismatch: have %d, want %d", opsGas, root.Gas)
+	}
+	top := prof.Ops[0]
+	if top.Address != to || top.PC != 4 || top.Op != "SSTORE" || top.Count != 1 || top.Gas != params.SstoreSetGasEIP2200+params.ColdSloadCostEIP2929 {
+		t.Fatalf("hottest opcode mismatch: have %+v", top)
+	}
+	for _, op := range prof.Ops {
+		// MSTORE costs 3 gas, plus 3 gas for expanding the memory by a word
+		if op.Address == callee && op.Op == "MSTORE" && (op.Count != 2 || op.Gas != 2*(3+params.MemoryGas)) {
+			t.Fatalf("callee opcode mismatch: have %+v", op)
+		}
+	}
+	// Check the flame graph input
+	var folded string
+	if err := json.Unmarshal(run("folded"), &folded); err != nil {
+		t.Fatalf("failed to decode folded stacks: %v", err)
+	}
+	want := "CALL " + to.Hex() + ";SSTORE:4 22100\n"
+	if !strings.Contains(folded, want) {
+		t.Fatalf("folded stacks missing %q:\n%s", want, folded)
+	}
+	// Check the pprof profile
+	var blob []byte
+	if err := json.Unmarshal(run("pprof"), &blob); err != nil {
+		t.Fatalf("failed to decode pprof profile: %v", err)
+	}
+	pprof, err := profile.Parse(bytes.NewReader(blob))
+	if err != nil {
+		t.Fatalf("failed to parse pprof profile: %v", err)
+	}
+	var total int64
+	for _, sample := range pprof.Sample {
+		total += sample.Value[0]
+	}
+	if uint64(total) != root.Gas {
+		t.Fatalf("pprof gas mismatch: have %d, want %d", total, root.Gas)
+	}
+}
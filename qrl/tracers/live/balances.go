@@ -0,0 +1,137 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package live contains the tracers which can be attached to the chain to
+// observe blocks while they are imported.
+package live
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/common/hexutil"
+	"github.com/theQRL/go-zond/core/tracing"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/log"
+	"github.com/theQRL/go-zond/qrl/tracers"
+)
+
+func init() {
+	tracers.LiveDirectory.Register("balances", newBalancesTracer)
+}
+
+// balancesTracerConfig is the configuration of the balances tracer.
+type balancesTracerConfig struct {
+	Path string `json:"path"` // File the block summaries are appended to
+}
+
+// balanceChange is the net change of the balance of an account over a block.
+type balanceChange struct {
+	Prev *hexutil.Big `json:"prev"`
+	New  *hexutil.Big `json:"new"`
+}
+
+// blockBalances is the summary written for every imported block.
+type blockBalances struct {
+	Number  uint64                            `json:"number"`
+	Hash    common.Hash                       `json:"hash"`
+	Changes map[common.Address]*balanceChange `json:"changes"`
+}
+
+// balancesTracer writes the net balance changes of every imported block into
+// a file, one JSON object per line. Changes of blocks failing to import are
+// discarded.
+type balancesTracer struct {
+	file    *os.File
+	out     *json.Encoder
+	block   *types.Block
+	changes map[common.Address]*balanceChange
+}
+
+func newBalancesTracer(cfg json.RawMessage) (*tracing.Hooks, error) {
+	var config balancesTracerConfig
+	if cfg != nil {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, fmt.Errorf("failed to parse config: %v", err)
+		}
+	}
+	if config.Path == "" {
+		return nil, errors.New("balances tracer output path is required")
+	}
+	file, err := os.OpenFile(config.Path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	t := &balancesTracer{file: file, out: json.NewEncoder(file)}
+	return &tracing.Hooks{
+		OnBlockStart:    t.OnBlockStart,
+		OnBlockEnd:      t.OnBlockEnd,
+		OnClose:         t.OnClose,
+		OnBalanceChange: t.OnBalanceChange,
+	}, nil
+}
+
+func (t *balancesTracer) OnBlockStart(block *types.Block) {
+	t.block = block
+	t.changes = make(map[common.Address]*balanceChange)
+}
+
+func (t *balancesTracer) OnBlockEnd(err error) {
+	block, changes := t.block, t.changes
+	t.block, t.changes = nil, nil
+
+	if err != nil || block == nil {
+		return
+	}
+	for addr, change := range changes {
+		if change.Prev.ToInt().Cmp(change.New.ToInt()) == 0 {
+			delete(changes, addr)
+		}
+	}
+	summary := &blockBalances{
+		Number:  block.NumberU64(),
+		Hash:    block.Hash(),
+		Changes: changes,
+	}
+	if err := t.out.Encode(summary); err != nil {
+		log.Warn("Failed to write balance changes", "number", block.NumberU64(), "hash", block.Hash(), "err", err)
+	}
+}
+
+func (t *balancesTracer) OnClose() {
+	if err := t.file.Sync(); err != nil {
+		log.Warn("Failed to sync balance changes", "err", err)
+	}
+	if err := t.file.Close(); err != nil {
+		log.Warn("Failed to close balance changes", "err", err)
+	}
+}
+
+func (t *balancesTracer) OnBalanceChange(addr common.Address, prev, cur *big.Int) {
+	if t.changes == nil {
+		return // balance changes outside of block processing
+	}
+	change, ok := t.changes[addr]
+	if !ok {
+		change = &balanceChange{Prev: (*hexutil.Big)(new(big.Int).Set(prev))}
+		t.changes[addr] = change
+	}
+	change.New = (*hexutil.Big)(new(big.Int).Set(cur))
+}
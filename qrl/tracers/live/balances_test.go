@@ -0,0 +1,125 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package live
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/consensus/beacon"
+	"github.com/theQRL/go-zond/core"
+	"github.com/theQRL/go-zond/core/rawdb"
+	"github.com/theQRL/go-zond/core/types"
+	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
+	"github.com/theQRL/go-zond/params"
+	"github.com/theQRL/go-zond/qrl/tracers"
+)
+
+func TestBalancesTracer(t *testing.T) {
+	var (
+		key, _  = pqcrypto.HexToWallet("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = key.GetAddress()
+		funds   = big.NewInt(params.Quanta)
+		gspec   = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  core.GenesisAlloc{address: {Balance: funds}},
+		}
+		engine = beacon.NewFaker()
+		signer = types.LatestSigner(gspec.Config)
+	)
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, engine, 3, func(i int, b *core.BlockGen) {
+		if i == 1 {
+			return // leave a block without balance changes
+		}
+		tx, _ := types.SignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   gspec.Config.ChainID,
+			Nonce:     b.TxNonce(address),
+			To:        &common.Address{2},
+			Value:     big.NewInt(1000),
+			Gas:       params.TxGas,
+			GasFeeCap: b.BaseFee(),
+		})
+		b.AddTx(tx)
+	})
+	path := filepath.Join(t.TempDir(), "balances.jsonl")
+	hooks, err := tracers.LiveDirectory.New("balances", json.RawMessage(fmt.Sprintf(`{"path":%q}`, path)))
+	if err != nil {
+		t.Fatalf("failed to create tracer: %v", err)
+	}
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, engine, vm.Config{LiveTracer: hooks}, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	// Stopping the chain closes the output, later events are not written
+	chain.Stop()
+
+	hooks.OnBlockStart(blocks[0])
+	hooks.OnBalanceChange(address, big.NewInt(1), big.NewInt(2))
+	hooks.OnBlockEnd(nil)
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open output: %v", err)
+	}
+	defer file.Close()
+
+	var summaries []blockBalances
+	for scanner := bufio.NewScanner(file); scanner.Scan(); {
+		var summary blockBalances
+		if err := json.Unmarshal(scanner.Bytes(), &summary); err != nil {
+			t.Fatalf("failed to decode summary: %v", err)
+		}
+		summaries = append(summaries, summary)
+	}
+	if len(summaries) != len(blocks) {
+		t.Fatalf("summary count mismatch: have %d, want %d", len(summaries), len(blocks))
+	}
+	for i, summary := range summaries {
+		block := blocks[i]
+		if summary.Number != block.NumberU64() || summary.Hash != block.Hash() {
+			t.Errorf("summary %d: block mismatch: have %d %x, want %d %x", i, summary.Number, summary.Hash, block.NumberU64(), block.Hash())
+		}
+		// Every reported change must match the state transition of the block
+		parent, _ := chain.StateAt(chain.GetBlockByNumber(block.NumberU64() - 1).Root())
+		state, _ := chain.StateAt(block.Root())
+		for addr, change := range summary.Changes {
+			if have := parent.GetBalance(addr); have.Cmp(change.Prev.ToInt()) != 0 {
+				t.Errorf("summary %d: previous balance mismatch for %v: have %v, want %v", i, addr, change.Prev, have)
+			}
+			if have := state.GetBalance(addr); have.Cmp(change.New.ToInt()) != 0 {
+				t.Errorf("summary %d: new balance mismatch for %v: have %v, want %v", i, addr, change.New, have)
+			}
+		}
+		if len(block.Transactions()) == 0 {
+			if len(summary.Changes) != 0 {
+				t.Errorf("summary %d: unexpected changes in empty block: %v", i, summary.Changes)
+			}
+		} else if _, ok := summary.Changes[common.Address{2}]; !ok {
+			t.Errorf("summary %d: missing recipient balance change", i)
+		}
+	}
+}
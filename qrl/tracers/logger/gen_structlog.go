@@ -26,6 +26,7 @@ func (s StructLog) MarshalJSON() ([]byte, error) {
 		Stack         []uint256.Int               `json:"stack"`
 		ReturnData    hexutil.Bytes               `json:"returnData,omitempty"`
 		Storage       map[common.Hash]common.Hash `json:"-"`
+		TStorage      map[common.Hash]common.Hash `json:"-"`
 		Depth         int                         `json:"depth"`
 		RefundCounter uint64                      `json:"refund"`
 		Err           error                       `json:"-"`
@@ -42,6 +43,7 @@ func (s StructLog) MarshalJSON() ([]byte, error) {
 	enc.Stack = s.Stack
 	enc.ReturnData = s.ReturnData
 	enc.Storage = s.Storage
+	enc.TStorage = s.TStorage
 	enc.Depth = s.Depth
 	enc.RefundCounter = s.RefundCounter
 	enc.Err = s.Err
@@ -62,6 +64,7 @@ func (s *StructLog) UnmarshalJSON(input []byte) error {
 		Stack         []uint256.Int               `json:"stack"`
 		ReturnData    *hexutil.Bytes              `json:"returnData,omitempty"`
 		Storage       map[common.Hash]common.Hash `json:"-"`
+		TStorage      map[common.Hash]common.Hash `json:"-"`
 		Depth         *int                        `json:"depth"`
 		RefundCounter *uint64                     `json:"refund"`
 		Err           error                       `json:"-"`
@@ -97,6 +100,9 @@ func (s *StructLog) UnmarshalJSON(input []byte) error {
 	if dec.Storage != nil {
 		s.Storage = dec.Storage
 	}
+	if dec.TStorage != nil {
+		s.TStorage = dec.TStorage
+	}
 	if dec.Depth != nil {
 		s.Depth = *dec.Depth
 	}
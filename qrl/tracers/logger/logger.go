@@ -72,6 +72,7 @@ type StructLog struct {
 	Stack         []uint256.Int               `json:"stack"`
 	ReturnData    []byte                      `json:"returnData,omitempty"`
 	Storage       map[common.Hash]common.Hash `json:"-"`
+	TStorage      map[common.Hash]common.Hash `json:"-"`
 	Depth         int                         `json:"depth"`
 	RefundCounter uint64                      `json:"refund"`
 	Err           error                       `json:"-"`
@@ -110,6 +111,7 @@ type StructLogger struct {
 	env *vm.QRVM
 
 	storage  map[common.Address]Storage
+	tstorage map[common.Address]Storage
 	logs     []StructLog
 	output   []byte
 	err      error
@@ -123,7 +125,8 @@ type StructLogger struct {
 // NewStructLogger returns a new logger
 func NewStructLogger(cfg *Config) *StructLogger {
 	logger := &StructLogger{
-		storage: make(map[common.Address]Storage),
+		storage:  make(map[common.Address]Storage),
+		tstorage: make(map[common.Address]Storage),
 	}
 	if cfg != nil {
 		logger.cfg = *cfg
@@ -134,6 +137,7 @@ func NewStructLogger(cfg *Config) *StructLogger {
 // Reset clears the data held by the logger.
 func (l *StructLogger) Reset() {
 	l.storage = make(map[common.Address]Storage)
+	l.tstorage = make(map[common.Address]Storage)
 	l.output = make([]byte, 0)
 	l.logs = l.logs[:0]
 	l.err = nil
@@ -146,7 +150,8 @@ func (l *StructLogger) CaptureStart(env *vm.QRVM, from common.Address, to common
 
 // CaptureState logs a new structured log message and pushes it out to the environment
 //
-// CaptureState also tracks SLOAD/SSTORE ops to track storage change.
+// CaptureState also tracks SLOAD/SSTORE and TLOAD/TSTORE ops to track storage
+// and transient storage changes.
 func (l *StructLogger) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
 	// If tracing was interrupted, set the error and stop
 	if l.interrupt.Load() {
@@ -202,13 +207,39 @@ func (l *StructLogger) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, s
 			storage = l.storage[contract.Address()].Copy()
 		}
 	}
+	// Copy a snapshot of the current transient storage to a new container
+	var tstorage Storage
+	if !l.cfg.DisableStorage && (op == vm.TLOAD || op == vm.TSTORE) {
+		// initialise new changed values transient storage container for this
+		// contract if not present.
+		if l.tstorage[contract.Address()] == nil {
+			l.tstorage[contract.Address()] = make(Storage)
+		}
+		// capture TLOAD opcodes and record the read entry in the local storage
+		if op == vm.TLOAD && stackLen >= 1 {
+			var (
+				address = common.Hash(stackData[stackLen-1].Bytes32())
+				value   = l.env.StateDB.GetTransientState(contract.Address(), address)
+			)
+			l.tstorage[contract.Address()][address] = value
+			tstorage = l.tstorage[contract.Address()].Copy()
+		} else if op == vm.TSTORE && stackLen >= 2 {
+			// capture TSTORE opcodes and record the written entry in the local storage.
+			var (
+				value   = common.Hash(stackData[stackLen-2].Bytes32())
+				address = common.Hash(stackData[stackLen-1].Bytes32())
+			)
+			l.tstorage[contract.Address()][address] = value
+			tstorage = l.tstorage[contract.Address()].Copy()
+		}
+	}
 	var rdata []byte
 	if l.cfg.EnableReturnData {
 		rdata = make([]byte, len(rData))
 		copy(rdata, rData)
 	}
 	// create a new snapshot of the QRVM.
-	log := StructLog{pc, op, gas, cost, mem, memory.Len(), stck, rdata, storage, depth, l.env.StateDB.GetRefund(), err}
+	log := StructLog{pc, op, gas, cost, mem, memory.Len(), stck, rdata, storage, tstorage, depth, l.env.StateDB.GetRefund(), err}
 	l.logs = append(l.logs, log)
 }
 
@@ -303,6 +334,12 @@ func WriteTrace(writer io.Writer, logs []StructLog) {
 				fmt.Fprintf(writer, "%x: %x\n", h, item)
 			}
 		}
+		if len(log.TStorage) > 0 {
+			fmt.Fprintln(writer, "TransientStorage:")
+			for h, item := range log.TStorage {
+				fmt.Fprintf(writer, "%x: %x\n", h, item)
+			}
+		}
 		if len(log.ReturnData) > 0 {
 			fmt.Fprintln(writer, "ReturnData:")
 			fmt.Fprint(writer, hex.Dump(log.ReturnData))
@@ -421,6 +458,7 @@ type StructLogRes struct {
 	ReturnData    string             `json:"returnData,omitempty"`
 	Memory        *[]string          `json:"memory,omitempty"`
 	Storage       *map[string]string `json:"storage,omitempty"`
+	TStorage      *map[string]string `json:"transientStorage,omitempty"`
 	RefundCounter uint64             `json:"refund,omitempty"`
 }
 
@@ -461,6 +499,13 @@ func formatLogs(logs []StructLog) []StructLogRes {
 			}
 			formatted[index].Storage = &storage
 		}
+		if trace.TStorage != nil {
+			tstorage := make(map[string]string)
+			for i, storageValue := range trace.TStorage {
+				tstorage[fmt.Sprintf("%x", i)] = fmt.Sprintf("%x", storageValue)
+			}
+			formatted[index].TStorage = &tstorage
+		}
 	}
 	return formatted
 }
@@ -79,6 +79,38 @@ func TestStoreCapture(t *testing.T) {
 	}
 }
 
+func TestTransientStoreCapture(t *testing.T) {
+	var (
+		logger     = NewStructLogger(nil)
+		statedb, _ = state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		env        = vm.NewQRVM(vm.BlockContext{}, vm.TxContext{}, statedb, params.TestChainConfig, vm.Config{Tracer: logger})
+		contract   = vm.NewContract(&dummyContractRef{}, &dummyContractRef{}, new(big.Int), 100000)
+	)
+	contract.Code = []byte{byte(vm.PUSH1), 0x1, byte(vm.PUSH1), 0x0, byte(vm.TSTORE), byte(vm.PUSH1), 0x0, byte(vm.TLOAD)}
+	var index common.Hash
+	logger.CaptureStart(env, common.Address{}, contract.Address(), false, nil, 0, nil)
+	_, err := env.Interpreter().Run(contract, []byte{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logger.tstorage[contract.Address()]) != 1 {
+		t.Fatalf("expected exactly 1 changed transient value on address %x, got %d", contract.Address(),
+			len(logger.tstorage[contract.Address()]))
+	}
+	if len(logger.storage[contract.Address()]) != 0 {
+		t.Fatalf("expected no persistent storage changes, got %d", len(logger.storage[contract.Address()]))
+	}
+	exp := common.BigToHash(big.NewInt(1))
+	if logger.tstorage[contract.Address()][index] != exp {
+		t.Errorf("expected %x, got %x", exp, logger.tstorage[contract.Address()][index])
+	}
+	for _, log := range logger.StructLogs() {
+		if log.Op == vm.TLOAD && log.TStorage[index] != exp {
+			t.Errorf("expected TLOAD log to capture %x, got %x", exp, log.TStorage[index])
+		}
+	}
+}
+
 // Tests that blank fields don't appear in logs when JSON marshalled, to reduce
 // logs bloat and confusion. See https://github.com/theQRL/go-zond/issues/24487
 func TestStructLogMarshalingOmitEmpty(t *testing.T) {
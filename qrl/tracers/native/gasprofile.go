@@ -0,0 +1,481 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/google/pprof/profile"
+	"github.com/theQRL/go-zond/common"
+	"github.com/theQRL/go-zond/core/vm"
+	"github.com/theQRL/go-zond/qrl/tracers"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("gasProfileTracer", newGasProfileTracer, false)
+}
+
+// Output formats supported by the gas profiler.
+const (
+	gasProfileJSON   = "json"   // Aggregated opcodes and call frames as JSON
+	gasProfileFolded = "folded" // Folded stacks, the input format of flame graph tools
+	gasProfilePprof  = "pprof"  // Gzipped pprof protobuf, base64 encoded in a JSON string
+)
+
+// gasProfileTracer attributes the gas used by a transaction to the code locations
+// it was spent at. Gas and execution counts are aggregated per opcode location
+// (contract address, pc, opcode) and per call frame, where a call frame is the
+// path of calls leading to a contract invocation.
+//
+// The gas attributed to an opcode is the gas it consumed itself: for calls and
+// creations, the gas used by the callee is attributed to the child call frame.
+// Gas not attributable to any opcode (e.g. precompile execution) is reported as
+// the self gas of the call frame. Intrinsic gas is not part of the profile.
+//
+// Example:
+//
+//	> debug.traceTransaction("0x...", {tracer: "gasProfileTracer", tracerConfig: {format: "folded"}})
+//	"CALL Q...dad;PUSH1:0 3\nCALL Q...dad;SSTORE:5 22100\n..."
+type gasProfileTracer struct {
+	noopTracer
+	config   gasProfileConfig
+	root     *profileNode    // Call frame of the transaction's top level call
+	stack    []*profileFrame // Call frames being executed
+	gasLimit uint64
+	gasUsed  uint64
+
+	interrupt atomic.Bool // Atomic flag to signal execution interruption
+	reason    error       // Textual reason for the interruption
+}
+
+type gasProfileConfig struct {
+	Format string `json:"format"` // Output format: json (default), folded or pprof
+}
+
+// profileNode is the aggregated profile of a call frame.
+type profileNode struct {
+	name     string                  // Display name, e.g. "CALL Q..."
+	address  common.Address          // Address whose code runs in the frame
+	count    uint64                  // Number of times the frame was entered
+	gas      uint64                  // Gas used by the frame, including its children
+	selfGas  uint64                  // Gas used by the frame not attributed to any opcode
+	ops      map[profileOp]*opStat   // Gas used by the opcodes executed in the frame
+	children map[string]*profileNode // Call frames entered from this one, keyed by name
+	order    []*profileNode          // Children in order of first entry
+}
+
+// profileOp is an opcode at a given location in the code of a call frame.
+type profileOp struct {
+	pc uint64
+	op vm.OpCode
+}
+
+// opStat is the aggregated gas and execution count of an opcode location.
+type opStat struct {
+	count uint64
+	gas   uint64
+}
+
+// profileFrame tracks a single invocation of a call frame during execution.
+type profileFrame struct {
+	node     *profileNode
+	last     *opStat // Opcode executed last, its gas is settled by the next step
+	lastGas  uint64  // Gas available before executing the last opcode
+	opsGas   uint64  // Gas attributed to the settled opcodes of the invocation
+	childGas uint64  // Gas used by the children of the invocation
+	pending  uint64  // Gas used by the children entered since the last opcode
+}
+
+// newGasProfileTracer returns a native go tracer which profiles the gas usage of
+// a transaction, and implements vm.QRVMLogger.
+func newGasProfileTracer(ctx *tracers.Context, cfg json.RawMessage) (tracers.Tracer, error) {
+	var config gasProfileConfig
+	if cfg != nil {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, err
+		}
+	}
+	switch config.Format {
+	case "":
+		config.Format = gasProfileJSON
+	case gasProfileJSON, gasProfileFolded, gasProfilePprof:
+	default:
+		return nil, fmt.Errorf("unknown gas profile format %q", config.Format)
+	}
+	return &gasProfileTracer{config: config}, nil
+}
+
+func newProfileNode(name string, address common.Address) *profileNode {
+	return &profileNode{
+		name:     name,
+		address:  address,
+		ops:      make(map[profileOp]*opStat),
+		children: make(map[string]*profileNode),
+	}
+}
+
+// frameName returns the display name of a call frame.
+func frameName(typ vm.OpCode, address common.Address) string {
+	return typ.String() + " " + address.Hex()
+}
+
+// child returns the call frame of the given call made from this frame.
+func (n *profileNode) child(typ vm.OpCode, address common.Address) *profileNode {
+	name := frameName(typ, address)
+	if child, ok := n.children[name]; ok {
+		return child
+	}
+	child := newProfileNode(name, address)
+	n.children[name] = child
+	n.order = append(n.order, child)
+	return child
+}
+
+// settle attributes the gas used since the last opcode, excluding the gas used
+// by the children entered meanwhile, to the last opcode.
+func (f *profileFrame) settle(gas uint64) {
+	if f.last == nil {
+		return
+	}
+	var used uint64
+	if gas < f.lastGas && f.lastGas-gas > f.pending {
+		used = f.lastGas - gas - f.pending
+	}
+	f.last.gas += used
+	f.opsGas += used
+	f.last, f.pending = nil, 0
+}
+
+// finish closes the invocation, attributing the gas not accounted for by the
+// settled opcodes and the children to the last opcode, or to the frame itself
+// if no code was executed.
+func (f *profileFrame) finish(gasUsed uint64) {
+	var rest uint64
+	if accounted := f.opsGas + f.childGas; gasUsed > accounted {
+		rest = gasUsed - accounted
+	}
+	if f.last != nil {
+		f.last.gas += rest
+	} else {
+		f.node.selfGas += rest
+	}
+	f.node.gas += gasUsed
+}
+
+// CaptureStart implements the QRVMLogger interface to initialize the tracing operation.
+func (t *gasProfileTracer) CaptureStart(env *vm.QRVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	typ := vm.CALL
+	if create {
+		typ = vm.CREATE
+	}
+	t.root = newProfileNode(frameName(typ, to), to)
+	t.root.count++
+	t.stack = []*profileFrame{{node: t.root}}
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *gasProfileTracer) CaptureEnd(output []byte, gasUsed uint64, err error) {
+	if len(t.stack) != 1 {
+		return
+	}
+	t.stack[0].finish(gasUsed)
+	t.stack = nil
+}
+
+// CaptureState implements the QRVMLogger interface to trace a single step of VM execution.
+func (t *gasProfileTracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	if t.interrupt.Load() || len(t.stack) == 0 {
+		return
+	}
+	frame := t.stack[len(t.stack)-1]
+	frame.settle(gas)
+
+	key := profileOp{pc: pc, op: op}
+	stat, ok := frame.node.ops[key]
+	if !ok {
+		stat = new(opStat)
+		frame.node.ops[key] = stat
+	}
+	stat.count++
+	frame.last, frame.lastGas = stat, gas
+}
+
+// CaptureEnter is called when QRVM enters a new scope (via call or create).
+func (t *gasProfileTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	if t.interrupt.Load() || len(t.stack) == 0 {
+		return
+	}
+	node := t.stack[len(t.stack)-1].node.child(typ, to)
+	node.count++
+	t.stack = append(t.stack, &profileFrame{node: node})
+}
+
+// CaptureExit is called when QRVM exits a scope, even if the scope didn't
+// execute any code.
+func (t *gasProfileTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	if t.interrupt.Load() || len(t.stack) < 2 {
+		return
+	}
+	frame := t.stack[len(t.stack)-1]
+	t.stack = t.stack[:len(t.stack)-1]
+	frame.finish(gasUsed)
+
+	parent := t.stack[len(t.stack)-1]
+	parent.childGas += gasUsed
+	parent.pending += gasUsed
+}
+
+func (t *gasProfileTracer) CaptureTxStart(gasLimit uint64) {
+	t.gasLimit = gasLimit
+}
+
+func (t *gasProfileTracer) CaptureTxEnd(restGas uint64) {
+	t.gasUsed = t.gasLimit - restGas
+}
+
+// gasProfile is the JSON output format of the gas profiler.
+type gasProfile struct {
+	GasUsed uint64         `json:"gasUsed"` // Gas used by the transaction, including intrinsic gas
+	Ops     []opProfile    `json:"ops"`     // Opcode locations, by descending gas usage
+	Frames  []frameProfile `json:"frames"`  // Call frames, depth first in order of entry
+}
+
+type opProfile struct {
+	Address common.Address `json:"address"`
+	PC      uint64         `json:"pc"`
+	Op      string         `json:"op"`
+	Count   uint64         `json:"count"`
+	Gas     uint64         `json:"gas"`
+}
+
+type frameProfile struct {
+	Stack   []string `json:"stack"`   // Names of the call frames leading to this one
+	Count   uint64   `json:"count"`   // Number of times the frame was entered
+	Gas     uint64   `json:"gas"`     // Gas used, including the children
+	SelfGas uint64   `json:"selfGas"` // Gas used, excluding the children
+}
+
+// walk visits all the call frames depth first, along with their stacks.
+func (n *profileNode) walk(stack []string, fn func(n *profileNode, stack []string)) {
+	stack = append(stack, n.name)
+	fn(n, stack)
+	for _, child := range n.order {
+		child.walk(stack, fn)
+	}
+}
+
+// sortedOps returns the opcode locations of the call frame ordered by pc.
+func (n *profileNode) sortedOps() []profileOp {
+	ops := make([]profileOp, 0, len(n.ops))
+	for op := range n.ops {
+		ops = append(ops, op)
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		if ops[i].pc != ops[j].pc {
+			return ops[i].pc < ops[j].pc
+		}
+		return ops[i].op < ops[j].op
+	})
+	return ops
+}
+
+// GetResult returns the gas profile in the configured format, and any error
+// arising from the encoding or forceful termination (via `Stop`).
+func (t *gasProfileTracer) GetResult() (json.RawMessage, error) {
+	if t.root == nil {
+		return nil, errors.New("no execution traced")
+	}
+	var (
+		res interface{}
+		err error
+	)
+	switch t.config.Format {
+	case gasProfileFolded:
+		res = t.folded()
+	case gasProfilePprof:
+		res, err = t.pprof()
+	default:
+		res = t.profile()
+	}
+	if err != nil {
+		return nil, err
+	}
+	blob, err := json.Marshal(res)
+	if err != nil {
+		return nil, err
+	}
+	return blob, t.reason
+}
+
+// profile aggregates the gas usage into the JSON output format.
+func (t *gasProfileTracer) profile() *gasProfile {
+	type location struct {
+		address common.Address
+		profileOp
+	}
+	var (
+		res   = &gasProfile{GasUsed: t.gasUsed, Ops: []opProfile{}}
+		stats = make(map[location]*opStat)
+	)
+	t.root.walk(nil, func(n *profileNode, stack []string) {
+		var childGas uint64
+		for _, child := range n.order {
+			childGas += child.gas
+		}
+		res.Frames = append(res.Frames, frameProfile{
+			Stack:   append([]string(nil), stack...),
+			Count:   n.count,
+			Gas:     n.gas,
+			SelfGas: n.gas - childGas,
+		})
+		for op, stat := range n.ops {
+			loc := location{n.address, op}
+			if _, ok := stats[loc]; !ok {
+				stats[loc] = new(opStat)
+			}
+			stats[loc].count += stat.count
+			stats[loc].gas += stat.gas
+		}
+	})
+	for loc, stat := range stats {
+		res.Ops = append(res.Ops, opProfile{
+			Address: loc.address,
+			PC:      loc.pc,
+			Op:      loc.op.String(),
+			Count:   stat.count,
+			Gas:     stat.gas,
+		})
+	}
+	sort.Slice(res.Ops, func(i, j int) bool {
+		a, b := res.Ops[i], res.Ops[j]
+		if a.Gas != b.Gas {
+			return a.Gas > b.Gas
+		}
+		if a.Address != b.Address {
+			return bytes.Compare(a.Address[:], b.Address[:]) < 0
+		}
+		return a.PC < b.PC
+	})
+	return res
+}
+
+// folded renders the gas usage as folded stacks: one line per opcode location,
+// made of the semicolon separated call frames, the opcode and its pc, followed
+// by the gas used.
+func (t *gasProfileTracer) folded() string {
+	var out strings.Builder
+	t.root.walk(nil, func(n *profileNode, stack []string) {
+		path := strings.Join(stack, ";")
+		if n.selfGas > 0 {
+			fmt.Fprintf(&out, "%s %d\n", path, n.selfGas)
+		}
+		for _, op := range n.sortedOps() {
+			if gas := n.ops[op].gas; gas > 0 {
+				fmt.Fprintf(&out, "%s;%s:%d %d\n", path, op.op, op.pc, gas)
+			}
+		}
+	})
+	return out.String()
+}
+
+// pprof renders the gas usage as a gzipped pprof profile, with gas and
+// execution counts as sample values.
+func (t *gasProfileTracer) pprof() ([]byte, error) {
+	prof := &profile.Profile{
+		SampleType: []*profile.ValueType{
+			{Type: "gas", Unit: "gas"},
+			{Type: "steps", Unit: "count"},
+		},
+	}
+	var (
+		functions = make(map[string]*profile.Function)
+		function  = func(name string, address common.Address) *profile.Function {
+			key := address.Hex() + " " + name
+			if fn, ok := functions[key]; ok {
+				return fn
+			}
+			fn := &profile.Function{
+				ID:         uint64(len(prof.Function) + 1),
+				Name:       name,
+				SystemName: name,
+				Filename:   address.Hex(),
+			}
+			functions[key] = fn
+			prof.Function = append(prof.Function, fn)
+			return fn
+		}
+		locations = make(map[*profile.Function]map[uint64]*profile.Location)
+		location  = func(fn *profile.Function, pc uint64) *profile.Location {
+			if loc, ok := locations[fn][pc]; ok {
+				return loc
+			}
+			loc := &profile.Location{
+				ID:      uint64(len(prof.Location) + 1),
+				Address: pc,
+				Line:    []profile.Line{{Function: fn, Line: int64(pc)}},
+			}
+			if locations[fn] == nil {
+				locations[fn] = make(map[uint64]*profile.Location)
+			}
+			locations[fn][pc] = loc
+			prof.Location = append(prof.Location, loc)
+			return loc
+		}
+	)
+	var visit func(n *profileNode, callers []*profile.Location)
+	visit = func(n *profileNode, callers []*profile.Location) {
+		frame := location(function(n.name, n.address), 0)
+		stack := append([]*profile.Location{frame}, callers...)
+		if n.selfGas > 0 {
+			prof.Sample = append(prof.Sample, &profile.Sample{Location: stack, Value: []int64{int64(n.selfGas), 0}})
+		}
+		for _, op := range n.sortedOps() {
+			stat := n.ops[op]
+			leaf := location(function(op.op.String(), n.address), op.pc)
+			prof.Sample = append(prof.Sample, &profile.Sample{
+				Location: append([]*profile.Location{leaf}, stack...),
+				Value:    []int64{int64(stat.gas), int64(stat.count)},
+			})
+		}
+		for _, child := range n.order {
+			visit(child, stack)
+		}
+	}
+	visit(t.root, nil)
+
+	if err := prof.CheckValid(); err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := prof.Write(&out); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *gasProfileTracer) Stop(err error) {
+	t.reason = err
+	t.interrupt.Store(true)
+}
@@ -125,6 +125,80 @@ func (ec *Client) GetProof(ctx context.Context, account common.Address, keys []s
 	return &result, err
 }
 
+// AccountState is the result of a GetAccount or GetAccounts operation.
+type AccountState struct {
+	Address     common.Address
+	Nonce       uint64
+	Balance     *big.Int
+	CodeHash    common.Hash
+	StorageRoot common.Hash
+	Storage     map[common.Hash]common.Hash // Requested storage slots, GetAccounts only
+}
+
+// AccountQuery selects an account and some of its storage slots to be retrieved
+// by GetAccounts.
+type AccountQuery struct {
+	Address     common.Address
+	StorageKeys []common.Hash
+}
+
+type accountState struct {
+	Address     common.Address              `json:"address"`
+	Nonce       hexutil.Uint64              `json:"nonce"`
+	Balance     *hexutil.Big                `json:"balance"`
+	CodeHash    common.Hash                 `json:"codeHash"`
+	StorageRoot common.Hash                 `json:"storageRoot"`
+	Storage     map[common.Hash]common.Hash `json:"storage"`
+}
+
+func (s *accountState) toAccountState() *AccountState {
+	return &AccountState{
+		Address:     s.Address,
+		Nonce:       uint64(s.Nonce),
+		Balance:     s.Balance.ToInt(),
+		CodeHash:    s.CodeHash,
+		StorageRoot: s.StorageRoot,
+		Storage:     s.Storage,
+	}
+}
+
+// GetAccount returns the nonce, balance, code hash and storage root of the specified
+// account. The block number can be nil, in which case the state is taken from the
+// latest known block.
+func (ec *Client) GetAccount(ctx context.Context, account common.Address, blockNumber *big.Int) (*AccountState, error) {
+	var res accountState
+	if err := ec.c.CallContext(ctx, &res, "qrl_getAccount", account, toBlockNumArg(blockNumber)); err != nil {
+		return nil, err
+	}
+	return res.toAccountState(), nil
+}
+
+// GetAccounts returns the state of many accounts along with the requested storage
+// slots of each, all taken from the same block. The block number can be nil, in
+// which case the state is taken from the latest known block.
+func (ec *Client) GetAccounts(ctx context.Context, queries []AccountQuery, blockNumber *big.Int) ([]*AccountState, error) {
+	type accountQuery struct {
+		Address     common.Address `json:"address"`
+		StorageKeys []string       `json:"storageKeys"`
+	}
+	args := make([]accountQuery, len(queries))
+	for i, query := range queries {
+		args[i] = accountQuery{Address: query.Address, StorageKeys: make([]string, len(query.StorageKeys))}
+		for j, key := range query.StorageKeys {
+			args[i].StorageKeys[j] = key.Hex()
+		}
+	}
+	var res []*accountState
+	if err := ec.c.CallContext(ctx, &res, "qrl_getAccounts", args, toBlockNumArg(blockNumber)); err != nil {
+		return nil, err
+	}
+	states := make([]*AccountState, len(res))
+	for i, state := range res {
+		states[i] = state.toAccountState()
+	}
+	return states, nil
+}
+
 // CallContract executes a message call transaction, which is directly executed in the VM
 // of the node, but never mined into the blockchain.
 //
@@ -130,6 +130,10 @@ func TestGzondClient(t *testing.T) {
 			"TestGetProofCanonicalizeKeys",
 			func(t *testing.T) { testGetProofCanonicalizeKeys(t, client) },
 		},
+		{
+			"TestGetAccounts",
+			func(t *testing.T) { testGetAccounts(t, client) },
+		},
 		{
 			"TestGCStats",
 			func(t *testing.T) { testGCStats(t, client) },
@@ -230,6 +234,50 @@ func testAccessList(t *testing.T, client *rpc.Client) {
 	}
 }
 
+func testGetAccounts(t *testing.T, client *rpc.Client) {
+	zc := New(client)
+
+	account, err := zc.GetAccount(context.Background(), testContract, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.Address != testContract || account.Nonce != 1 || account.Balance.Sign() != 0 {
+		t.Fatalf("account mismatch: have %+v", account)
+	}
+	if have, want := account.CodeHash, crypto.Keccak256Hash([]byte{0x13, 0x37}); have != want {
+		t.Fatalf("code hash mismatch: have %x, want %x", have, want)
+	}
+	if account.StorageRoot != types.EmptyRootHash || account.Storage != nil {
+		t.Fatalf("storage mismatch: have root %x, slots %v", account.StorageRoot, account.Storage)
+	}
+	accounts, err := zc.GetAccounts(context.Background(), []AccountQuery{
+		{Address: testAddr, StorageKeys: []common.Hash{testSlot, {}}},
+		{Address: testEmpty},
+		{Address: zeroAddr},
+	}, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts) != 3 {
+		t.Fatalf("account count mismatch: have %d, want 3", len(accounts))
+	}
+	if accounts[0].Balance.Cmp(testBalance) != 0 {
+		t.Fatalf("balance mismatch: have %v, want %v", accounts[0].Balance, testBalance)
+	}
+	if accounts[0].StorageRoot == types.EmptyRootHash {
+		t.Fatalf("storage root of account with storage is empty")
+	}
+	if have := accounts[0].Storage; len(have) != 2 || have[testSlot] != testValue || have[common.Hash{}] != (common.Hash{}) {
+		t.Fatalf("storage mismatch: have %v", have)
+	}
+	if accounts[1].Balance.Cmp(big.NewInt(1)) != 0 || accounts[1].CodeHash != types.EmptyCodeHash || accounts[1].Storage != nil {
+		t.Fatalf("empty account mismatch: have %+v", accounts[1])
+	}
+	if accounts[2].Address != zeroAddr || accounts[2].CodeHash != types.EmptyCodeHash || accounts[2].StorageRoot != types.EmptyRootHash {
+		t.Fatalf("non-existent account mismatch: have %+v", accounts[2])
+	}
+}
+
 func testGetProof(t *testing.T, client *rpc.Client, addr common.Address) {
 	zc := New(client)
 	qrlcl := qrlclient.NewClient(client)
@@ -43,6 +43,13 @@ type KeyValueStater interface {
 	Stat(property string) (string, error)
 }
 
+// KeyValueSyncer wraps the SyncKeyValue method of a backing data store.
+type KeyValueSyncer interface {
+	// SyncKeyValue ensures that all pending writes are flushed to disk,
+	// guaranteeing data durability up to the point.
+	SyncKeyValue() error
+}
+
 // Compacter wraps the Compact method of a backing data store.
 type Compacter interface {
 	// Compact flattens the underlying data store for the given key range. In essence,
@@ -61,6 +68,7 @@ type KeyValueStore interface {
 	KeyValueReader
 	KeyValueWriter
 	KeyValueStater
+	KeyValueSyncer
 	Batcher
 	Iteratee
 	Compacter
@@ -186,6 +194,7 @@ type Database interface {
 	Batcher
 	Iteratee
 	Stater
+	KeyValueSyncer
 	Compacter
 	Snapshotter
 	io.Closer
@@ -259,6 +259,17 @@ func (db *Database) Compact(start []byte, limit []byte) error {
 	return db.db.CompactRange(util.Range{Start: start, Limit: limit})
 }
 
+// SyncKeyValue flushes all pending writes in the write-ahead-log to disk,
+// ensuring data durability up to that point.
+func (db *Database) SyncKeyValue() error {
+	// LevelDB has no dedicated operation to sync the write-ahead-log and
+	// there's no key reserved to issue a synced write with. Writes block
+	// until they are written to the log though, so they're only lost on
+	// power failure or system crash, which is deemed acceptable for the
+	// legacy backend.
+	return nil
+}
+
 // Path returns the path to the database directory.
 func (db *Database) Path() string {
 	return db.fn
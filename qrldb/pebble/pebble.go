@@ -438,6 +438,16 @@ func (d *Database) Compact(start []byte, limit []byte) error {
 	return d.db.Compact(start, limit, true) // Parallelization is preferred
 }
 
+// SyncKeyValue flushes all pending writes in the write-ahead-log to disk,
+// ensuring data durability up to that point.
+func (d *Database) SyncKeyValue() error {
+	// The log entry is not written to the database, only to the write-ahead-log.
+	// Writing it in sync mode flushes all the writes preceding it.
+	b := d.db.NewBatch()
+	b.LogData(nil, nil)
+	return d.db.Apply(b, pebble.Sync)
+}
+
 // Path returns the path to the database directory.
 func (d *Database) Path() string {
 	return d.fn
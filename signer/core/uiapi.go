@@ -131,7 +131,7 @@ func (s *UIServerAPI) ImportRawKey(hexSeed string, password string) (accounts.Ac
 		return accounts.Account{}, fmt.Errorf("password requirements not met: %v", err)
 	}
 	// No error
-	return fetchKeystore(s.am).ImportMLDSA87(key, password)
+	return fetchKeystore(s.am).ImportWallet(key, password)
 }
 
 // OpenWallet initiates a hardware wallet opening procedure, establishing a USB
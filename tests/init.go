@@ -29,8 +29,18 @@ var Forks = map[string]*params.ChainConfig{
 	"Shanghai": {
 		ChainID: big.NewInt(1),
 	},
+	"Cancun": {
+		ChainID:    big.NewInt(1),
+		CancunTime: u64(0),
+	},
+	"ShanghaiToCancunAtTime15k": {
+		ChainID:    big.NewInt(1),
+		CancunTime: u64(15_000),
+	},
 }
 
+func u64(val uint64) *uint64 { return &val }
+
 // AvailableForks returns the set of defined fork names
 func AvailableForks() []string {
 	var availableForks []string
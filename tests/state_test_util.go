@@ -26,7 +26,6 @@ import (
 	"strings"
 
 	walletcommon "github.com/theQRL/go-qrllib/wallet/common"
-	walletmldsa87 "github.com/theQRL/go-qrllib/wallet/ml_dsa_87"
 	"github.com/theQRL/go-zond/common"
 	"github.com/theQRL/go-zond/common/hexutil"
 	"github.com/theQRL/go-zond/common/math"
@@ -37,6 +36,7 @@ import (
 	"github.com/theQRL/go-zond/core/types"
 	"github.com/theQRL/go-zond/core/vm"
 	"github.com/theQRL/go-zond/crypto"
+	"github.com/theQRL/go-zond/crypto/pqcrypto"
 	"github.com/theQRL/go-zond/params"
 	"github.com/theQRL/go-zond/qrldb"
 	"github.com/theQRL/go-zond/rlp"
@@ -360,7 +360,7 @@ func (tx *stTransaction) toMessage(ps stPostState, baseFee *big.Int) (*core.Mess
 			return nil, fmt.Errorf("failed to convert tx.Seed string into extendedSeed: %v", err)
 		}
 		// Derive sender from key if needed.
-		key, err := walletmldsa87.NewWalletFromExtendedSeed(extendedSeed)
+		key, err := pqcrypto.WalletFromExtendedSeed(extendedSeed)
 		if err != nil {
 			return nil, fmt.Errorf("invalid seed: %v", err)
 		}
@@ -790,6 +790,7 @@ func (s *spongeDb) NewBatch() qrldb.Batch                    { return &spongeBat
 func (s *spongeDb) NewBatchWithSize(size int) qrldb.Batch    { return &spongeBatch{s} }
 func (s *spongeDb) NewSnapshot() (qrldb.Snapshot, error)     { panic("implement me") }
 func (s *spongeDb) Stat(property string) (string, error)     { panic("implement me") }
+func (s *spongeDb) SyncKeyValue() error                      { return nil }
 func (s *spongeDb) Compact(start []byte, limit []byte) error { panic("implement me") }
 func (s *spongeDb) Close() error                             { return nil }
 func (s *spongeDb) Put(key []byte, value []byte) error {